@@ -5,8 +5,10 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/keep-network/keep-common/pkg/chain/celo/celoutil"
+	"github.com/keep-network/keep-common/pkg/metrics"
 	"github.com/keep-network/keep-ecdsa/config"
 	"github.com/keep-network/keep-ecdsa/pkg/chain"
 	"github.com/keep-network/keep-ecdsa/pkg/chain/celo"
@@ -62,6 +64,16 @@ func connectChain(
 	return celoChain, operatorKeys, nil
 }
 
+// observeChainMetrics exposes metrics collected by the chain handle in the
+// given registry. The Celo chain handle does not collect any metrics.
+func observeChainMetrics(
+	ctx context.Context,
+	registry *metrics.Registry,
+	chainHandle chain.Handle,
+	tick time.Duration,
+) {
+}
+
 func extractKeyFilePassword(config *config.Config) string {
 	return config.Celo.Account.KeyFilePassword
 }
@@ -5,13 +5,20 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"path/filepath"
+	"time"
 
 	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
+	"github.com/keep-network/keep-common/pkg/metrics"
 	"github.com/keep-network/keep-ecdsa/config"
 	"github.com/keep-network/keep-ecdsa/pkg/chain"
 	"github.com/keep-network/keep-ecdsa/pkg/chain/ethereum"
 )
 
+// blockCheckpointsFileName is the name of the file in the storage directory
+// keeping the last blocks processed by keep event subscriptions.
+const blockCheckpointsFileName = "block_checkpoints.json"
+
 func offlineChain(
 	config *config.Config,
 ) (chain.OfflineHandle, error) {
@@ -111,10 +118,25 @@ func connectChain(
 		}
 	}
 
+	blockCheckpoints, err := chain.NewFileBlockCheckpointStore(
+		filepath.Join(config.Storage.DataDir, blockCheckpointsFileName),
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"failed to initialize block checkpoints store: [%v]",
+			err,
+		)
+	}
+
 	ethereumChain, err := ethereum.Connect(
 		ctx,
 		ethereumKey,
 		&config.Ethereum,
+		append(
+			ethereumClientOptions(config),
+			ethereum.WithBlockCheckpointStore(blockCheckpoints),
+			ethereum.WithTransactionMetrics(ethereum.NewTransactionMetrics()),
+		)...,
 	)
 	if err != nil {
 		return nil, nil, fmt.Errorf(
@@ -131,6 +153,142 @@ func connectChain(
 	return ethereumChain, operatorKeys, nil
 }
 
+// ethereumClientOptions returns options customizing the Ethereum chain handle
+// according to the Ethereum client configuration. Options of settings which
+// are not configured are not returned so defaults of the chain handle are
+// used.
+func ethereumClientOptions(
+	config *config.Config,
+) []ethereum.ConnectOption {
+	clientConfig := config.EthereumClient
+
+	var options []ethereum.ConnectOption
+
+	if clientConfig.MaxFeePerGas != nil {
+		feeStrategy := &ethereum.FeeStrategy{
+			MaxFeePerGas: clientConfig.MaxFeePerGas.Int,
+		}
+		if clientConfig.MaxPriorityFeePerGas != nil {
+			feeStrategy.MaxPriorityFeePerGas = clientConfig.MaxPriorityFeePerGas.Int
+		}
+
+		options = append(options, ethereum.WithFeeStrategy(feeStrategy))
+	}
+
+	if clientConfig.MaxSubmissionGasPrice != nil {
+		options = append(
+			options,
+			ethereum.WithMaxSubmissionGasPrice(
+				clientConfig.MaxSubmissionGasPrice.Int,
+			),
+		)
+	}
+
+	if clientConfig.SubmissionMaxResubmissions > 0 {
+		checkInterval := clientConfig.SubmissionMiningCheckInterval.ToDuration()
+		if checkInterval == 0 {
+			checkInterval = ethereum.DefaultMiningCheckInterval
+			if config.Ethereum.MiningCheckInterval != 0 {
+				checkInterval = time.Duration(
+					config.Ethereum.MiningCheckInterval,
+				) * time.Second
+			}
+		}
+
+		options = append(
+			options,
+			ethereum.WithSubmissionMiningWaiter(
+				checkInterval,
+				clientConfig.SubmissionMaxResubmissions,
+			),
+		)
+	}
+
+	submitPublicKeyRetries := clientConfig.SubmitPublicKeyRetries
+	submitPublicKeyRetryDelay := clientConfig.SubmitPublicKeyRetryDelay.ToDuration()
+	if submitPublicKeyRetries != 0 || submitPublicKeyRetryDelay != 0 {
+		if submitPublicKeyRetries == 0 {
+			submitPublicKeyRetries = ethereum.DefaultSubmitPublicKeyRetries
+		}
+		if submitPublicKeyRetryDelay == 0 {
+			submitPublicKeyRetryDelay = ethereum.DefaultSubmitPublicKeyRetryDelay
+		}
+
+		options = append(
+			options,
+			ethereum.WithSubmitPublicKeyRetry(
+				submitPublicKeyRetries,
+				submitPublicKeyRetryDelay,
+			),
+		)
+	}
+
+	if clientConfig.SubmitSignatureGasMargin > 0 {
+		options = append(
+			options,
+			ethereum.WithSubmitSignatureGasMargin(
+				clientConfig.SubmitSignatureGasMargin,
+			),
+		)
+	}
+
+	if clientConfig.SubmissionConfirmations > 0 {
+		confirmationTimeout := clientConfig.SubmissionConfirmationTimeout.ToDuration()
+		if confirmationTimeout == 0 {
+			confirmationTimeout = ethereum.DefaultSubmissionConfirmationTimeout
+		}
+
+		options = append(
+			options,
+			ethereum.WithSubmissionConfirmations(
+				clientConfig.SubmissionConfirmations,
+				confirmationTimeout,
+			),
+		)
+	}
+
+	if clientConfig.PastEventsChunkSize > 0 {
+		options = append(
+			options,
+			ethereum.WithPastEventsChunking(
+				clientConfig.PastEventsChunkSize,
+				clientConfig.PastEventsConcurrency,
+			),
+		)
+	}
+
+	readRetries := clientConfig.ReadRetries
+	readRetryBackoff := clientConfig.ReadRetryBackoff.ToDuration()
+	if readRetries != 0 || readRetryBackoff != 0 {
+		if readRetries == 0 {
+			readRetries = ethereum.DefaultReadRetries
+		}
+		if readRetryBackoff == 0 {
+			readRetryBackoff = ethereum.DefaultReadRetryBackoff
+		}
+
+		options = append(
+			options,
+			ethereum.WithReadRetry(readRetries, readRetryBackoff),
+		)
+	}
+
+	return options
+}
+
+// observeChainMetrics exposes metrics collected by the chain handle in the
+// given registry.
+func observeChainMetrics(
+	ctx context.Context,
+	registry *metrics.Registry,
+	chainHandle chain.Handle,
+	tick time.Duration,
+) {
+	if source, ok := chainHandle.(ethereum.TransactionMetricsSource); ok {
+		source.TransactionMetrics().Observe(ctx, registry, tick)
+	}
+}
+
 func extractKeyFilePassword(config *config.Config) string {
 	return config.Ethereum.Account.KeyFilePassword
 }
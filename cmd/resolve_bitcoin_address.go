@@ -4,7 +4,6 @@ import (
 	"fmt"
 
 	"github.com/keep-network/keep-ecdsa/config"
-	"github.com/keep-network/keep-ecdsa/pkg/chain/bitcoin"
 	"github.com/keep-network/keep-ecdsa/pkg/extensions/tbtc/recovery"
 
 	"github.com/urfave/cli"
@@ -56,7 +55,10 @@ func ResolveBitcoinBeneficiaryAddress(c *cli.Context) error {
 		)
 	}
 
-	bitcoinHandle := bitcoin.Connect(tbtcConfig.Bitcoin.ElectrsURLWithDefault())
+	bitcoinHandle, err := tbtcConfig.Bitcoin.ConnectElectrs()
+	if err != nil {
+		return fmt.Errorf("failed to connect to electrs API: [%w]", err)
+	}
 
 	beneficiaryAddress, err := recovery.ResolveAddress(
 		tbtcConfig.Bitcoin.BeneficiaryAddress,
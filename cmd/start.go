@@ -3,6 +3,9 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/keep-network/keep-ecdsa/pkg/metrics"
@@ -19,7 +22,7 @@ import (
 	"github.com/keep-network/keep-core/pkg/net/libp2p"
 	"github.com/keep-network/keep-core/pkg/net/retransmission"
 	"github.com/keep-network/keep-ecdsa/config"
-	"github.com/keep-network/keep-ecdsa/pkg/chain/bitcoin"
+	ecdsachain "github.com/keep-network/keep-ecdsa/pkg/chain"
 	"github.com/keep-network/keep-ecdsa/pkg/client"
 	"github.com/keep-network/keep-ecdsa/pkg/extensions/tbtc/recovery"
 	"github.com/keep-network/keep-ecdsa/pkg/firewall"
@@ -34,6 +37,10 @@ var StartCommand cli.Command
 
 const startDescription = `Starts the Keep tECDSA client in the foreground.`
 
+// clientShutdownTimeout determines how long the client waits for running
+// deposit monitorings to exit once it is requested to shut down.
+const clientShutdownTimeout = 1 * time.Minute
+
 // Constants related with network.
 //
 // In order to communicate, nodes in the network should have a connection
@@ -82,7 +89,12 @@ func Start(c *cli.Context) error {
 		return fmt.Errorf("failed while reading config file: [%v]", err)
 	}
 
-	ctx := context.Background()
+	ctx, stop := signal.NotifyContext(
+		context.Background(),
+		os.Interrupt,
+		syscall.SIGTERM,
+	)
+	defer stop()
 
 	chainHandle, operatorKeys, err := connectChain(ctx, config)
 	if err != nil {
@@ -143,7 +155,7 @@ func Start(c *cli.Context) error {
 
 	err = config.Extensions.TBTC.Bitcoin.Validate()
 	if err != nil {
-		if (bitcoin.Config{}) == config.Extensions.TBTC.Bitcoin {
+		if config.Extensions.TBTC.Bitcoin.IsZero() {
 			logger.Warnf("missing bitcoin configuration for tbtc extension: [%v]", err)
 		} else {
 			logger.Errorf("misconfigured bitcoin configured for tbtc extension: [%v]", err)
@@ -170,20 +182,30 @@ func Start(c *cli.Context) error {
 		networkProvider,
 		stakeMonitor,
 		chainHandle.OperatorID().String(),
+		chainHandle,
 		clientHandle,
 	)
 	initializeDiagnostics(config, networkProvider)
 
 	logger.Info("client started")
 
-	select {
-	case <-ctx.Done():
-		if err != nil {
-			return err
-		}
+	<-ctx.Done()
 
-		return fmt.Errorf("unexpected context cancellation")
+	logger.Info("shutting down client")
+
+	shutdownCtx, cancelShutdown := context.WithTimeout(
+		context.Background(),
+		clientShutdownTimeout,
+	)
+	defer cancelShutdown()
+
+	if err := clientHandle.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("could not shut down client gracefully: [%v]", err)
 	}
+
+	logger.Info("client shut down")
+
+	return nil
 }
 
 func initializeMetrics(
@@ -192,6 +214,7 @@ func initializeMetrics(
 	netProvider net.Provider,
 	stakeMonitor chain.StakeMonitor,
 	address string,
+	chainHandle ecdsachain.Handle,
 	clientHandle *client.Handle,
 ) {
 	registry, isConfigured := coreMetrics.Initialize(
@@ -237,6 +260,20 @@ func initializeMetrics(
 		clientHandle,
 		time.Duration(config.Metrics.ClientMetricsTick)*time.Second,
 	)
+
+	metrics.ObserveTBTCMonitorings(
+		ctx,
+		registry,
+		clientHandle,
+		time.Duration(config.Metrics.ClientMetricsTick)*time.Second,
+	)
+
+	chainMetricsTick := time.Duration(config.Metrics.ClientMetricsTick) * time.Second
+	if chainMetricsTick <= 0 {
+		chainMetricsTick = metrics.DefaultClientMetricsTick
+	}
+
+	observeChainMetrics(ctx, registry, chainHandle, chainMetricsTick)
 }
 
 func initializeDiagnostics(
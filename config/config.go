@@ -9,6 +9,7 @@ import (
 	"github.com/keep-network/keep-common/pkg/chain/celo"
 	"github.com/keep-network/keep-common/pkg/chain/ethereum"
 	"github.com/keep-network/keep-core/pkg/net/libp2p"
+	configtime "github.com/keep-network/keep-ecdsa/config/time"
 	"github.com/keep-network/keep-ecdsa/pkg/client"
 	"github.com/keep-network/keep-ecdsa/pkg/ecdsa/tss"
 	"github.com/keep-network/keep-ecdsa/pkg/extensions/tbtc"
@@ -23,6 +24,7 @@ const PasswordEnvVariable = "KEEP_ETHEREUM_PASSWORD"
 // Config is the top level config structure.
 type Config struct {
 	Ethereum               ethereum.Config
+	EthereumClient         EthereumClient
 	Celo                   celo.Config
 	SanctionedApplications SanctionedApplications
 	Storage                Storage
@@ -34,6 +36,63 @@ type Config struct {
 	Extensions             Extensions
 }
 
+// EthereumClient stores configuration of the Ethereum chain client which is
+// specific to keep contracts and not covered by the Ethereum configuration.
+type EthereumClient struct {
+	// Maximum fee per gas, including the base fee, of transactions submitted
+	// to keep contracts. If set and the Ethereum node supports EIP-1559,
+	// transactions are submitted as dynamic fee transactions. Otherwise,
+	// legacy transactions priced at the gas price suggested by the Ethereum
+	// node are submitted.
+	MaxFeePerGas *ethereum.Wei
+	// Maximum fee per gas paid on top of the base fee. If not set, the
+	// priority fee suggested by the Ethereum node is used.
+	MaxPriorityFeePerGas *ethereum.Wei
+	// Maximum gas price public key and signature submissions are sent with.
+	// Submissions are postponed while the gas price is higher. If not set,
+	// they are sent regardless of the gas price.
+	MaxSubmissionGasPrice *ethereum.Wei
+
+	// Maximum number of times public key and signature submissions which
+	// are not mined are resubmitted with a higher gas price. Zero or no
+	// value resubmits them until the max gas price is reached.
+	SubmissionMaxResubmissions int
+	// Interval in which mining status of public key and signature
+	// submissions is checked. If not set, the mining check interval of the
+	// Ethereum configuration is used.
+	SubmissionMiningCheckInterval configtime.Duration
+
+	// Maximum number of attempts to submit a public key to a keep contract
+	// and the delay between consecutive attempts. If not set, defaults of
+	// the Ethereum chain client are used.
+	SubmitPublicKeyRetries    int
+	SubmitPublicKeyRetryDelay configtime.Duration
+
+	// Safety margin added on top of the gas estimate of a signature
+	// submission, expressed as a fraction of the estimate. Zero or no value
+	// uses the default of the Ethereum chain client.
+	SubmitSignatureGasMargin float64
+
+	// Number of confirmations public key and signature submissions are
+	// waited for and the maximum time of waiting. Zero or no number of
+	// confirmations does not wait for submissions to be mined.
+	SubmissionConfirmations       uint64
+	SubmissionConfirmationTimeout configtime.Duration
+
+	// Maximum number of blocks past keep events are fetched for in a single
+	// call and the maximum number of such calls executed at the same time.
+	// Zero or no chunk size fetches past events in a single call.
+	PastEventsChunkSize   uint64
+	PastEventsConcurrency int
+
+	// Maximum number of attempts of frequently executed read-only calls to
+	// keep contracts and the delay before the second attempt, doubled after
+	// each consecutive attempt. If not set, defaults of the Ethereum chain
+	// client are used.
+	ReadRetries      int
+	ReadRetryBackoff configtime.Duration
+}
+
 // SanctionedApplications contains addresses of applications approved by the
 // operator.
 type SanctionedApplications struct {
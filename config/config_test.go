@@ -55,6 +55,62 @@ func TestReadConfig(t *testing.T) {
 				"TBTCSystem":             "0xda4c869B9073deac021344fd592c1BB0DC6Fc9a5",
 			},
 		},
+		"EthereumClient.MaxFeePerGas": {
+			readValueFunc: func(c *Config) interface{} { return c.EthereumClient.MaxFeePerGas.Int },
+			expectedValue: big.NewInt(200000000000),
+		},
+		"EthereumClient.MaxPriorityFeePerGas": {
+			readValueFunc: func(c *Config) interface{} { return c.EthereumClient.MaxPriorityFeePerGas.Int },
+			expectedValue: big.NewInt(2000000000),
+		},
+		"EthereumClient.MaxSubmissionGasPrice": {
+			readValueFunc: func(c *Config) interface{} { return c.EthereumClient.MaxSubmissionGasPrice.Int },
+			expectedValue: big.NewInt(500000000000),
+		},
+		"EthereumClient.SubmissionMaxResubmissions": {
+			readValueFunc: func(c *Config) interface{} { return c.EthereumClient.SubmissionMaxResubmissions },
+			expectedValue: 3,
+		},
+		"EthereumClient.SubmissionMiningCheckInterval": {
+			readValueFunc: func(c *Config) interface{} { return c.EthereumClient.SubmissionMiningCheckInterval.ToDuration() },
+			expectedValue: 2 * time.Minute,
+		},
+		"EthereumClient.SubmitPublicKeyRetries": {
+			readValueFunc: func(c *Config) interface{} { return c.EthereumClient.SubmitPublicKeyRetries },
+			expectedValue: 5,
+		},
+		"EthereumClient.SubmitPublicKeyRetryDelay": {
+			readValueFunc: func(c *Config) interface{} { return c.EthereumClient.SubmitPublicKeyRetryDelay.ToDuration() },
+			expectedValue: 30 * time.Second,
+		},
+		"EthereumClient.SubmitSignatureGasMargin": {
+			readValueFunc: func(c *Config) interface{} { return c.EthereumClient.SubmitSignatureGasMargin },
+			expectedValue: 0.35,
+		},
+		"EthereumClient.SubmissionConfirmations": {
+			readValueFunc: func(c *Config) interface{} { return c.EthereumClient.SubmissionConfirmations },
+			expectedValue: uint64(6),
+		},
+		"EthereumClient.SubmissionConfirmationTimeout": {
+			readValueFunc: func(c *Config) interface{} { return c.EthereumClient.SubmissionConfirmationTimeout.ToDuration() },
+			expectedValue: 15 * time.Minute,
+		},
+		"EthereumClient.PastEventsChunkSize": {
+			readValueFunc: func(c *Config) interface{} { return c.EthereumClient.PastEventsChunkSize },
+			expectedValue: uint64(5000),
+		},
+		"EthereumClient.PastEventsConcurrency": {
+			readValueFunc: func(c *Config) interface{} { return c.EthereumClient.PastEventsConcurrency },
+			expectedValue: 4,
+		},
+		"EthereumClient.ReadRetries": {
+			readValueFunc: func(c *Config) interface{} { return c.EthereumClient.ReadRetries },
+			expectedValue: 5,
+		},
+		"EthereumClient.ReadRetryBackoff": {
+			readValueFunc: func(c *Config) interface{} { return c.EthereumClient.ReadRetryBackoff.ToDuration() },
+			expectedValue: 1 * time.Second,
+		},
 		"Storage.DataDir": {
 			readValueFunc: func(c *Config) interface{} { return c.Storage.DataDir },
 			expectedValue: "/my/secure/location",
@@ -95,6 +151,14 @@ func TestReadConfig(t *testing.T) {
 			readValueFunc: func(c *Config) interface{} { return c.Extensions.TBTC.GetLiquidationRecoveryTimeout() },
 			expectedValue: time.Duration(49 * 60 * 60 * 1000000000), // 49 hours in nanoseconds
 		},
+		"Extensions.TBTC.CircuitBreakerThreshold": {
+			readValueFunc: func(c *Config) interface{} { return c.Extensions.TBTC.CircuitBreakerThreshold },
+			expectedValue: 4,
+		},
+		"Extensions.TBTC.CircuitBreakerCooldown": {
+			readValueFunc: func(c *Config) interface{} { return c.Extensions.TBTC.GetCircuitBreakerCooldown() },
+			expectedValue: 45 * time.Minute,
+		},
 		"Extensions.TBTC.Bitcoin.ElectrsURL": {
 			readValueFunc: func(c *Config) interface{} { return *c.Extensions.TBTC.Bitcoin.ElectrsURL },
 			expectedValue: "example.com",
@@ -103,6 +167,22 @@ func TestReadConfig(t *testing.T) {
 			readValueFunc: func(c *Config) interface{} { return c.Extensions.TBTC.Bitcoin.ElectrsURLWithDefault() },
 			expectedValue: "example.com",
 		},
+		"Extensions.TBTC.Bitcoin.ElectrsTimeout": {
+			readValueFunc: func(c *Config) interface{} { return c.Extensions.TBTC.Bitcoin.ElectrsTimeout.ToDuration() },
+			expectedValue: 45 * time.Second,
+		},
+		"Extensions.TBTC.Bitcoin.ElectrsRequestsPerSecond": {
+			readValueFunc: func(c *Config) interface{} { return c.Extensions.TBTC.Bitcoin.ElectrsRequestsPerSecond },
+			expectedValue: 2.5,
+		},
+		"Extensions.TBTC.Bitcoin.ElectrsRequestsBurst": {
+			readValueFunc: func(c *Config) interface{} { return c.Extensions.TBTC.Bitcoin.ElectrsRequestsBurst },
+			expectedValue: 4,
+		},
+		"Extensions.TBTC.Bitcoin.ElectrsFeeEstimatesCacheTTL": {
+			readValueFunc: func(c *Config) interface{} { return c.Extensions.TBTC.Bitcoin.ElectrsFeeEstimatesCacheTTL.ToDuration() },
+			expectedValue: 20 * time.Second,
+		},
 		"Extensions.TBTC.Bitcoin.BeneficiaryAddress": {
 			readValueFunc: func(c *Config) interface{} { return c.Extensions.TBTC.Bitcoin.BeneficiaryAddress },
 			expectedValue: "xpub6Cg41S21VrxkW1WBTZJn95KNpHozP2Xc6AhG27ZcvZvH8XyNzunEqLdk9dxyXQUoy7ALWQFNn5K1me74aEMtS6pUgNDuCYTTMsJzCAk9sk1",
@@ -0,0 +1,93 @@
+package chain
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// DefaultBatchConcurrency is the default maximum number of calls a batch
+// operation issues concurrently.
+const DefaultBatchConcurrency = 10
+
+// BatchError is returned by batch operations when some of the individual
+// calls failed. Results of the successful calls are still returned along with
+// the error.
+type BatchError struct {
+	Errors []error
+}
+
+func (be *BatchError) Error() string {
+	messages := make([]string, len(be.Errors))
+	for i, err := range be.Errors {
+		messages[i] = err.Error()
+	}
+
+	return fmt.Sprintf(
+		"[%v] batch calls failed: [%v]",
+		len(be.Errors),
+		strings.Join(messages, "; "),
+	)
+}
+
+// GetMembersBatch fetches members of the given keeps using the provided
+// getMembersFn, with at most concurrency calls running at once. It returns
+// members of all keeps for which the call succeeded. If any of the calls
+// failed, a *BatchError holding all failures is returned as well.
+func GetMembersBatch(
+	keepIDs []ID,
+	concurrency int,
+	getMembersFn func(keepID ID) ([]ID, error),
+) (map[ID][]ID, error) {
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency
+	}
+
+	var (
+		mutex   sync.Mutex
+		members = make(map[ID][]ID, len(keepIDs))
+		errors  = make([]error, 0)
+	)
+
+	keepIDsChan := make(chan ID)
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for keepID := range keepIDsChan {
+				keepMembers, err := getMembersFn(keepID)
+
+				mutex.Lock()
+				if err != nil {
+					errors = append(
+						errors,
+						fmt.Errorf(
+							"failed to get members of keep [%v]: [%w]",
+							keepID,
+							err,
+						),
+					)
+				} else {
+					members[keepID] = keepMembers
+				}
+				mutex.Unlock()
+			}
+		}()
+	}
+
+	for _, keepID := range keepIDs {
+		keepIDsChan <- keepID
+	}
+	close(keepIDsChan)
+
+	wg.Wait()
+
+	if len(errors) > 0 {
+		return members, &BatchError{Errors: errors}
+	}
+
+	return members, nil
+}
@@ -0,0 +1,106 @@
+package chain
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type testID string
+
+func (ti testID) String() string                { return string(ti) }
+func (ti testID) ChainName() string             { return "test" }
+func (ti testID) IsForChain(handle Handle) bool { return false }
+
+func TestGetMembersBatch_ConcurrencyLimit(t *testing.T) {
+	keepIDs := make([]ID, 20)
+	for i := range keepIDs {
+		keepIDs[i] = testID(fmt.Sprintf("keep-%v", i))
+	}
+
+	concurrency := 3
+
+	var running, maxRunning int32
+	getMembersFn := func(keepID ID) ([]ID, error) {
+		current := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+			if current <= max ||
+				atomic.CompareAndSwapInt32(&maxRunning, max, current) {
+				break
+			}
+		}
+
+		time.Sleep(5 * time.Millisecond)
+
+		return []ID{keepID}, nil
+	}
+
+	members, err := GetMembersBatch(keepIDs, concurrency, getMembersFn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(members) != len(keepIDs) {
+		t.Errorf(
+			"unexpected number of results\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			len(keepIDs),
+			len(members),
+		)
+	}
+
+	if maxRunning > int32(concurrency) {
+		t.Errorf(
+			"concurrency limit exceeded\n"+
+				"expected at most: [%v]\n"+
+				"actual:           [%v]",
+			concurrency,
+			maxRunning,
+		)
+	}
+}
+
+func TestGetMembersBatch_PartialFailure(t *testing.T) {
+	keepIDs := []ID{testID("keep-1"), testID("keep-2"), testID("keep-3")}
+
+	getMembersFn := func(keepID ID) ([]ID, error) {
+		if keepID == testID("keep-2") {
+			return nil, fmt.Errorf("scripted failure")
+		}
+		return []ID{keepID}, nil
+	}
+
+	members, err := GetMembersBatch(keepIDs, 2, getMembersFn)
+
+	batchErr, ok := err.(*BatchError)
+	if !ok {
+		t.Fatalf("unexpected error type: [%T]", err)
+	}
+
+	expectedError := "[1] batch calls failed: " +
+		"[failed to get members of keep [keep-2]: [scripted failure]]"
+	if expectedError != batchErr.Error() {
+		t.Errorf(
+			"unexpected error\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedError,
+			batchErr.Error(),
+		)
+	}
+
+	if len(members) != 2 {
+		t.Errorf(
+			"unexpected number of results\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			2,
+			len(members),
+		)
+	}
+}
@@ -2,8 +2,10 @@ package bitcoin
 
 import (
 	"fmt"
+	"reflect"
 
 	"github.com/btcsuite/btcd/chaincfg"
+	configtime "github.com/keep-network/keep-ecdsa/config/time"
 )
 
 // Config stores configuration related to recovering BTC from a closed keep.
@@ -12,6 +14,23 @@ type Config struct {
 	MaxFeePerVByte     int32
 	BitcoinChainName   string
 	ElectrsURL         *string
+	// Interchangeable electrs API URLs used instead of ElectrsURL. Requests
+	// are sent to the next URL when the previous one fails.
+	ElectrsURLs []string
+	// If true, recovery transactions are never broadcast to the bitcoin
+	// network; they are only logged.
+	ElectrsDryRun bool
+	// Period after which electrs API calls are given up on. If not set,
+	// the default timeout is used.
+	ElectrsTimeout configtime.Duration
+	// Maximum average number of requests per second sent to the electrs API
+	// and the maximum number of requests sent at once. If not set, the rate
+	// is not limited.
+	ElectrsRequestsPerSecond float64
+	ElectrsRequestsBurst     int
+	// Period for which fee estimates fetched from the electrs API are
+	// reused. If not set, fee estimates are fetched on every call.
+	ElectrsFeeEstimatesCacheTTL configtime.Duration
 }
 
 // Validate returns nil if the configuration is suitable for bitcoin recovery,
@@ -31,9 +50,17 @@ func (c Config) Validate() error {
 			err,
 		)
 	}
+	if _, err := c.electrsURLs(); err != nil {
+		return err
+	}
 	return nil
 }
 
+// IsZero returns true if no bitcoin configuration is provided.
+func (c Config) IsZero() bool {
+	return reflect.DeepEqual(c, Config{})
+}
+
 // ChainParams parses the net param name into the associated chaincfg.Params
 func (c Config) ChainParams() (*chaincfg.Params, error) {
 	switch c.BitcoinChainName {
@@ -66,3 +93,54 @@ func (c Config) ElectrsURLWithDefault() string {
 	}
 	return *c.ElectrsURL
 }
+
+// electrsURLs returns the configured electrs API URLs. If ElectrsURLs is not
+// configured, only the URL returned by ElectrsURLWithDefault is used.
+func (c Config) electrsURLs() ([]string, error) {
+	if c.ElectrsURLs == nil {
+		return []string{c.ElectrsURLWithDefault()}, nil
+	}
+
+	if len(c.ElectrsURLs) == 0 {
+		return nil, fmt.Errorf("at least one electrs URL is required; configure one at [Extensions.TBTC.Bitcoin.ElectrsURLs]")
+	}
+
+	if c.ElectrsURL != nil {
+		return nil, fmt.Errorf("electrs URLs are configured at both [Extensions.TBTC.Bitcoin.ElectrsURL] and [Extensions.TBTC.Bitcoin.ElectrsURLs]; configure only one of them")
+	}
+
+	return c.ElectrsURLs, nil
+}
+
+// ConnectElectrs connects to the electrs API configured at ElectrsURL or
+// ElectrsURLs, with the connection customized according to the rest of the
+// electrs configuration.
+func (c Config) ConnectElectrs() (Handle, error) {
+	apiURLs, err := c.electrsURLs()
+	if err != nil {
+		return nil, err
+	}
+
+	options := []Option{WithFailover(apiURLs[1:]...)}
+
+	if c.ElectrsDryRun {
+		options = append(options, WithDryRun())
+	}
+
+	if timeout := c.ElectrsTimeout.ToDuration(); timeout != 0 {
+		options = append(options, WithTimeout(timeout))
+	}
+
+	if c.ElectrsRequestsPerSecond != 0 {
+		options = append(
+			options,
+			WithRateLimit(c.ElectrsRequestsPerSecond, c.ElectrsRequestsBurst),
+		)
+	}
+
+	if ttl := c.ElectrsFeeEstimatesCacheTTL.ToDuration(); ttl != 0 {
+		options = append(options, WithFeeEstimatesCache(ttl))
+	}
+
+	return Connect(apiURLs[0], options...), nil
+}
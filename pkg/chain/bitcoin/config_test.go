@@ -0,0 +1,85 @@
+package bitcoin
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConfigConnectElectrs(t *testing.T) {
+	electrsURL := "example.org/api"
+
+	var tests = map[string]struct {
+		config           Config
+		expectedAPIURL   string
+		expectedFailover []string
+		expectError      bool
+	}{
+		"single URL": {
+			config:         Config{ElectrsURL: &electrsURL},
+			expectedAPIURL: electrsURL,
+		},
+		"multiple URLs": {
+			config: Config{
+				ElectrsURLs: []string{"first.example.org/api", "second.example.org/api"},
+			},
+			expectedAPIURL: "first.example.org/api",
+			expectedFailover: []string{
+				"first.example.org/api",
+				"second.example.org/api",
+			},
+		},
+		"single URL in URLs": {
+			config:         Config{ElectrsURLs: []string{"first.example.org/api"}},
+			expectedAPIURL: "first.example.org/api",
+		},
+		"empty URLs": {
+			config:      Config{ElectrsURLs: []string{}},
+			expectError: true,
+		},
+		"both URL and URLs": {
+			config: Config{
+				ElectrsURL:  &electrsURL,
+				ElectrsURLs: []string{"first.example.org/api"},
+			},
+			expectError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			handle, err := test.config.ConnectElectrs()
+			if test.expectError {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			electrs := handle.(*electrsConnection)
+
+			if electrs.apiURL != test.expectedAPIURL {
+				t.Errorf(
+					"unexpected API URL\nexpected: %v\nactual:   %v",
+					test.expectedAPIURL,
+					electrs.apiURL,
+				)
+			}
+
+			var actualFailover []string
+			if electrs.failover != nil {
+				actualFailover = electrs.failover.apiURLs
+			}
+
+			if !reflect.DeepEqual(test.expectedFailover, actualFailover) {
+				t.Errorf(
+					"unexpected failover URLs\nexpected: %v\nactual:   %v",
+					test.expectedFailover,
+					actualFailover,
+				)
+			}
+		})
+	}
+}
@@ -8,21 +8,42 @@ import (
 	"github.com/btcsuite/btcutil/hdkeychain"
 )
 
-// DeriveAddress uses the specified extended public key and address index to
-// derive an address string in the appropriate format at the specified address
-// index. The extended public key can be at any level. deriveAddress will take
-// the first child `/0` until a depth of 4 is reached, and then produce the
-// address at the supplied index. Thus, calling deriveAddress with an xpub
-// generated at m/44'/0' and passing the address index 5 will produce the
-// address at path m/44'/0'/0/0/5.
+const (
+	// ExternalChain is the index of the external chain used for receiving
+	// addresses, as per [BIP44].
+	//
+	// [BIP44]: https://github.com/bitcoin/bips/blob/master/bip-0044.mediawiki
+	ExternalChain uint32 = 0
+	// InternalChain is the index of the internal chain used for change
+	// addresses, as per [BIP44].
+	//
+	// [BIP44]: https://github.com/bitcoin/bips/blob/master/bip-0044.mediawiki
+	InternalChain uint32 = 1
+)
+
+// DeriveAddress uses the specified extended public key, chain and address index
+// to derive an address string in the appropriate format at the specified
+// address index. The extended public key can be at any level. deriveAddress
+// will take the first child `/0` until a depth of 3 is reached, then the
+// supplied chain, and then produce the address at the supplied index. Thus,
+// calling deriveAddress with an xpub generated at m/44'/0' and passing the
+// external chain 0 and the address index 5 will produce the address at path
+// m/44'/0'/0/0/5. Passing the internal chain 1 instead will produce the change
+// address at path m/44'/0'/0/1/5.
 //
 // In cases where the extended public key is at depth 4, meaning the external or
 // internal chain is already included, deriveAddress will directly derive the
-// address index at the existing depth.
+// address index at the existing depth and the supplied chain is ignored.
 //
 // deriveAddress does not support hardened child indexes (anything greater than
 // or equal to 2147483648, abbreviated as 0')
 //
+// The network of the returned address is determined by the supplied chain
+// parameters rather than inferred from the extended public key prefix, so
+// test network keys can be used with testnet, regtest, or signet parameters.
+// The prefix must still be consistent with the network: mainnet prefixes are
+// accepted only for mainnet and test network prefixes only for test networks.
+//
 // The returned address will be a p2pkh/p2sh address for prefixes xpub and tpub,
 // (i.e. prefixed by 1, m, or n), a p2wpkh-in-p2sh address for prefixes ypub or
 // upub (i.e., prefixed by 3 or 2), and a bech32 p2wpkh address for prefixes
@@ -37,47 +58,96 @@ import (
 // [BIP84]: https://github.com/bitcoin/bips/blob/master/bip-0084.mediawiki
 func DeriveAddress(
 	extendedPublicKey string,
+	chain uint32,
 	addressIndex uint32,
 	chainParams *chaincfg.Params,
 ) (string, error) {
-	extendedKey, err := hdkeychain.NewKeyFromString(extendedPublicKey)
+	requestedPublicKey, err := deriveChildPublicKey(
+		extendedPublicKey,
+		chain,
+		addressIndex,
+	)
 	if err != nil {
-		return "", fmt.Errorf(
-			"error parsing extended public key: [%s]",
-			err,
-		)
+		return "", err
 	}
 
-	externalChain := extendedKey
-	if externalChain.Depth() > 4 {
-		return "", fmt.Errorf("extended public key is deeper than 4, depth: %d", externalChain.Depth())
+	publicKeyDescriptor := extendedPublicKey[0:4]
+	if err := validatePublicKeyDescriptor(publicKeyDescriptor, chainParams); err != nil {
+		return "", err
 	}
-	for externalChain.Depth() < 4 {
-		// Descend the hierarchy at /0 until the external chain path, `m/*/*/*/0`.
-		// ex: If we get a `m/32'/5` extended key, we descend to `m/32'/5/0/0`.
-		externalChain, err = externalChain.Derive(0)
-		if err != nil {
-			return "", fmt.Errorf(
-				"error deriving external chain path /0 from extended key: [%s]",
-				err,
-			)
+
+	return encodeDerivedAddress(
+		extendedPublicKey,
+		requestedPublicKey,
+		chainParams,
+	)
+}
+
+// DeriveAddresses uses the specified extended public key and chain to derive
+// count sequential addresses starting at the specified address index. The
+// returned addresses are the same as the ones returned by DeriveAddress for
+// each index, but the extended public key is parsed and the hierarchy is
+// descended only once, which is much faster for large address scans.
+func DeriveAddresses(
+	extendedPublicKey string,
+	chain uint32,
+	startIndex uint32,
+	count uint32,
+	chainParams *chaincfg.Params,
+) ([]string, error) {
+	if count > 0 {
+		if err := validateAddressIndex(uint64(startIndex) + uint64(count) - 1); err != nil {
+			return nil, err
 		}
 	}
 
-	requestedPublicKey, err := externalChain.Derive(addressIndex)
+	chainKey, err := deriveChainKey(extendedPublicKey, chain)
 	if err != nil {
-		return "", fmt.Errorf(
-			"error deriving requested address index /0/%v from extended key: [%w]",
-			addressIndex,
-			err,
-		)
+		return nil, err
 	}
 
 	publicKeyDescriptor := extendedPublicKey[0:4]
 	if err := validatePublicKeyDescriptor(publicKeyDescriptor, chainParams); err != nil {
-		return "", err
+		return nil, err
+	}
+
+	addresses := make([]string, 0, count)
+	for i := uint32(0); i < count; i++ {
+		addressIndex := startIndex + i
+
+		requestedPublicKey, err := chainKey.Derive(addressIndex)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error deriving requested address index /%v/%v from extended key: [%w]",
+				chain,
+				addressIndex,
+				err,
+			)
+		}
+
+		address, err := encodeDerivedAddress(
+			extendedPublicKey,
+			requestedPublicKey,
+			chainParams,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		addresses = append(addresses, address)
 	}
 
+	return addresses, nil
+}
+
+// encodeDerivedAddress encodes the address of the public key derived from the
+// specified extended public key in the format determined by the extended
+// public key descriptor.
+func encodeDerivedAddress(
+	extendedPublicKey string,
+	requestedPublicKey *hdkeychain.ExtendedKey,
+	chainParams *chaincfg.Params,
+) (string, error) {
 	requestedAddress, err := requestedPublicKey.Address(chainParams)
 	if err != nil {
 		return "", fmt.Errorf(
@@ -88,7 +158,7 @@ func DeriveAddress(
 	}
 
 	var finalAddress btcutil.Address = requestedAddress
-	switch publicKeyDescriptor {
+	switch extendedPublicKey[0:4] {
 	case "xpub", "tpub":
 		// Noop, the address is already correct
 	case "ypub", "upub":
@@ -115,6 +185,172 @@ func DeriveAddress(
 	return finalAddress.EncodeAddress(), nil
 }
 
+// DeriveTaprootAddress uses the specified extended public key, chain and
+// address index to derive a [BIP86] taproot address at the specified address
+// index. The extended public key is descended the same way as in DeriveAddress.
+//
+// Taproot has no dedicated SLIP-132 extended key prefix, so BIP86 extended
+// public keys use the xpub and tpub prefixes. The returned address is a bech32m
+// segwit v1 address (i.e., prefixed by bc1p or tb1p) paying to the key path
+// of the derived public key, with no script path.
+//
+// [BIP86]: https://github.com/bitcoin/bips/blob/master/bip-0086.mediawiki
+func DeriveTaprootAddress(
+	extendedPublicKey string,
+	chain uint32,
+	addressIndex uint32,
+	chainParams *chaincfg.Params,
+) (string, error) {
+	requestedPublicKey, err := deriveChildPublicKey(
+		extendedPublicKey,
+		chain,
+		addressIndex,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	publicKeyDescriptor := extendedPublicKey[0:4]
+	if publicKeyDescriptor != "xpub" && publicKeyDescriptor != "tpub" {
+		return "", fmt.Errorf(
+			"unsupported public key format [%s] for taproot address",
+			publicKeyDescriptor,
+		)
+	}
+	if err := validatePublicKeyDescriptor(publicKeyDescriptor, chainParams); err != nil {
+		return "", err
+	}
+
+	internalKey, err := requestedPublicKey.ECPubKey()
+	if err != nil {
+		return "", fmt.Errorf(
+			"error retrieving the requested public key with extended key [%v]: [%s]",
+			extendedPublicKey,
+			err,
+		)
+	}
+
+	outputKey, err := taprootOutputKey(internalKey)
+	if err != nil {
+		return "", fmt.Errorf(
+			"failed to compute taproot output key from extended key: [%s]",
+			err,
+		)
+	}
+
+	return encodeTaprootAddress(outputKey, chainParams)
+}
+
+// deriveChildPublicKey parses the specified extended public key, descends it
+// to the supplied chain with deriveChainKey, and derives the child public key
+// at the supplied address index.
+func deriveChildPublicKey(
+	extendedPublicKey string,
+	chain uint32,
+	addressIndex uint32,
+) (*hdkeychain.ExtendedKey, error) {
+	if err := validateAddressIndex(uint64(addressIndex)); err != nil {
+		return nil, err
+	}
+
+	chainKey, err := deriveChainKey(extendedPublicKey, chain)
+	if err != nil {
+		return nil, err
+	}
+
+	requestedPublicKey, err := chainKey.Derive(addressIndex)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error deriving requested address index /%v/%v from extended key: [%w]",
+			chain,
+			addressIndex,
+			err,
+		)
+	}
+
+	return requestedPublicKey, nil
+}
+
+// validateAddressIndex checks that the address index does not require
+// hardened derivation, which is not possible from an extended public key.
+func validateAddressIndex(addressIndex uint64) error {
+	if addressIndex >= uint64(hdkeychain.HardenedKeyStart) {
+		return fmt.Errorf(
+			"address index [%d] requires hardened derivation which is not "+
+				"possible from an extended public key",
+			addressIndex,
+		)
+	}
+
+	return nil
+}
+
+// deriveChainKey parses the specified extended public key, descends the
+// hierarchy at `/0` until a depth of 3 is reached, and then at the supplied
+// chain. Extended public keys at depth 4 already include the chain, so the
+// supplied chain is ignored for them.
+func deriveChainKey(
+	extendedPublicKey string,
+	chain uint32,
+) (*hdkeychain.ExtendedKey, error) {
+	if chain != ExternalChain && chain != InternalChain {
+		return nil, fmt.Errorf(
+			"unsupported chain [%d]; must be external [%d] or internal [%d]",
+			chain,
+			ExternalChain,
+			InternalChain,
+		)
+	}
+
+	extendedKey, err := hdkeychain.NewKeyFromString(extendedPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error parsing extended public key: [%s]",
+			err,
+		)
+	}
+
+	// Validate the prefix before any derivation so that unknown prefixes
+	// are not passed further as missing chain parameters.
+	switch prefix := extendedPublicKey[0:4]; prefix {
+	case "xpub", "ypub", "zpub", "tpub", "upub", "vpub":
+	default:
+		return nil, fmt.Errorf(
+			"unsupported extended public key prefix: [%s]",
+			prefix,
+		)
+	}
+
+	// All the steps descending the key to the chain path are non-hardened,
+	// so account level keys at depth 3, like `m/44'/0'/0'` exported by most
+	// wallets, descend to the chain at `m/44'/0'/0'/chain` without
+	// requiring the private key.
+	chainKey := extendedKey
+	if chainKey.Depth() > 4 {
+		return nil, fmt.Errorf("extended public key is deeper than 4, depth: %d", chainKey.Depth())
+	}
+	for chainKey.Depth() < 4 {
+		// Descend the hierarchy at /0 until the chain path, `m/*/*/*/chain`.
+		// ex: If we get a `m/32'/5` extended key, we descend to `m/32'/5/0/0`
+		// for the external chain and to `m/32'/5/0/1` for the internal chain.
+		childIndex := uint32(0)
+		if chainKey.Depth() == 3 {
+			childIndex = chain
+		}
+
+		chainKey, err = chainKey.Derive(childIndex)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error deriving chain path /%v from extended key: [%s]",
+				childIndex,
+				err,
+			)
+		}
+	}
+
+	return chainKey, nil
+}
+
 // validatePublicKeyDescriptor validates public key descriptor against chain network
 // type. `xpub`, `ypub`, and `zpub` are dedicated for mainnet. `tpub`, `upub`,
 // and `vpub` may be used on testnet and regtest.
@@ -122,9 +358,12 @@ func validatePublicKeyDescriptor(
 	publicKeyDescriptor string,
 	chainParams *chaincfg.Params,
 ) error {
+	// Test network descriptors are shared by all the test networks, so any
+	// network other than mainnet is accepted for them. This lets callers
+	// pass parameters of networks unknown to chaincfg, like signet.
 	switch publicKeyDescriptor {
 	case "xpub", "ypub", "zpub":
-		if chainParams.Name != chaincfg.MainNetParams.Name {
+		if chainParams.Net != chaincfg.MainNetParams.Net {
 			return fmt.Errorf(
 				"public key descriptor [%s] is invalid for network [%s]",
 				publicKeyDescriptor,
@@ -132,8 +371,7 @@ func validatePublicKeyDescriptor(
 			)
 		}
 	case "tpub", "upub", "vpub":
-		if chainParams.Name != chaincfg.TestNet3Params.Name &&
-			chainParams.Name != chaincfg.RegressionNetParams.Name {
+		if chainParams.Net == chaincfg.MainNetParams.Net {
 			return fmt.Errorf(
 				"public key descriptor [%s] is invalid for network [%s]",
 				publicKeyDescriptor,
@@ -154,7 +392,7 @@ func validatePublicKeyDescriptor(
 // supplied chain. We check both raw btc addresses and *pub extended keys.
 func ValidateAddressOrKey(btcAddress string, chainParams *chaincfg.Params) error {
 	if validateErr := ValidateAddress(btcAddress, chainParams); validateErr != nil {
-		_, deriveErr := DeriveAddress(btcAddress, 0, chainParams)
+		_, deriveErr := DeriveAddress(btcAddress, ExternalChain, 0, chainParams)
 		if deriveErr != nil {
 			return fmt.Errorf(
 				"[%s] is not a valid btc address or extended key using chain [%s]: "+
@@ -5,7 +5,9 @@ import (
 	"testing"
 
 	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btcutil"
+	"github.com/btcsuite/btcutil/hdkeychain"
 )
 
 // These tests use https://iancoleman.io/bip39/ with the bip39 mnemonic: loyal
@@ -176,12 +178,34 @@ var deriveAddressTestData = map[string]struct {
 		&chaincfg.RegressionNetParams,
 		"bcrt1qjy5r90er70t2cexwpmmkf9hr4glxdx83s7cr79",
 	},
+	// signet
+	"BIP44: tpub at m/44'/1'/3'/0/4 for signet": {
+		"tpubDEXzoXkNdhoFeYrtS2BJKfok6LwH5PKkr5jPSMR6A2erw2yS3VgY5EoYdcKH24VPqeAgBTF6i82Ft9NG1iVjSQVAvFBfd2wkRQXF1W2Q8W1",
+		4,
+		signetParams,
+		"ms5BTH2MNLgtp1RNJh4Fnsjdyu3ZuoV7nw",
+	},
+	"BIP141: vpub at m/44'/0'/0'/0/4 for signet": {
+		"vpub5Zx5difzitDBNPjrr9pTno6C44dJFd89naYzhyk9QWHFTpF7pJqnyAnADhbVrFYX7eCK8V2WBBVprxzJrSk15NsYHiB8CvV8h4JnXkU66as",
+		4,
+		signetParams,
+		"tb1qjy5r90er70t2cexwpmmkf9hr4glxdx83jhpwfv",
+	},
 }
 
+// signetParams are the signet network parameters, which are not provided by
+// chaincfg. Signet shares address encoding with testnet.
+var signetParams = func() *chaincfg.Params {
+	params := chaincfg.TestNet3Params
+	params.Name = "signet"
+	params.Net = wire.BitcoinNet(0x40cf030a)
+	return &params
+}()
+
 func TestDeriveAddress(t *testing.T) {
 	for testName, testData := range deriveAddressTestData {
 		t.Run(testName, func(t *testing.T) {
-			address, err := DeriveAddress(testData.extendedAddress, uint32(testData.addressIndex), testData.chainParams)
+			address, err := DeriveAddress(testData.extendedAddress, ExternalChain, uint32(testData.addressIndex), testData.chainParams)
 			if err != nil {
 				t.Fatalf("failed to derive address: %s", err)
 			}
@@ -210,6 +234,279 @@ func TestDeriveAddress(t *testing.T) {
 	}
 }
 
+func TestDeriveAddress_InternalChain(t *testing.T) {
+	// BIP84 zpub at m/84'/0'/0'
+	extendedPublicKey := "zpub6rePDVHfRP14VpYiejwepBhzu45UbvqvzE3ZMdDnNykG47mZYyGTjsuq6uzQYRakSrHyix1YTXKohag4GDZLcHcLvhSAs2MQNF8VDaZuQT9"
+
+	externalAddress, err := DeriveAddress(
+		extendedPublicKey,
+		ExternalChain,
+		0,
+		&chaincfg.MainNetParams,
+	)
+	if err != nil {
+		t.Fatalf("failed to derive external address: %s", err)
+	}
+
+	internalAddress, err := DeriveAddress(
+		extendedPublicKey,
+		InternalChain,
+		0,
+		&chaincfg.MainNetParams,
+	)
+	if err != nil {
+		t.Fatalf("failed to derive internal address: %s", err)
+	}
+
+	expectedExternalAddress := "bc1q46uejlhm9vkswfcqs9plvujzzmqjvtfda3mra6"
+	if externalAddress != expectedExternalAddress {
+		t.Errorf(
+			"unexpected external address\nexpected: %s\nactual:   %s",
+			expectedExternalAddress,
+			externalAddress,
+		)
+	}
+
+	if internalAddress == externalAddress {
+		t.Errorf(
+			"internal address should differ from external address [%s]",
+			externalAddress,
+		)
+	}
+}
+
+func TestDeriveAddress_AccountLevelKeys(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		extendedAddress string
+		depth           uint8
+		addressIndex    uint32
+		expectedAddress string
+	}{
+		"account key at m/6'/4'/9'": {
+			"zpub6rXD5NEyWK1YKTrPzAK96Yri6Y7EnBXFj7wsVumD2JJCmiudVMtieG8MRpKnYjNANEWZKBSefxF1MvBcH7E57anyYBBj6ms2XZMVgsuPzzs",
+			3,
+			11,
+			"bc1qsszrcep8whzqh93ksmmckn77eh9fl55s5dzjx6",
+		},
+		"external chain key at m/6'/4'/9'/0": {
+			"zpub6sx8SbypUZWDUJHvUUs8V6zwmak399trAMDc95WYVjniRoDphotnh6BdvDbmdunkRwA9hFpBXjGDDKLweR3kTSSCVNm15rANpN4XixewDwG",
+			4,
+			4,
+			"bc1q5l3j7e2s3dzg4vxquxldvzw4dwdlvvhdc9c4zh",
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			extendedKey, err := hdkeychain.NewKeyFromString(testData.extendedAddress)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if extendedKey.Depth() != testData.depth {
+				t.Fatalf(
+					"unexpected key depth\nexpected: %d\nactual:   %d",
+					testData.depth,
+					extendedKey.Depth(),
+				)
+			}
+
+			address, err := DeriveAddress(
+				testData.extendedAddress,
+				ExternalChain,
+				testData.addressIndex,
+				&chaincfg.MainNetParams,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if address != testData.expectedAddress {
+				t.Errorf(
+					"unexpected derived address\nexpected: %s\nactual:   %s",
+					testData.expectedAddress,
+					address,
+				)
+			}
+		})
+	}
+}
+
+func TestDeriveAddresses_HardenedIndex(t *testing.T) {
+	_, err := DeriveAddresses(
+		"zpub6rXD5NEyWK1YKTrPzAK96Yri6Y7EnBXFj7wsVumD2JJCmiudVMtieG8MRpKnYjNANEWZKBSefxF1MvBcH7E57anyYBBj6ms2XZMVgsuPzzs",
+		ExternalChain,
+		hdkeychain.HardenedKeyStart-2,
+		3,
+		&chaincfg.MainNetParams,
+	)
+
+	expectedError := "address index [2147483648] requires hardened derivation which is not possible from an extended public key"
+	if err == nil || err.Error() != expectedError {
+		t.Errorf(
+			"unexpected error\nexpected: %v\nactual:   %v",
+			expectedError,
+			err,
+		)
+	}
+}
+
+func TestDeriveAddress_UnsupportedChain(t *testing.T) {
+	_, err := DeriveAddress(
+		"zpub6rePDVHfRP14VpYiejwepBhzu45UbvqvzE3ZMdDnNykG47mZYyGTjsuq6uzQYRakSrHyix1YTXKohag4GDZLcHcLvhSAs2MQNF8VDaZuQT9",
+		2,
+		0,
+		&chaincfg.MainNetParams,
+	)
+
+	expectedError := "unsupported chain [2]; must be external [0] or internal [1]"
+	if err == nil || err.Error() != expectedError {
+		t.Errorf(
+			"unexpected error\nexpected: %s\nactual:   %v",
+			expectedError,
+			err,
+		)
+	}
+}
+
+func TestDeriveAddresses(t *testing.T) {
+	startIndex := uint32(7)
+	count := uint32(50)
+
+	for testName, testData := range map[string]struct {
+		extendedAddress string
+		chainParams     *chaincfg.Params
+	}{
+		"xpub": {
+			"xpub6Cg41S21VrxkW1WBTZJn95KNpHozP2Xc6AhG27ZcvZvH8XyNzunEqLdk9dxyXQUoy7ALWQFNn5K1me74aEMtS6pUgNDuCYTTMsJzCAk9sk1",
+			&chaincfg.MainNetParams,
+		},
+		"ypub": {
+			"ypub6Xxan668aiJqvh4SVfd7EzqjWvf36gWufTkhWHv3gaxnBh44HpkTi2TTkm1u136qjUxk7F3jGzoyfrGpHvALMgJgbF4WNXpoPu3QYrqogMK",
+			&chaincfg.MainNetParams,
+		},
+		"zpub": {
+			"zpub6rePDVHfRP14VpYiejwepBhzu45UbvqvzE3ZMdDnNykG47mZYyGTjsuq6uzQYRakSrHyix1YTXKohag4GDZLcHcLvhSAs2MQNF8VDaZuQT9",
+			&chaincfg.MainNetParams,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			for _, chain := range []uint32{ExternalChain, InternalChain} {
+				addresses, err := DeriveAddresses(
+					testData.extendedAddress,
+					chain,
+					startIndex,
+					count,
+					testData.chainParams,
+				)
+				if err != nil {
+					t.Fatalf("failed to derive addresses: %s", err)
+				}
+
+				if uint32(len(addresses)) != count {
+					t.Fatalf(
+						"unexpected number of addresses\nexpected: %d\nactual:   %d",
+						count,
+						len(addresses),
+					)
+				}
+
+				for i, address := range addresses {
+					addressIndex := startIndex + uint32(i)
+					expectedAddress, err := DeriveAddress(
+						testData.extendedAddress,
+						chain,
+						addressIndex,
+						testData.chainParams,
+					)
+					if err != nil {
+						t.Fatalf("failed to derive address: %s", err)
+					}
+
+					if address != expectedAddress {
+						t.Errorf(
+							"unexpected derived address at /%d/%d\nexpected: %s\nactual:   %s",
+							chain,
+							addressIndex,
+							expectedAddress,
+							address,
+						)
+					}
+				}
+			}
+		})
+	}
+}
+
+// These tests use test vectors from BIP86:
+// https://github.com/bitcoin/bips/blob/master/bip-0086.mediawiki#test-vectors
+func TestDeriveTaprootAddress(t *testing.T) {
+	extendedPublicKey := "xpub6BgBgsespWvERF3LHQu6CnqdvfEvtMcQjYrcRzx53QJjSxarj2afYWcLteoGVky7D3UKDP9QyrLprQ3VCECoY49yfdDEHGCtMMj92pReUsQ"
+
+	var tests = map[string]struct {
+		chain           uint32
+		addressIndex    uint32
+		expectedAddress string
+	}{
+		"BIP86: xpub at m/86'/0'/0'/0/0": {
+			ExternalChain,
+			0,
+			"bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr",
+		},
+		"BIP86: xpub at m/86'/0'/0'/0/1": {
+			ExternalChain,
+			1,
+			"bc1p4qhjn9zdvkux4e44uhx8tc55attvtyu358kutcqkudyccelu0was9fqzwh",
+		},
+		"BIP86: xpub at m/86'/0'/0'/0/2": {
+			ExternalChain,
+			2,
+			"bc1p0d0rhyynq0awa9m8cqrcr8f5nxqx3aw29w4ru5u9my3h0sfygnzs9khxz8",
+		},
+		"BIP86: xpub at m/86'/0'/0'/1/0": {
+			InternalChain,
+			0,
+			"bc1p3qkhfews2uk44qtvauqyr2ttdsw7svhkl9nkm9s9c3x4ax5h60wqwruhk7",
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			address, err := DeriveTaprootAddress(
+				extendedPublicKey,
+				testData.chain,
+				testData.addressIndex,
+				&chaincfg.MainNetParams,
+			)
+			if err != nil {
+				t.Fatalf("failed to derive address: %s", err)
+			}
+
+			if address != testData.expectedAddress {
+				t.Errorf(
+					"unexpected derived address\nexpected: %s\nactual:   %s",
+					testData.expectedAddress,
+					address,
+				)
+			}
+		})
+	}
+}
+
+func TestDeriveTaprootAddress_UnsupportedPrefix(t *testing.T) {
+	_, err := DeriveTaprootAddress(
+		"zpub6rePDVHfRP14VpYiejwepBhzu45UbvqvzE3ZMdDnNykG47mZYyGTjsuq6uzQYRakSrHyix1YTXKohag4GDZLcHcLvhSAs2MQNF8VDaZuQT9",
+		ExternalChain,
+		0,
+		&chaincfg.MainNetParams,
+	)
+
+	expectedError := "unsupported public key format [zpub] for taproot address"
+	if err == nil || err.Error() != expectedError {
+		t.Errorf(
+			"unexpected error\nexpected: %s\nactual:   %v",
+			expectedError,
+			err,
+		)
+	}
+}
+
 func TestDeriveAddress_ExpectedFailures(t *testing.T) {
 	deriveAddressTestFailureData := map[string]struct {
 		extendedAddress string
@@ -221,13 +518,13 @@ func TestDeriveAddress_ExpectedFailures(t *testing.T) {
 			"ypub6Z7s8wJuKsxjd16oe85WH1uSbcbbCXuMFEhPMgcf7jQqNhQbT9jE52XVu1eBe18q2J3LwnDd54ufL2jNvidjfCkbd34aVwLtYdztLUqucwR",
 			11 + 2147483648,
 			&chaincfg.MainNetParams,
-			"error deriving requested address index /0/2147483659 from extended key: [cannot derive a hardened key from a public key]",
+			"address index [2147483659] requires hardened derivation which is not possible from an extended public key",
 		},
 		"BIP141 P2WPKH nested in P2SH ypub at m/6'/4'/9'/0/11' with a private key": {
 			"yprvAL8WjRn1VWQSQX2LY6YVusxi3am6o5BVt1mnZJD3ZPsrVu5SucQyXED23ikCvDeeFHTMeX9q5n5MHNTLWQvCSm3KWnA3KdyZuDXncTn2VW5",
 			11 + 2147483648,
 			&chaincfg.MainNetParams,
-			"unsupported public key format [yprv]",
+			"unsupported extended public key prefix: [yprv]",
 		},
 		"BIP141 ypub is too deep at m/0/0/0/0/0/0": {
 			"ypub6bp11ZqNVMqm3C3eXAFGpEvKqNfEZ6Vhznd4Uo3S73RYTSFgmF7q9sWPoCFhLGVMSLqKZZpcpHoKgHNwStDuqQPnDfF13goQwS8qSFA6vnz",
@@ -248,6 +545,12 @@ func TestDeriveAddress_ExpectedFailures(t *testing.T) {
 			&chaincfg.RegressionNetParams,
 			"public key descriptor [zpub] is invalid for network [regtest]",
 		},
+		"xpub and signet": {
+			"xpub6Cg41S21VrxkW1WBTZJn95KNpHozP2Xc6AhG27ZcvZvH8XyNzunEqLdk9dxyXQUoy7ALWQFNn5K1me74aEMtS6pUgNDuCYTTMsJzCAk9sk1",
+			0,
+			signetParams,
+			"public key descriptor [xpub] is invalid for network [signet]",
+		},
 		"upub and mainnet": {
 			"upub5DnYQWgCDSGe6DAr6NZaD4jsh1Qz55jh7DeScVVyJJnHCp5BwyvM7Xm7S5r5n5ZYMJ1WrrM31i4kcsWwW2vxcJS1kfsuKgK9vME2z1cx6aX",
 			0,
@@ -259,13 +562,20 @@ func TestDeriveAddress_ExpectedFailures(t *testing.T) {
 			"Ypub6irfuKpa9fsDMGDpSL6655BYEihJK3CVyjQNRZBb4MoBoscy4E8jo9KPZTyNZUKjxx2iyq3rADCUo1tab9KHWARMRiAumwHoHdKH8qBNhaf",
 			0,
 			&chaincfg.MainNetParams,
-			"unsupported public key format [Ypub]",
+			"unsupported extended public key prefix: [Ypub]",
+		},
+		// Valid extended key encoding with a made-up version bytes.
+		"made-up prefix": {
+			"rFaStAQ7ByRGHcWbnav7DEusAEHBkSNXyRdJUXwPmME8UJ5VQfXKSyCBoA5MzqGmrg1vGfdBZNr3k4x5a1oX74h3Ynz8Nyg9dZC53z3Px2i9Rcd",
+			0,
+			&chaincfg.MainNetParams,
+			"unsupported extended public key prefix: [rFaS]",
 		},
 	}
 
 	for testName, testData := range deriveAddressTestFailureData {
 		t.Run(testName, func(t *testing.T) {
-			_, err := DeriveAddress(testData.extendedAddress, uint32(testData.addressIndex), testData.chainParams)
+			_, err := DeriveAddress(testData.extendedAddress, ExternalChain, uint32(testData.addressIndex), testData.chainParams)
 			if err == nil || err.Error() != testData.failure {
 				t.Errorf(
 					"unexpected error message\nexpected: %v\nactual:   %v",
@@ -3,10 +3,13 @@ package bitcoin
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ipfs/go-log"
@@ -15,6 +18,10 @@ import (
 
 var logger = log.Logger("keep-bitcoin")
 
+// ErrTransactionNotFound is an error returned when the requested transaction
+// is not known to the bitcoin network.
+var ErrTransactionNotFound = errors.New("transaction not found")
+
 const (
 	// defaultTimeout defines a period within which the member tries to call Electrs
 	// API. If the time is reached an error will be returned.
@@ -23,6 +30,9 @@ const (
 	// liquidation recovery protocol (recoveryProtocolReadyTimeout), so the nodes
 	// can correctly synchronize liquidation protocol execution.
 	defaultTimeout = 1 * time.Minute
+
+	// feeEstimatesPath is the electrs API path of fee estimates.
+	feeEstimatesPath = "/fee-estimates"
 )
 
 type httpClient interface {
@@ -32,32 +42,267 @@ type httpClient interface {
 
 // electrsConnection exposes a native API for interacting with an electrs http API.
 type electrsConnection struct {
-	apiURL  string
-	client  httpClient
-	timeout time.Duration
+	apiURL   string
+	failover *failover
+	client   httpClient
+	timeout  time.Duration
+	dryRun   bool
+
+	// rateLimiter limits the rate of requests sent to the electrs API. It is
+	// nil if the rate is not limited.
+	rateLimiter *rateLimiter
+
+	// feeEstimatesCache keeps fee estimates fetched from the electrs API for
+	// a short time. It is nil if fee estimates are not cached.
+	feeEstimatesCache *responseCache
+}
+
+// failover holds a list of interchangeable electrs API URLs along with the
+// index of the URL which most recently responded successfully.
+type failover struct {
+	mutex    sync.Mutex
+	apiURLs  []string
+	lastGood int
 }
 
-// Connect is a constructor for electrsConnection.
-func Connect(apiURL string) Handle {
-	return &electrsConnection{
+// Option customizes the connection created by Connect.
+type Option func(e *electrsConnection)
+
+// Connect is a constructor for electrsConnection. Optional options can be
+// passed to customize the returned handle.
+func Connect(apiURL string, options ...Option) Handle {
+	electrs := &electrsConnection{
 		apiURL:  apiURL,
 		client:  http.DefaultClient,
 		timeout: defaultTimeout,
 	}
+
+	for _, option := range options {
+		option(electrs)
+	}
+
+	return electrs
+}
+
+// WithDryRun makes the connection never broadcast transactions to the
+// bitcoin network. Broadcast only logs the transaction and reports success.
+// All other calls are executed normally.
+func WithDryRun() Option {
+	return func(e *electrsConnection) {
+		e.dryRun = true
+	}
+}
+
+// WithTimeout makes the connection give up on electrs API calls after the
+// given timeout. The timeout must be positive; otherwise, the default timeout
+// is used.
+func WithTimeout(timeout time.Duration) Option {
+	return func(e *electrsConnection) {
+		if timeout <= 0 {
+			logger.Warnf(
+				"invalid electrs timeout [%v]; using the default timeout [%v]",
+				timeout,
+				defaultTimeout,
+			)
+			return
+		}
+
+		e.timeout = timeout
+	}
+}
+
+// WithFailover makes the connection use the given electrs API URLs
+// interchangeably with the URL passed to Connect. Every request is sent to
+// the URLs in order, starting from the one which most recently responded
+// successfully, until one of them responds with status 200. If no URLs are
+// given, only the URL passed to Connect is used.
+func WithFailover(apiURLs ...string) Option {
+	return func(e *electrsConnection) {
+		if len(apiURLs) == 0 {
+			return
+		}
+
+		e.failover = &failover{
+			apiURLs: append([]string{e.apiURL}, apiURLs...),
+		}
+	}
+}
+
+// WithRateLimit makes the connection send at most the given number of
+// requests per second to the electrs API, allowing bursts of up to the given
+// number of requests. A call exceeding the limit waits until it can be sent,
+// as long as the call timeout is not reached. The rate must be positive;
+// otherwise, the rate is not limited.
+func WithRateLimit(requestsPerSecond float64, burst int) Option {
+	return func(e *electrsConnection) {
+		if requestsPerSecond <= 0 {
+			logger.Warnf(
+				"invalid electrs rate limit [%v]; not limiting the rate",
+				requestsPerSecond,
+			)
+			return
+		}
+
+		e.rateLimiter = newRateLimiter(requestsPerSecond, burst)
+	}
+}
+
+// WithFeeEstimatesCache makes the connection reuse fee estimates fetched from
+// the electrs API for the given time to live, as fee estimates change slowly.
+// Failed fetches are not cached. The time to live must be positive;
+// otherwise, the default time to live is used.
+func WithFeeEstimatesCache(ttl time.Duration) Option {
+	return func(e *electrsConnection) {
+		if ttl <= 0 {
+			logger.Warnf(
+				"invalid fee estimates cache TTL [%v]; using the default TTL [%v]",
+				ttl,
+				defaultFeeEstimatesCacheTTL,
+			)
+			ttl = defaultFeeEstimatesCacheTTL
+		}
+
+		e.feeEstimatesCache = newResponseCache(ttl)
+	}
 }
 
 func (e *electrsConnection) setClient(client httpClient) {
 	e.client = client
 }
 
+// get sends a GET request for the given API path.
+func (e electrsConnection) get(ctx context.Context, path string) (*http.Response, error) {
+	return e.request(ctx, func(apiURL string) (*http.Response, error) {
+		return e.client.Get(apiURL + path)
+	})
+}
+
+// post sends a POST request with the given body for the given API path.
+func (e electrsConnection) post(
+	ctx context.Context,
+	path string,
+	contentType string,
+	body string,
+) (*http.Response, error) {
+	return e.request(ctx, func(apiURL string) (*http.Response, error) {
+		return e.client.Post(apiURL+path, contentType, strings.NewReader(body))
+	})
+}
+
+// request executes requestFn against the configured API URLs. If failover is
+// configured, the URLs are tried in order starting from the last good one and
+// the first response with status 200 is returned. If no URL responds with
+// status 200, the result of the last attempt is returned. Every attempt is
+// abandoned once the context is done. If the rate of requests is limited,
+// every attempt waits until it can be sent.
+func (e electrsConnection) request(
+	ctx context.Context,
+	requestFn func(apiURL string) (*http.Response, error),
+) (*http.Response, error) {
+	if e.failover == nil {
+		if err := e.rateLimiter.wait(ctx); err != nil {
+			return nil, err
+		}
+
+		return requestWithContext(ctx, e.apiURL, requestFn)
+	}
+
+	e.failover.mutex.Lock()
+	lastGood := e.failover.lastGood
+	e.failover.mutex.Unlock()
+
+	urlsCount := len(e.failover.apiURLs)
+
+	var resp *http.Response
+	var err error
+	for i := 0; i < urlsCount; i++ {
+		index := (lastGood + i) % urlsCount
+		apiURL := e.failover.apiURLs[index]
+
+		if err := e.rateLimiter.wait(ctx); err != nil {
+			return nil, err
+		}
+
+		resp, err = requestWithContext(ctx, apiURL, requestFn)
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if err == nil && resp.StatusCode == 200 {
+			e.failover.mutex.Lock()
+			e.failover.lastGood = index
+			e.failover.mutex.Unlock()
+
+			return resp, nil
+		}
+
+		if err != nil {
+			logger.Warnf("electrs request to [%s] failed: [%v]", apiURL, err)
+		} else {
+			logger.Warnf(
+				"electrs request to [%s] failed with status [%s]",
+				apiURL,
+				resp.Status,
+			)
+		}
+
+		// The response of the last attempt is returned to the caller so
+		// its body can't be closed.
+		if err == nil && i < urlsCount-1 {
+			resp.Body.Close()
+		}
+	}
+
+	return resp, err
+}
+
+// requestWithContext executes requestFn against the given API URL and waits
+// for its result as long as the context is not done. The response of an
+// abandoned request is closed once it arrives.
+func requestWithContext(
+	ctx context.Context,
+	apiURL string,
+	requestFn func(apiURL string) (*http.Response, error),
+) (*http.Response, error) {
+	type result struct {
+		resp *http.Response
+		err  error
+	}
+
+	resultChan := make(chan result, 1)
+	go func() {
+		resp, err := requestFn(apiURL)
+		resultChan <- result{resp, err}
+	}()
+
+	select {
+	case result := <-resultChan:
+		return result.resp, result.err
+	case <-ctx.Done():
+		go func() {
+			if result := <-resultChan; result.err == nil {
+				result.resp.Body.Close()
+			}
+		}()
+		return nil, ctx.Err()
+	}
+}
+
 // Broadcast broadcasts a transaction the configured bitcoin network.
 func (e electrsConnection) Broadcast(transaction string) error {
 	if e.apiURL == "" {
 		return fmt.Errorf("attempted to call Broadcast with no apiURL")
 	}
 
+	if e.dryRun {
+		logger.Warnf(
+			"dry-run mode enabled; skipping broadcast of the bitcoin transaction: [%s]",
+			transaction,
+		)
+		return nil
+	}
+
 	return utils.DoWithDefaultRetry(e.timeout, func(ctx context.Context) error {
-		resp, err := e.client.Post(fmt.Sprintf("%s/tx", e.apiURL), "text/plain", strings.NewReader(transaction))
+		resp, err := e.post(ctx, "/tx", "text/plain", transaction)
 		if err != nil {
 			return err
 		}
@@ -88,15 +333,73 @@ func (e electrsConnection) Broadcast(transaction string) error {
 	})
 }
 
+// IsDryRun returns true if the connection does not broadcast transactions to
+// the bitcoin network.
+func (e electrsConnection) IsDryRun() bool {
+	return e.dryRun
+}
+
 // VbyteFeeFor25Blocks retrieves the 25-block estimate fee per vbyte on the bitcoin network.
 func (e electrsConnection) VbyteFeeFor25Blocks() (int32, error) {
 	if e.apiURL == "" {
 		return 0, fmt.Errorf("attempted to call VbyteFeeFor25Blocks with no apiURL")
 	}
 
-	var vbyteFee int32
+	return e.VbyteFeeForTarget(25)
+}
+
+// VbyteFeeForTarget retrieves the estimate fee per vbyte on the bitcoin network
+// for a transaction to be confirmed within the given number of blocks. If the
+// fee estimates do not contain the requested target, the estimate of the
+// nearest available target is used. When two targets are equally near, the
+// lower one is preferred, as it yields the faster confirmation. If the fee
+// estimates cache is configured, recently fetched fee estimates are reused.
+func (e electrsConnection) VbyteFeeForTarget(blocks int) (int32, error) {
+	if e.apiURL == "" {
+		return 0, fmt.Errorf("attempted to call VbyteFeeForTarget with no apiURL")
+	}
+	if blocks <= 0 {
+		return 0, fmt.Errorf("invalid confirmation target [%d]; must be positive", blocks)
+	}
+
+	responseBody, cached := e.feeEstimatesCache.get(feeEstimatesPath)
+	if !cached {
+		var err error
+		responseBody, err = e.fetchFeeEstimates()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	var fees map[string]float32
+	err := json.Unmarshal(responseBody, &fees)
+	if err != nil {
+		return 0, fmt.Errorf("something went wrong decoding the vbyte fees: [%v]", err)
+	}
+
+	if !cached {
+		e.feeEstimatesCache.put(feeEstimatesPath, responseBody)
+	}
+
+	target, fee, err := nearestFeeEstimate(fees, blocks)
+	if err != nil {
+		return 0, err
+	}
+	logger.Infof(
+		"retrieved a vbyte fee of [%v] for a [%d]-block target",
+		fee,
+		target,
+	)
+	return int32(fee), nil
+}
+
+// fetchFeeEstimates fetches the raw fee estimates from the electrs API.
+func (e electrsConnection) fetchFeeEstimates() ([]byte, error) {
+	// Only fetching the fee estimates is retried. A response which can not
+	// be decoded will not get any better when fetched again.
+	var responseBody []byte
 	err := utils.DoWithDefaultRetry(e.timeout, func(ctx context.Context) error {
-		resp, err := e.client.Get(fmt.Sprintf("%s/fee-estimates", e.apiURL))
+		resp, err := e.get(ctx, feeEstimatesPath)
 		if err != nil {
 			return err
 		}
@@ -116,23 +419,58 @@ func (e electrsConnection) VbyteFeeFor25Blocks() (int32, error) {
 			)
 		}
 
-		var fees map[string]float32
-		err = json.NewDecoder(resp.Body).Decode(&fees)
+		responseBody, err = io.ReadAll(resp.Body)
 		if err != nil {
-			return fmt.Errorf("something went wrong decoding the vbyte fees: [%v]", err)
-		}
-		fee, ok := fees["25"]
-		if !ok {
-			fee = 0
+			return fmt.Errorf(
+				"something went wrong trying to read response for bitcoin fee estimates: [%w]",
+				err,
+			)
 		}
-		logger.Infof("retrieved a vbyte fee of [%v]", fee)
-		vbyteFee = int32(fee)
 		return nil
 	})
 	if err != nil {
-		return 0, err
+		return nil, err
+	}
+
+	return responseBody, nil
+}
+
+// nearestFeeEstimate returns the fee estimate for the target closest to the
+// requested number of blocks, along with that target. Keys which are not valid
+// block targets are ignored.
+func nearestFeeEstimate(fees map[string]float32, blocks int) (int, float32, error) {
+	nearestTarget := 0
+	for key := range fees {
+		target, err := strconv.Atoi(key)
+		if err != nil || target <= 0 {
+			continue
+		}
+
+		if nearestTarget == 0 {
+			nearestTarget = target
+			continue
+		}
+
+		distance := absInt(target - blocks)
+		nearestDistance := absInt(nearestTarget - blocks)
+		if distance < nearestDistance ||
+			(distance == nearestDistance && target < nearestTarget) {
+			nearestTarget = target
+		}
+	}
+
+	if nearestTarget == 0 {
+		return 0, 0, fmt.Errorf("no fee estimates available")
+	}
+
+	return nearestTarget, fees[strconv.Itoa(nearestTarget)], nil
+}
+
+func absInt(value int) int {
+	if value < 0 {
+		return -value
 	}
-	return vbyteFee, nil
+	return value
 }
 
 // IsAddressUnused returns true if and only if the supplied bitcoin address has
@@ -144,9 +482,66 @@ func (e electrsConnection) IsAddressUnused(btcAddress string) (bool, error) {
 		return true, fmt.Errorf("attempted to call IsAddressUnused with no apiURL")
 	}
 
-	isAddressUnused := false
+	// Only fetching the transactions is retried. A response which can not be
+	// decoded will not get any better when fetched again.
+	var responseBody []byte
+	err := utils.DoWithDefaultRetry(e.timeout, func(ctx context.Context) error {
+		resp, err := e.get(ctx, fmt.Sprintf("/address/%s/txs", btcAddress))
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode != 200 {
+			responseBody, err := io.ReadAll(resp.Body)
+			if err != nil {
+				logger.Errorf(
+					"something went wrong trying to read error response for transactions of bitcoin address [%s]: [%v]",
+					btcAddress,
+					err,
+				)
+			}
+			return fmt.Errorf(
+				"something went wrong trying to get information about address [%s] - status: [%s], payload: [%s]",
+				btcAddress,
+				resp.Status,
+				responseBody,
+			)
+		}
+
+		responseBody, err = io.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf(
+				"something went wrong trying to read response for transactions of bitcoin address [%s]: [%w]",
+				btcAddress,
+				err,
+			)
+		}
+		return nil
+	})
+	if err != nil {
+		return true, err
+	}
+
+	responses := []interface{}{}
+	err = json.Unmarshal(responseBody, &responses)
+	if err != nil {
+		return true, fmt.Errorf("failed to decode response body: [%w]", err)
+	}
+
+	return len(responses) == 0, nil
+}
+
+// AddressTransactionStatus returns information whether the supplied bitcoin
+// address has confirmed and unconfirmed transactions recorded. Unlike
+// IsAddressUnused, it returns an explicit error if the status could not be
+// determined.
+func (e electrsConnection) AddressTransactionStatus(btcAddress string) (*AddressStatus, error) {
+	if e.apiURL == "" {
+		return nil, fmt.Errorf("attempted to call AddressTransactionStatus with no apiURL")
+	}
+
+	var addressStatus *AddressStatus
 	err := utils.DoWithDefaultRetry(e.timeout, func(ctx context.Context) error {
-		resp, err := e.client.Get(fmt.Sprintf("%s/address/%s/txs", e.apiURL, btcAddress))
+		resp, err := e.get(ctx, fmt.Sprintf("/address/%s/txs", btcAddress))
 		if err != nil {
 			return err
 		}
@@ -167,17 +562,239 @@ func (e electrsConnection) IsAddressUnused(btcAddress string) (bool, error) {
 			)
 		}
 
-		responses := []interface{}{}
-		err = json.NewDecoder(resp.Body).Decode(&responses)
+		transactions := []struct {
+			Status struct {
+				Confirmed bool `json:"confirmed"`
+			} `json:"status"`
+		}{}
+		err = json.NewDecoder(resp.Body).Decode(&transactions)
 		if err != nil {
 			return fmt.Errorf("failed to decode response body: [%w]", err)
 		}
 
-		isAddressUnused = len(responses) == 0
+		addressStatus = &AddressStatus{}
+		for _, transaction := range transactions {
+			if transaction.Status.Confirmed {
+				addressStatus.HasConfirmed = true
+			} else {
+				addressStatus.HasUnconfirmed = true
+			}
+		}
 		return nil
 	})
 	if err != nil {
-		return true, err
+		return nil, err
+	}
+	return addressStatus, nil
+}
+
+// GetUnspentOutputs returns unspent transaction outputs of the supplied
+// bitcoin address.
+func (e electrsConnection) GetUnspentOutputs(btcAddress string) ([]UnspentOutput, error) {
+	if e.apiURL == "" {
+		return nil, fmt.Errorf("attempted to call GetUnspentOutputs with no apiURL")
+	}
+
+	var unspentOutputs []UnspentOutput
+	err := utils.DoWithDefaultRetry(e.timeout, func(ctx context.Context) error {
+		resp, err := e.get(ctx, fmt.Sprintf("/address/%s/utxo", btcAddress))
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode != 200 {
+			responseBody, err := io.ReadAll(resp.Body)
+			if err != nil {
+				logger.Errorf(
+					"something went wrong trying to read error response for unspent outputs of bitcoin address [%s]: [%v]",
+					btcAddress,
+					err,
+				)
+			}
+			return fmt.Errorf(
+				"failed to get unspent outputs of address [%s] - status: [%s], payload: [%s]",
+				btcAddress,
+				resp.Status,
+				responseBody,
+			)
+		}
+
+		outputs := []UnspentOutput{}
+		err = json.NewDecoder(resp.Body).Decode(&outputs)
+		if err != nil {
+			return fmt.Errorf("failed to decode response body: [%w]", err)
+		}
+
+		unspentOutputs = outputs
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return unspentOutputs, nil
+}
+
+// GetRawTransaction returns the raw transaction hex of the transaction with
+// the given id. If the transaction is not known to the bitcoin network,
+// an error wrapping ErrTransactionNotFound is returned without retrying.
+func (e electrsConnection) GetRawTransaction(txid string) (string, error) {
+	if e.apiURL == "" {
+		return "", fmt.Errorf("attempted to call GetRawTransaction with no apiURL")
+	}
+
+	var rawTransaction string
+	transactionFound := true
+	err := utils.DoWithDefaultRetry(e.timeout, func(ctx context.Context) error {
+		resp, err := e.get(ctx, fmt.Sprintf("/tx/%s/hex", txid))
+		if err != nil {
+			return err
+		}
+
+		responseBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf(
+				"something went wrong trying to read response for transaction [%s]: [%w]",
+				txid,
+				err,
+			)
+		}
+
+		if resp.StatusCode == http.StatusNotFound {
+			// The transaction is unknown; retrying will not change that.
+			transactionFound = false
+			return nil
+		}
+		if resp.StatusCode != 200 {
+			return fmt.Errorf(
+				"failed to get transaction [%s] - status: [%s], payload: [%s]",
+				txid,
+				resp.Status,
+				responseBody,
+			)
+		}
+
+		rawTransaction = strings.TrimSpace(string(responseBody))
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	if !transactionFound {
+		return "", fmt.Errorf("%w: [%s]", ErrTransactionNotFound, txid)
+	}
+	return rawTransaction, nil
+}
+
+// GetTransactionConfirmations returns the number of confirmations of the
+// transaction with the given id. Zero is returned for a transaction which is
+// known to the bitcoin network but not yet confirmed. If the transaction is not
+// known to the bitcoin network, an error wrapping ErrTransactionNotFound is
+// returned.
+func (e electrsConnection) GetTransactionConfirmations(txid string) (int, error) {
+	if e.apiURL == "" {
+		return 0, fmt.Errorf("attempted to call GetTransactionConfirmations with no apiURL")
+	}
+
+	var status struct {
+		Confirmed   bool `json:"confirmed"`
+		BlockHeight int  `json:"block_height"`
+	}
+	transactionFound := true
+	err := utils.DoWithDefaultRetry(e.timeout, func(ctx context.Context) error {
+		resp, err := e.get(ctx, fmt.Sprintf("/tx/%s/status", txid))
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode == http.StatusNotFound {
+			// The transaction is unknown; retrying will not change that.
+			transactionFound = false
+			return nil
+		}
+		if resp.StatusCode != 200 {
+			responseBody, err := io.ReadAll(resp.Body)
+			if err != nil {
+				logger.Errorf(
+					"something went wrong trying to read error response for status of transaction [%s]: [%v]",
+					txid,
+					err,
+				)
+			}
+			return fmt.Errorf(
+				"failed to get status of transaction [%s] - status: [%s], payload: [%s]",
+				txid,
+				resp.Status,
+				responseBody,
+			)
+		}
+
+		err = json.NewDecoder(resp.Body).Decode(&status)
+		if err != nil {
+			return fmt.Errorf("failed to decode response body: [%w]", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	if !transactionFound {
+		return 0, fmt.Errorf("%w: [%s]", ErrTransactionNotFound, txid)
+	}
+	if !status.Confirmed {
+		return 0, nil
+	}
+
+	tipHeight, err := e.tipHeight()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get tip height: [%w]", err)
+	}
+
+	// The block including the transaction counts as the first confirmation.
+	confirmations := tipHeight - status.BlockHeight + 1
+	if confirmations < 1 {
+		return 0, fmt.Errorf(
+			"transaction block height [%d] is above the tip height [%d]",
+			status.BlockHeight,
+			tipHeight,
+		)
+	}
+
+	return confirmations, nil
+}
+
+// tipHeight returns the height of the last block of the best chain.
+func (e electrsConnection) tipHeight() (int, error) {
+	var height int
+	err := utils.DoWithDefaultRetry(e.timeout, func(ctx context.Context) error {
+		resp, err := e.get(ctx, "/blocks/tip/height")
+		if err != nil {
+			return err
+		}
+
+		responseBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf(
+				"something went wrong trying to read response for tip height: [%w]",
+				err,
+			)
+		}
+
+		if resp.StatusCode != 200 {
+			return fmt.Errorf(
+				"failed to get tip height - status: [%s], payload: [%s]",
+				resp.Status,
+				responseBody,
+			)
+		}
+
+		height, err = strconv.Atoi(strings.TrimSpace(string(responseBody)))
+		if err != nil {
+			return fmt.Errorf("failed to parse tip height: [%w]", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
 	}
-	return isAddressUnused, nil
+	return height, nil
 }
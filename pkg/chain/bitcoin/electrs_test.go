@@ -7,8 +7,11 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"reflect"
 	"testing"
 	"time"
+
+	"github.com/keep-network/keep-ecdsa/pkg/utils"
 )
 
 func TestBroadcast(t *testing.T) {
@@ -63,6 +66,27 @@ func TestBroadcast_ExpectFailure(t *testing.T) {
 	checkWrappedError(err, expectedError, t)
 }
 
+func TestBroadcast_DryRun(t *testing.T) {
+	electrs := Connect(testAPIURL, WithDryRun()).(*electrsConnection)
+	electrs.setClient(
+		mockClient{
+			mockPost: func(url string, contentType string, body io.Reader) (*http.Response, error) {
+				t.Fatalf("unexpected broadcast to [%s]", url)
+				return nil, nil
+			},
+		},
+	)
+
+	if !electrs.IsDryRun() {
+		t.Errorf("expected dry-run mode to be enabled")
+	}
+
+	err := electrs.Broadcast("01000000000101ba84a592005742406bd1d6683e3a894c7ab13385bd437ff7bd7c74929bf1413200000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestVbyteFeeFor25Blocks(t *testing.T) {
 	mockedResponseCode := 200
 	mockedResponseBody := `{ "1": 87.882, "2": 87.882, "3": 87.882, "4": 87.882, "5": 81.129, "6": 68.285, "7": 65.182, "8": 63.876, "9": 61.153, "10": 60.172, "11": 57.721, "12": 54.753, "13": 52.879, "14": 46.872, "15": 42.871, "16": 39.989, "17": 35.919, "18": 30.821, "19": 25.888, "20": 21.876, "21": 16.156, "22": 11.222, "23": 10.982, "24": 9.654, "25": 7.883, "144": 1.027, "504": 1.027, "1008": 1.027 }`
@@ -134,6 +158,75 @@ func TestVbyteFeeFor25Blocks_ExpectFailure(t *testing.T) {
 
 }
 
+func TestVbyteFeeForTarget(t *testing.T) {
+	mockedResponseCode := 200
+	mockedResponseBody := `{ "1": 87.882, "2": 87.882, "3": 87.882, "4": 87.882, "5": 81.129, "6": 68.285, "144": 1.027, "504": 1.027, "1008": 1.027 }`
+
+	var tests = map[string]struct {
+		blocks      int
+		expectedFee int32
+	}{
+		"exact target": {
+			blocks:      6,
+			expectedFee: 68,
+		},
+		"nearest lower target": {
+			blocks:      25,
+			expectedFee: 68,
+		},
+		"nearest higher target": {
+			blocks:      100,
+			expectedFee: 1,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			electrs := newTestElectrsConnection(
+				mockClient{
+					mockGet: mockGet(
+						fmt.Sprintf("%s/fee-estimates", testAPIURL),
+						mockedResponseCode,
+						mockedResponseBody,
+						t,
+					),
+				},
+			)
+
+			fee, err := electrs.VbyteFeeForTarget(test.blocks)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fee != test.expectedFee {
+				t.Errorf(
+					"unexpected fee\nexpected: %d\nactual:   %d",
+					test.expectedFee,
+					fee,
+				)
+			}
+		})
+	}
+}
+
+func TestVbyteFeeForTarget_EmptyApiURL(t *testing.T) {
+	expectedError := "attempted to call VbyteFeeForTarget with no apiURL"
+	expectedFee := int32(0)
+
+	electrs := &electrsConnection{}
+
+	fee, err := electrs.VbyteFeeForTarget(2)
+	if err.Error() != expectedError {
+		t.Errorf(
+			"unexpected error\nexpected: %v\nactual:   %v",
+			expectedError,
+			err,
+		)
+	}
+	if fee != expectedFee {
+		t.Errorf("unexpected fee\nexpected: %d\nactual:   %d", expectedFee, fee)
+	}
+}
+
 func TestIsAddressUnused(t *testing.T) {
 	testData := map[string]struct {
 		btcAddress         string
@@ -226,13 +319,13 @@ func TestIsAddressUnused_ExpectedFailures(t *testing.T) {
 			"bcrt1qy6n80gen875en87ka798svvzrneq2erhhwfzzf",
 			200,
 			`[{"txi4fd49a9719be53affe55c4761abf00df1cda9b7a02419411bc9c04174c3f7","version":2,"locktime":14154,"vin":[{"txid":"fc4e62537ad932a84b4888826e10810119c854cbed1244091e864502282cee92","vout":0,"prevout":{"scriptpubkey":"001401160c280c429cb349c5c37d786eedb5b7bfd3e2","scriptpubkey_asm":"OP_0 OP_PUSHBYTES_20 01160c280c429cb349c5c37d786eedb5b7bfd3e2","scriptpubkey_type":"v0_p2wpkh","scriptpubkey_address":"bcrt1qqytqc2qvg2wtxjw9cd7hsmhdkkmml5lzqc04tk","value":9765625},"scriptsig":"","scriptsig_asm":"","witness":["304402202f3bf6827a90d566f7543119156d0b2aa19262379429ca58e95728479258c64d02202aee28e0083c900fe57a4a7f3f1a65fc2b012acdde88b99349a5bca17336486901","0360eda8d2a5e92cfa752f756a972442271381c1b2081f8e43e6f3475c7feedf76"],"is_coinbase":false,"sequence":4294967294},{"txid":"14e4defea6b2a164701562b0e9d379b23288b41b44ab8201749e5c5c312f0e59","vout":0,"prevout":{"scriptpubkey":"001401160c280c429cb349c5c37d786eedb5b7bfd3e2","scriptpubkey_asm":"OP_0 OP_PUSHBYTES_20 01160c280c429cb349c5c37d786eedb5b7bfd3e2","scriptpubkey_type":"v0_p2wpkh","scriptpubkey_address":"bcrt1qqytqc2qvg2wtxjw9cd7hsmhdkkmml5lzqc04tk","value":4768},"scriptsig":"","scriptsig_asm":"","witness":["304402206feee35d7a3de261c4ddd0e9bbccdc65b24226a9be8aef2bcacc87b032eca93702206e2040856bd7d61f3a8d9f24ef515e7660a99984f4e55fcd2f7ea16ad56ecf2b01","0360eda8d2a5e92cfa752f756a972442271381c1b2081f8e43e6f3475c7feedf76"],"is_coinbase":false,"sequence":4294967294},{"txid":"246d552c11741b7196f0f7dfd7c65a22daba9da20264edc26dbb8afcc2906465","vout":0,"prevout":{"scriptpubkey":"001401160c280c429cb349c5c37d786eedb5b7bfd3e2","scriptpubkey_asm":"OP_0 OP_PUSHBYTES_20 01160c280c429cb349c5c37d786eedb5b7bfd3e2","scriptpubkey_type":"v0_p2wpkh","scriptpubkey_address":"bcrt1qqytqc2qvg2wtxjw9cd7hsmhdkkmml5lzqc04tk","value":4768},"scriptsig":"","scriptsig_asm":"","witness":["304402206fc18ffa446f681d8901d7d211f7d972e5e18420ac991d44befd13f9740a26a5022030079817769551fb818149b1d146ff6c02859060e56118796b711ba6e9f5e37801","0360eda8d2a5e92cfa752f756a972442271381c1b2081f8e43e6f3475c7feedf76"],"is_coinbase":false,"sequence":4294967294},{"txid":"91517b69b67dee45c3cca4e2b1d5f0c4e04abc19363fc161b6dde9f72a2615ed","vout":0,"prevout":{"scriptpubkey":"001401160c280c429cb349c5c37d786eedb5b7bfd3e2","scriptpubkey_asm":"OP_0 OP_PUSHBYTES_20 01160c280c429cb349c5c37d786eedb5b7bfd3e2","scriptpubkey_type":"v0_p2wpkh","scriptpubkey_address":"bcrt1qqytqc2qvg2wtxjw9cd7hsmhdkkmml5lzqc04tk","value":152587},"scriptsig":"","scriptsig_asm":"","witness":["3044022073658ccf19f921eba6d46fa642dadaed54bf011438c63656a0466ed21ceaf58202202a90e99d3b9e599f1f88e0816f2b8076ca8238ef9b5149c0bfe1dfd5a4967a2801","0360eda8d2a5e92cfa752f756a972442271381c1b2081f8e43e6f3475c7feedf76"],"is_coinbase":false,"sequence":4294967294},{"txid":"8cff43db341777dc4a8de065a2357e5d546a922524adabc6c650b82bdb4a9db1","vout":0,"prevout":{"scriptpubkey":"001401160c280c429cb349c5c37d786eedb5b7bfd3e2","scriptpubkey_asm":"OP_0 OP_PUSHBYTES_20 01160c280c429cb349c5c37d786eedb5b7bfd3e2","scriptpubkey_type":"v0_p2wpkh","scriptpubkey_address":"bcrt1qqytqc2qvg2wtxjw9cd7hsmhdkkmml5lzqc04tk","value":10461},"scriptsig":"","scriptsig_asm":"","witness":["3044022056b40dd139f57caef154b327cb815e6976e4cacf226fa15c70f14ab0abe1059802204b864b4842e578c0ff11a0fea59bb91fb44162d1f7e9e9e048a1c560491cf62701","0360eda8d2a5e92cfa752f756a972442271381c1b2081f8e43e6f3475c7feedf76"],"is_coinbase":false,"sequence":4294967294},{"txid":"16c414db432c78fb2636e618b6b470265cbb68137d56101249c2d86a796cfc74","vout":0,"prevout":{"scriptpubkey":"001401160c280c429cb349c5c37d786eedb5b7bfd3e2","scriptpubkey_asm":"OP_0 OP_PUSHBYTES_20 01160c280c429cb349c5c37d786eedb5b7bfd3e2","scriptpubkey_type":"v0_p2wpkh","scriptpubkey_address":"bcrt1qqytqc2qvg2wtxjw9cd7hsmhdkkmml5lzqc04tk","value":12901},"scriptsig":"","scriptsig_asm":"","witness":["304402202c3d563122099edf16996dadf0bebcc5105eb6e5e53d5e5a8ab8939731e12ff802205bc8bdf99feef369b55832e5676a9d39f006643af35b7778c9dfa891a4b9792b01","0360eda8d2a5e92cfa752f756a972442271381c1b2081f8e43e6f3475c7feedf76"],"is_coinbase":false,"sequence":4294967294},{"txid":"3d93c2fef5cd7c8349170ec48dd96a4477bb6ca6958b6fb80a074ee7e80a421f","vout":0,"prevout":{"scriptpubkey":"001401160c280c429cb349c5c37d786eedb5b7bfd3e2","scriptpubkey_asm":"OP_0 OP_PUSHBYTES_20 01160c280c429cb349c5c37d786eedb5b7bfd3e2","scriptpubkey_type":"v0_p2wpkh","scriptpubkey_address":"bcrt1qqytqc2qvg2wtxjw9cd7hsmhdkkmml5lzqc04tk","value":19073},"scriptsig":"","scriptsig_asm":"","witness":["30440220034c0a3dbab8ccc7c9004ce8d5b1da25d3a527493368c98531acb47e147df37c02207646277753c9694aebc59ac47a230735c0c2b6c0ecf5d2251a9350b9171b330201","0360eda8d2a5e92cfa752f756a972442271381c1b2081f8e43e6f3475c7feedf76"],"is_coinbase":false,"sequence":4294967294},{"txid":"eb0d6c1f32f975b121fdbb0d5b010174fd47594fc7a2faa6184862d0688ac64d","vout":0,"prevout":{"scriptpubkey":"001401160c280c429cb349c5c37d786eedb5b7bfd3e2","scriptpubkey_asm":"OP_0 OP_PUSHBYTES_20 01160c280c429cb349c5c37d786eedb5b7bfd3e2","scriptpubkey_type":"v0_p2wpkh","scriptpubkey_address":"bcrt1qqytqc2qvg2wtxjw9cd7hsmhdkkmml5lzqc04tk","value":4768},"scriptsig":"","scriptsig_asm":"","witness":["30440220748e72af5018b7cc5774d7debe225b7ad304a1cfe9e0a469470affe9622fbac102200ea7c7a94bd7813b0ac472be36b1c7cb77d59b4b63363aac39e580003ec55c8501","0360eda8d2a5e92cfa752f756a972442271381c1b2081f8e43e6f3475c7feedf76"],"is_coinbase":false,"sequence":4294967294},{"txid":"0beb097b66aafd83f914dad6ebfee3bfbdc17c2111fa4dde964b928bba724330","vout":0,"prevout":{"scriptpubkey":"001401160c280c429cb349c5c37d786eedb5b7bfd3e2","scriptpubkey_asm":"OP_0 OP_PUSHBYTES_20 01160c280c429cb349c5c37d786eedb5b7bfd3e2","scriptpubkey_type":"v0_p2wpkh","scriptpubkey_address":"bcrt1qqytqc2qvg2wtxjw9cd7hsmhdkkmml5lzqc04tk","value":38146},"scriptsig":"","scriptsig_asm":"","witness":["30440220507ca93500dfd6f83f5b45b401b335563287a09906ee5a35e7877f2c1b87eaa202204c68e3eb43fa5f3054d823bacadfe644b4626e1b938d1ab564ee30dfe07dff8d01","0360eda8d2a5e92cfa752f756a972442271381c1b2081f8e43e6f3475c7feedf76"],"is_coinbase":false,"sequence":4294967294}],"vout":[{"scriptpubkey":"001426a677a3333fa9999fd6ef8a7831821cf2056477","scriptpubkey_asm":"OP_0 OP_PUSHBYTES_20 26a677a3333fa9999fd6ef8a7831821cf2056477","scriptpubkey_type":"v0_p2wpkh","scriptpubkey_address":"bcrt1qy6n80gen875en87ka798svvzrneq2erhhwfzzf","value":10000000}],"size":1375,"weight":2605,"fee":13097,"status":{"confirmed":true,"block_height":14208,"block_hash":"3c95707c627031feca93af0473cf5dc81e3f4fd6a660023924a85900d3b294ce","block_time":1620420106}}]`,
-			"failed to decode response body: [invalid character ',' after object key]",
+			"invalid character ',' after object key",
 		},
 		"unexpected integer response": {
 			"bcrt1qy6n80gen875en87ka798svvzrneq2erhhwfzzf",
 			200,
 			"73",
-			"failed to decode response body: [json: cannot unmarshal number into Go value of type []interface {}]",
+			"json: cannot unmarshal number into Go value of type []interface {}",
 		},
 	}
 
@@ -267,6 +360,736 @@ func TestIsAddressUnused_ExpectedFailures(t *testing.T) {
 	}
 }
 
+func TestAddressTransactionStatus(t *testing.T) {
+	btcAddress := "bcrt1qy6n80gen875en87ka798svvzrneq2erhhwfzzf"
+	confirmedTransaction := `{"txid":"2fd4fd49a9719be53affe55c4761abf00df1cda9b7a02419411bc9c04174c3f7","status":{"confirmed":true,"block_height":14208,"block_hash":"3c95707c627031feca93af0473cf5dc81e3f4fd6a660023924a85900d3b294ce","block_time":1620420106}}`
+	unconfirmedTransaction := `{"txid":"157617f0573262e466563272b643ce422dd378f86c0cfcac292776a979829b00","status":{"confirmed":false}}`
+
+	testData := map[string]struct {
+		response       string
+		expectedStatus *AddressStatus
+	}{
+		"no transactions": {
+			response:       `[]`,
+			expectedStatus: &AddressStatus{},
+		},
+		"confirmed transactions only": {
+			response:       fmt.Sprintf("[%s]", confirmedTransaction),
+			expectedStatus: &AddressStatus{HasConfirmed: true},
+		},
+		"unconfirmed transactions only": {
+			response:       fmt.Sprintf("[%s]", unconfirmedTransaction),
+			expectedStatus: &AddressStatus{HasUnconfirmed: true},
+		},
+		"confirmed and unconfirmed transactions": {
+			response: fmt.Sprintf(
+				"[%s,%s]",
+				unconfirmedTransaction,
+				confirmedTransaction,
+			),
+			expectedStatus: &AddressStatus{
+				HasConfirmed:   true,
+				HasUnconfirmed: true,
+			},
+		},
+	}
+	for testName, testData := range testData {
+		t.Run(testName, func(t *testing.T) {
+			electrs := newTestElectrsConnection(
+				mockClient{
+					mockGet: mockGet(
+						fmt.Sprintf("%s/address/%s/txs", testAPIURL, btcAddress),
+						200,
+						testData.response,
+						t,
+					),
+				},
+			)
+
+			status, err := electrs.AddressTransactionStatus(btcAddress)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(testData.expectedStatus, status) {
+				t.Errorf(
+					"unexpected address status\nexpected: %+v\nactual:   %+v",
+					testData.expectedStatus,
+					status,
+				)
+			}
+		})
+	}
+}
+
+func TestAddressTransactionStatus_ExpectFailure(t *testing.T) {
+	btcAddress := "banana"
+	expectedError := "something went wrong trying to get information about address [banana] - status: [400 Bad Request], payload: [Invalid Bitcoin address]"
+
+	electrs := newTestElectrsConnection(
+		mockClient{
+			mockGet: mockGet(
+				fmt.Sprintf("%s/address/%s/txs", testAPIURL, btcAddress),
+				400,
+				"Invalid Bitcoin address",
+				t,
+			),
+		},
+	)
+
+	status, err := electrs.AddressTransactionStatus(btcAddress)
+	checkWrappedError(err, expectedError, t)
+	if status != nil {
+		t.Errorf("unexpected address status: [%+v]", status)
+	}
+}
+
+func TestDecodeFailuresAreNotRetried(t *testing.T) {
+	btcAddress := "bcrt1qy6n80gen875en87ka798svvzrneq2erhhwfzzf"
+
+	testData := map[string]struct {
+		url  string
+		call func(electrs *electrsConnection) error
+	}{
+		"IsAddressUnused": {
+			url: fmt.Sprintf("%s/address/%s/txs", testAPIURL, btcAddress),
+			call: func(electrs *electrsConnection) error {
+				_, err := electrs.IsAddressUnused(btcAddress)
+				return err
+			},
+		},
+		"VbyteFeeFor25Blocks": {
+			url: fmt.Sprintf("%s/fee-estimates", testAPIURL),
+			call: func(electrs *electrsConnection) error {
+				_, err := electrs.VbyteFeeFor25Blocks()
+				return err
+			},
+		},
+	}
+
+	for testName, testData := range testData {
+		t.Run(testName, func(t *testing.T) {
+			calls := 0
+
+			electrs := newTestElectrsConnection(
+				mockClient{
+					mockGet: func(url string) (*http.Response, error) {
+						calls++
+						return mockGet(testData.url, 200, "{not json", t)(url)
+					},
+				},
+			)
+			// Long enough to let the call be retried if it was going to be.
+			electrs.timeout = 5 * time.Second
+
+			startTime := time.Now()
+			err := testData.call(electrs)
+			elapsedTime := time.Since(startTime)
+
+			if err == nil {
+				t.Fatal("expected decode error")
+			}
+			if calls != 1 {
+				t.Errorf(
+					"unexpected number of calls\nexpected: %d\nactual:   %d",
+					1,
+					calls,
+				)
+			}
+			if elapsedTime >= utils.DefaultDoBackoffTime {
+				t.Errorf("call has been retried; elapsed time: [%v]", elapsedTime)
+			}
+		})
+	}
+}
+
+func TestGetUnspentOutputs(t *testing.T) {
+	btcAddress := "bcrt1qy6n80gen875en87ka798svvzrneq2erhhwfzzf"
+	mockedResponseBody := `[{"txid":"2fd4fd49a9719be53affe55c4761abf00df1cda9b7a02419411bc9c04174c3f7","vout":0,"status":{"confirmed":true,"block_height":14208,"block_hash":"3c95707c627031feca93af0473cf5dc81e3f4fd6a660023924a85900d3b294ce","block_time":1620420106},"value":10000000},{"txid":"157617f0573262e466563272b643ce422dd378f86c0cfcac292776a979829b00","vout":2,"status":{"confirmed":false},"value":3329033}]`
+	expectedUnspentOutputs := []UnspentOutput{
+		{
+			TransactionID: "2fd4fd49a9719be53affe55c4761abf00df1cda9b7a02419411bc9c04174c3f7",
+			OutputIndex:   0,
+			Value:         10000000,
+		},
+		{
+			TransactionID: "157617f0573262e466563272b643ce422dd378f86c0cfcac292776a979829b00",
+			OutputIndex:   2,
+			Value:         3329033,
+		},
+	}
+
+	electrs := newTestElectrsConnection(
+		mockClient{
+			mockGet: mockGet(
+				fmt.Sprintf("%s/address/%s/utxo", testAPIURL, btcAddress),
+				200,
+				mockedResponseBody,
+				t,
+			),
+		},
+	)
+
+	unspentOutputs, err := electrs.GetUnspentOutputs(btcAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(expectedUnspentOutputs, unspentOutputs) {
+		t.Errorf(
+			"unexpected unspent outputs\nexpected: %+v\nactual:   %+v",
+			expectedUnspentOutputs,
+			unspentOutputs,
+		)
+	}
+}
+
+func TestGetUnspentOutputs_EmptyApiURL(t *testing.T) {
+	expectedError := "attempted to call GetUnspentOutputs with no apiURL"
+
+	electrs := &electrsConnection{}
+
+	_, err := electrs.GetUnspentOutputs("BtcAddress123")
+	if err.Error() != expectedError {
+		t.Errorf(
+			"unexpected error\nexpected: %v\nactual:   %v",
+			expectedError,
+			err,
+		)
+	}
+}
+
+func TestGetUnspentOutputs_ExpectFailure(t *testing.T) {
+	btcAddress := "banana"
+	expectedError := "failed to get unspent outputs of address [banana] - status: [400 Bad Request], payload: [Invalid Bitcoin address]"
+
+	electrs := newTestElectrsConnection(
+		mockClient{
+			mockGet: mockGet(
+				fmt.Sprintf("%s/address/%s/utxo", testAPIURL, btcAddress),
+				400,
+				"Invalid Bitcoin address",
+				t,
+			),
+		},
+	)
+
+	_, err := electrs.GetUnspentOutputs(btcAddress)
+	checkWrappedError(err, expectedError, t)
+}
+
+func TestGetRawTransaction(t *testing.T) {
+	txid := "2fd4fd49a9719be53affe55c4761abf00df1cda9b7a02419411bc9c04174c3f7"
+	expectedRawTransaction := "01000000000101ba84a592005742406bd1d6683e3a894c7ab13385bd437ff7bd7c74929bf1413200000000"
+
+	electrs := newTestElectrsConnection(
+		mockClient{
+			mockGet: mockGet(
+				fmt.Sprintf("%s/tx/%s/hex", testAPIURL, txid),
+				200,
+				expectedRawTransaction,
+				t,
+			),
+		},
+	)
+
+	rawTransaction, err := electrs.GetRawTransaction(txid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rawTransaction != expectedRawTransaction {
+		t.Errorf(
+			"unexpected raw transaction\nexpected: %s\nactual:   %s",
+			expectedRawTransaction,
+			rawTransaction,
+		)
+	}
+}
+
+func TestGetRawTransaction_EmptyApiURL(t *testing.T) {
+	expectedError := "attempted to call GetRawTransaction with no apiURL"
+
+	electrs := &electrsConnection{}
+
+	_, err := electrs.GetRawTransaction("abc")
+	if err.Error() != expectedError {
+		t.Errorf(
+			"unexpected error\nexpected: %v\nactual:   %v",
+			expectedError,
+			err,
+		)
+	}
+}
+
+func TestGetRawTransaction_ExpectedFailures(t *testing.T) {
+	txid := "157617f0573262e466563272b643ce422dd378f86c0cfcac292776a979829b00"
+
+	var tests = map[string]struct {
+		responseCode     int
+		responseBody     string
+		expectedNotFound bool
+		expectedError    string
+	}{
+		"unknown transaction": {
+			responseCode:     404,
+			responseBody:     "Transaction not found",
+			expectedNotFound: true,
+			expectedError:    fmt.Sprintf("transaction not found: [%s]", txid),
+		},
+		"server error": {
+			responseCode:     500,
+			responseBody:     "the dumpster is on fire",
+			expectedNotFound: false,
+			expectedError: fmt.Sprintf(
+				"retry timeout [100ms] exceeded; most recent error: "+
+					"[failed to get transaction [%s] - status: "+
+					"[500 Internal Server Error], payload: [the dumpster is on fire]]",
+				txid,
+			),
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			electrs := newTestElectrsConnection(
+				mockClient{
+					mockGet: mockGet(
+						fmt.Sprintf("%s/tx/%s/hex", testAPIURL, txid),
+						test.responseCode,
+						test.responseBody,
+						t,
+					),
+				},
+			)
+
+			_, err := electrs.GetRawTransaction(txid)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if err.Error() != test.expectedError {
+				t.Errorf(
+					"unexpected error\nexpected: %v\nactual:   %v",
+					test.expectedError,
+					err,
+				)
+			}
+			if errors.Is(err, ErrTransactionNotFound) != test.expectedNotFound {
+				t.Errorf(
+					"unexpected not found error\nexpected: %v\nactual:   %v",
+					test.expectedNotFound,
+					errors.Is(err, ErrTransactionNotFound),
+				)
+			}
+		})
+	}
+}
+
+func TestGetTransactionConfirmations(t *testing.T) {
+	txid := "2fd4fd49a9719be53affe55c4761abf00df1cda9b7a02419411bc9c04174c3f7"
+
+	var tests = map[string]struct {
+		statusResponseBody    string
+		expectedConfirmations int
+	}{
+		"transaction 3 blocks deep": {
+			statusResponseBody:    `{"confirmed":true,"block_height":14208,"block_hash":"3c95707c627031feca93af0473cf5dc81e3f4fd6a660023924a85900d3b294ce","block_time":1620420106}`,
+			expectedConfirmations: 3,
+		},
+		"unconfirmed transaction": {
+			statusResponseBody:    `{"confirmed":false}`,
+			expectedConfirmations: 0,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			electrs := newTestElectrsConnection(
+				mockClient{
+					mockGet: mockGetRoutes(
+						map[string]string{
+							fmt.Sprintf("%s/tx/%s/status", testAPIURL, txid): test.statusResponseBody,
+							fmt.Sprintf("%s/blocks/tip/height", testAPIURL):  "14210",
+						},
+						t,
+					),
+				},
+			)
+
+			confirmations, err := electrs.GetTransactionConfirmations(txid)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if confirmations != test.expectedConfirmations {
+				t.Errorf(
+					"unexpected confirmations\nexpected: %d\nactual:   %d",
+					test.expectedConfirmations,
+					confirmations,
+				)
+			}
+		})
+	}
+}
+
+func TestGetTransactionConfirmations_UnknownTransaction(t *testing.T) {
+	txid := "157617f0573262e466563272b643ce422dd378f86c0cfcac292776a979829b00"
+
+	electrs := newTestElectrsConnection(
+		mockClient{
+			mockGet: mockGet(
+				fmt.Sprintf("%s/tx/%s/status", testAPIURL, txid),
+				404,
+				"Transaction not found",
+				t,
+			),
+		},
+	)
+
+	_, err := electrs.GetTransactionConfirmations(txid)
+	if !errors.Is(err, ErrTransactionNotFound) {
+		t.Errorf(
+			"unexpected error\nexpected: %v\nactual:   %v",
+			ErrTransactionNotFound,
+			err,
+		)
+	}
+}
+
+func TestGetTransactionConfirmations_EmptyApiURL(t *testing.T) {
+	expectedError := "attempted to call GetTransactionConfirmations with no apiURL"
+
+	electrs := &electrsConnection{}
+
+	_, err := electrs.GetTransactionConfirmations("abc")
+	if err.Error() != expectedError {
+		t.Errorf(
+			"unexpected error\nexpected: %v\nactual:   %v",
+			expectedError,
+			err,
+		)
+	}
+}
+
+func TestConnect_WithFailover(t *testing.T) {
+	failingAPIURL := "failing.example.org/api"
+	workingAPIURL := "working.example.org/api"
+	btcAddress := "bcrt1qy6n80gen875en87ka798svvzrneq2erhhwfzzf"
+
+	failingCalls := 0
+	workingCalls := 0
+
+	electrs := Connect(
+		failingAPIURL,
+		WithFailover(workingAPIURL),
+	).(*electrsConnection)
+	electrs.timeout = 100 * time.Millisecond
+	electrs.setClient(
+		mockClient{
+			mockGet: func(url string) (*http.Response, error) {
+				switch url {
+				case fmt.Sprintf("%s/address/%s/txs", failingAPIURL, btcAddress):
+					failingCalls++
+					return nil, fmt.Errorf("connection refused")
+				case fmt.Sprintf("%s/address/%s/txs", workingAPIURL, btcAddress):
+					workingCalls++
+					return mockResponse(200, "[]"), nil
+				default:
+					t.Fatalf("unexpected url: %s", url)
+					return nil, nil
+				}
+			},
+		},
+	)
+
+	for i := 0; i < 2; i++ {
+		isAddressUnused, err := electrs.IsAddressUnused(btcAddress)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !isAddressUnused {
+			t.Errorf("expected address to be unused")
+		}
+	}
+
+	// The working endpoint is remembered after the first call, so the failing
+	// one is not tried again.
+	if failingCalls != 1 {
+		t.Errorf(
+			"unexpected number of calls to the failing endpoint\nexpected: %d\nactual:   %d",
+			1,
+			failingCalls,
+		)
+	}
+	if workingCalls != 2 {
+		t.Errorf(
+			"unexpected number of calls to the working endpoint\nexpected: %d\nactual:   %d",
+			2,
+			workingCalls,
+		)
+	}
+}
+
+func TestConnect_WithFailoverAllEndpointsFailing(t *testing.T) {
+	expectedError := "failed to get fee estimates - status: [503 Service Unavailable], payload: [second]"
+
+	electrs := Connect(
+		"first.example.org/api",
+		WithFailover("second.example.org/api"),
+	).(*electrsConnection)
+	electrs.timeout = 100 * time.Millisecond
+	electrs.setClient(
+		mockClient{
+			mockGet: func(url string) (*http.Response, error) {
+				if url == "first.example.org/api/fee-estimates" {
+					return mockResponse(503, "first"), nil
+				}
+				return mockResponse(503, "second"), nil
+			},
+		},
+	)
+
+	_, err := electrs.VbyteFeeFor25Blocks()
+	checkWrappedError(err, expectedError, t)
+}
+
+func TestConnect_WithTimeout(t *testing.T) {
+	timeout := 50 * time.Millisecond
+	expectedError := "retry timeout [50ms] exceeded; most recent error: [context deadline exceeded]"
+
+	electrs := Connect(testAPIURL, WithTimeout(timeout)).(*electrsConnection)
+	electrs.setClient(
+		mockClient{
+			mockGet: func(url string) (*http.Response, error) {
+				time.Sleep(10 * timeout)
+				return mockResponse(200, "[]"), nil
+			},
+		},
+	)
+
+	startTime := time.Now()
+	_, err := electrs.IsAddressUnused("bcrt1qy6n80gen875en87ka798svvzrneq2erhhwfzzf")
+	elapsedTime := time.Since(startTime)
+
+	if err == nil || err.Error() != expectedError {
+		t.Errorf(
+			"unexpected error\nexpected: %v\nactual:   %v",
+			expectedError,
+			err,
+		)
+	}
+	if elapsedTime >= 10*timeout {
+		t.Errorf("call did not honor the timeout; elapsed time: [%v]", elapsedTime)
+	}
+}
+
+func TestConnect_WithInvalidTimeout(t *testing.T) {
+	electrs := Connect(testAPIURL, WithTimeout(0)).(*electrsConnection)
+
+	if electrs.timeout != defaultTimeout {
+		t.Errorf(
+			"unexpected timeout\nexpected: %v\nactual:   %v",
+			defaultTimeout,
+			electrs.timeout,
+		)
+	}
+}
+
+func TestConnect_WithRateLimit(t *testing.T) {
+	requestsPerSecond := 20.0
+	interval := time.Duration(float64(time.Second) / requestsPerSecond)
+	requestsCount := 5
+
+	electrs := Connect(testAPIURL, WithRateLimit(requestsPerSecond, 1)).(*electrsConnection)
+
+	requestTimes := make([]time.Time, 0)
+	electrs.setClient(
+		mockClient{
+			mockGet: func(url string) (*http.Response, error) {
+				requestTimes = append(requestTimes, time.Now())
+				return mockResponse(200, "[]"), nil
+			},
+		},
+	)
+
+	for i := 0; i < requestsCount; i++ {
+		_, err := electrs.IsAddressUnused("bcrt1qy6n80gen875en87ka798svvzrneq2erhhwfzzf")
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(requestTimes) != requestsCount {
+		t.Fatalf(
+			"unexpected number of requests\nexpected: %v\nactual:   %v",
+			requestsCount,
+			len(requestTimes),
+		)
+	}
+
+	// Allow for the timer granularity.
+	minSpacing := interval - 5*time.Millisecond
+	for i := 1; i < len(requestTimes); i++ {
+		spacing := requestTimes[i].Sub(requestTimes[i-1])
+		if spacing < minSpacing {
+			t.Errorf(
+				"requests [%v] and [%v] not spaced according to the rate limit\n"+
+					"expected: >= %v\nactual:   %v",
+				i-1,
+				i,
+				interval,
+				spacing,
+			)
+		}
+	}
+}
+
+func TestConnect_WithRateLimitWaitHonorsTimeout(t *testing.T) {
+	electrs := Connect(testAPIURL, WithRateLimit(0.1, 1)).(*electrsConnection)
+	electrs.timeout = 50 * time.Millisecond
+
+	requestsCount := 0
+	electrs.setClient(
+		mockClient{
+			mockGet: func(url string) (*http.Response, error) {
+				requestsCount++
+				return mockResponse(200, "[]"), nil
+			},
+		},
+	)
+
+	// The first request takes the only token.
+	_, err := electrs.IsAddressUnused("bcrt1qy6n80gen875en87ka798svvzrneq2erhhwfzzf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	startTime := time.Now()
+	_, err = electrs.IsAddressUnused("bcrt1qy6n80gen875en87ka798svvzrneq2erhhwfzzf")
+	elapsedTime := time.Since(startTime)
+
+	if err == nil {
+		t.Errorf("expected error when the rate limit is not lifted in time")
+	}
+	if elapsedTime < electrs.timeout {
+		t.Errorf("call should block until the timeout; elapsed time: [%v]", elapsedTime)
+	}
+	if elapsedTime >= time.Second {
+		t.Errorf("call did not honor the timeout; elapsed time: [%v]", elapsedTime)
+	}
+	if requestsCount != 1 {
+		t.Errorf(
+			"unexpected number of requests\nexpected: %v\nactual:   %v",
+			1,
+			requestsCount,
+		)
+	}
+}
+
+func TestConnect_WithInvalidRateLimit(t *testing.T) {
+	electrs := Connect(testAPIURL, WithRateLimit(0, 1)).(*electrsConnection)
+
+	if electrs.rateLimiter != nil {
+		t.Errorf("rate should not be limited")
+	}
+}
+
+func TestConnect_WithFeeEstimatesCache(t *testing.T) {
+	ttl := 30 * time.Second
+
+	electrs := Connect(testAPIURL, WithFeeEstimatesCache(ttl)).(*electrsConnection)
+
+	currentTime := time.Now()
+	electrs.feeEstimatesCache.now = func() time.Time {
+		return currentTime
+	}
+
+	requestsCount := 0
+	electrs.setClient(
+		mockClient{
+			mockGet: func(url string) (*http.Response, error) {
+				requestsCount++
+				return mockResponse(200, `{"25": 2.5}`), nil
+			},
+		},
+	)
+
+	assertRequestsCount := func(expectedRequestsCount int) {
+		fee, err := electrs.VbyteFeeFor25Blocks()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if fee != 2 {
+			t.Errorf(
+				"unexpected fee\nexpected: %v\nactual:   %v",
+				2,
+				fee,
+			)
+		}
+
+		if requestsCount != expectedRequestsCount {
+			t.Errorf(
+				"unexpected number of requests\nexpected: %v\nactual:   %v",
+				expectedRequestsCount,
+				requestsCount,
+			)
+		}
+	}
+
+	assertRequestsCount(1)
+
+	currentTime = currentTime.Add(ttl - time.Second)
+	assertRequestsCount(1)
+
+	currentTime = currentTime.Add(time.Second)
+	assertRequestsCount(2)
+}
+
+func TestConnect_WithFeeEstimatesCacheErrorsNotCached(t *testing.T) {
+	electrs := Connect(testAPIURL, WithFeeEstimatesCache(time.Minute)).(*electrsConnection)
+	electrs.timeout = 100 * time.Millisecond
+
+	responseBody := "not json"
+	requestsCount := 0
+	electrs.setClient(
+		mockClient{
+			mockGet: func(url string) (*http.Response, error) {
+				requestsCount++
+				return mockResponse(200, responseBody), nil
+			},
+		},
+	)
+
+	_, err := electrs.VbyteFeeFor25Blocks()
+	if err == nil {
+		t.Fatal("expected error for malformed fee estimates")
+	}
+
+	responseBody = `{"25": 2.5}`
+
+	_, err = electrs.VbyteFeeFor25Blocks()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if requestsCount != 2 {
+		t.Errorf(
+			"unexpected number of requests\nexpected: %v\nactual:   %v",
+			2,
+			requestsCount,
+		)
+	}
+}
+
+func TestConnect_WithInvalidFeeEstimatesCacheTTL(t *testing.T) {
+	electrs := Connect(testAPIURL, WithFeeEstimatesCache(0)).(*electrsConnection)
+
+	if electrs.feeEstimatesCache.ttl != defaultFeeEstimatesCacheTTL {
+		t.Errorf(
+			"unexpected fee estimates cache TTL\nexpected: %v\nactual:   %v",
+			defaultFeeEstimatesCacheTTL,
+			electrs.feeEstimatesCache.ttl,
+		)
+	}
+}
+
 const testAPIURL = "example.org/api"
 
 func newTestElectrsConnection(client mockClient) *electrsConnection {
@@ -303,6 +1126,19 @@ func mockGet(expectedURL string, responseStatusCode int, responseBody string, t
 	}
 }
 
+// mockGetRoutes returns a mock GET function responding with status 200 and
+// the body configured for the requested url.
+func mockGetRoutes(routes map[string]string, t *testing.T) func(url string) (*http.Response, error) {
+	return func(url string) (*http.Response, error) {
+		responseBody, ok := routes[url]
+		if !ok {
+			t.Fatalf("unexpected url: %s", url)
+		}
+
+		return mockResponse(200, responseBody), nil
+	}
+}
+
 func mockPost(expectedURL string, expectedRequestBody string, responseStatusCode int, responseBody string, t *testing.T) func(url string, contentType string, reader io.Reader) (*http.Response, error) {
 	return func(url string, contentType string, body io.Reader) (*http.Response, error) {
 		if url != expectedURL {
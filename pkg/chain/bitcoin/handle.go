@@ -3,6 +3,25 @@ package bitcoin
 // Handle serves as an interface abstraction around bitcoin network queries
 type Handle interface {
 	Broadcast(transaction string) error
+	IsDryRun() bool
 	VbyteFeeFor25Blocks() (int32, error)
+	VbyteFeeForTarget(blocks int) (int32, error)
 	IsAddressUnused(btcAddress string) (bool, error)
+	AddressTransactionStatus(btcAddress string) (*AddressStatus, error)
+	GetUnspentOutputs(btcAddress string) ([]UnspentOutput, error)
+	GetRawTransaction(txid string) (string, error)
+	GetTransactionConfirmations(txid string) (int, error)
+}
+
+// AddressStatus describes transactions recorded for a bitcoin address.
+type AddressStatus struct {
+	HasConfirmed   bool
+	HasUnconfirmed bool
+}
+
+// UnspentOutput is an unspent transaction output of a bitcoin address.
+type UnspentOutput struct {
+	TransactionID string `json:"txid"`
+	OutputIndex   uint32 `json:"vout"`
+	Value         int64  `json:"value"`
 }
@@ -0,0 +1,76 @@
+package bitcoin
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// rateLimiter is a token bucket limiting the rate of electrs API requests.
+// The bucket holds up to burst tokens and is refilled with one token every
+// interval. Every request takes one token, waiting for it if the bucket is
+// empty. A nil rateLimiter does not limit requests.
+type rateLimiter struct {
+	mutex sync.Mutex
+
+	interval time.Duration
+	burst    float64
+
+	tokens     float64
+	lastRefill time.Time
+}
+
+func newRateLimiter(requestsPerSecond float64, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &rateLimiter{
+		interval:   time.Duration(float64(time.Second) / requestsPerSecond),
+		burst:      float64(burst),
+		tokens:     float64(burst),
+		lastRefill: time.Now(),
+	}
+}
+
+// wait blocks until a request can be sent without exceeding the rate limit.
+// It returns the context error if the context is done before that happens.
+func (rl *rateLimiter) wait(ctx context.Context) error {
+	if rl == nil {
+		return nil
+	}
+
+	rl.mutex.Lock()
+	now := time.Now()
+	rl.tokens += float64(now.Sub(rl.lastRefill)) / float64(rl.interval)
+	if rl.tokens > rl.burst {
+		rl.tokens = rl.burst
+	}
+	rl.lastRefill = now
+
+	// Tokens can go below zero; each request waiting for a token reserves
+	// the next one to be refilled.
+	rl.tokens--
+	delay := time.Duration(-rl.tokens * float64(rl.interval))
+	rl.mutex.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// Give the reserved token back so the requests waiting behind this
+		// one do not wait for it.
+		rl.mutex.Lock()
+		rl.tokens++
+		rl.mutex.Unlock()
+
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,72 @@
+package bitcoin
+
+import (
+	"sync"
+	"time"
+)
+
+// defaultFeeEstimatesCacheTTL is the default period within which fee
+// estimates fetched from the electrs API are reused.
+const defaultFeeEstimatesCacheTTL = 30 * time.Second
+
+// responseCache keeps bodies of successful electrs API responses, keyed by
+// the API path, for the configured time to live. A nil responseCache does
+// not cache anything.
+type responseCache struct {
+	mutex   sync.Mutex
+	ttl     time.Duration
+	entries map[string]cachedResponse
+
+	now func() time.Time
+}
+
+type cachedResponse struct {
+	body      []byte
+	fetchedAt time.Time
+}
+
+func newResponseCache(ttl time.Duration) *responseCache {
+	return &responseCache{
+		ttl:     ttl,
+		entries: make(map[string]cachedResponse),
+		now:     time.Now,
+	}
+}
+
+// get returns the cached response body for the given API path if it has
+// been cached within the time to live.
+func (rc *responseCache) get(path string) ([]byte, bool) {
+	if rc == nil {
+		return nil, false
+	}
+
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+
+	entry, ok := rc.entries[path]
+	if !ok {
+		return nil, false
+	}
+
+	if rc.now().Sub(entry.fetchedAt) >= rc.ttl {
+		delete(rc.entries, path)
+		return nil, false
+	}
+
+	return entry.body, true
+}
+
+// put caches the response body for the given API path.
+func (rc *responseCache) put(path string, body []byte) {
+	if rc == nil {
+		return
+	}
+
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+
+	rc.entries[path] = cachedResponse{
+		body:      body,
+		fetchedAt: rc.now(),
+	}
+}
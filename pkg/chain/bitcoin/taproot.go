@@ -0,0 +1,138 @@
+package bitcoin
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil/bech32"
+)
+
+// bech32mConstant is the checksum constant of the bech32m encoding used for
+// segwit v1+ addresses as per [BIP350].
+//
+// [BIP350]: https://github.com/bitcoin/bips/blob/master/bip-0350.mediawiki
+const bech32mConstant = 0x2bc830a3
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// taprootWitnessVersion is the segwit witness version of taproot outputs.
+const taprootWitnessVersion = 1
+
+// taprootOutputKey computes the taproot output key for a key path only
+// spending of the given internal public key, as per [BIP86]. The internal key
+// is tweaked with the tagged hash of its x coordinate, with no script tree
+// committed. The returned key is the 32-byte x-only output key.
+//
+// [BIP86]: https://github.com/bitcoin/bips/blob/master/bip-0086.mediawiki
+func taprootOutputKey(internalKey *btcec.PublicKey) ([]byte, error) {
+	curve := btcec.S256()
+
+	// BIP340 x-only keys implicitly have an even y coordinate.
+	internalX := internalKey.X
+	internalY := internalKey.Y
+	if internalY.Bit(0) == 1 {
+		internalY = new(big.Int).Sub(curve.P, internalY)
+	}
+
+	internalXBytes := make([]byte, 32)
+	internalX.FillBytes(internalXBytes)
+
+	tweak := taggedHash("TapTweak", internalXBytes)
+	if new(big.Int).SetBytes(tweak).Cmp(curve.N) >= 0 {
+		return nil, fmt.Errorf("taproot tweak exceeds the curve order")
+	}
+
+	tweakX, tweakY := curve.ScalarBaseMult(tweak)
+	outputX, _ := curve.Add(internalX, internalY, tweakX, tweakY)
+
+	outputKey := make([]byte, 32)
+	outputX.FillBytes(outputKey)
+
+	return outputKey, nil
+}
+
+// taggedHash computes the BIP340 tagged hash of the given message.
+func taggedHash(tag string, message []byte) []byte {
+	tagHash := sha256.Sum256([]byte(tag))
+
+	hash := sha256.New()
+	hash.Write(tagHash[:])
+	hash.Write(tagHash[:])
+	hash.Write(message)
+
+	return hash.Sum(nil)
+}
+
+// encodeTaprootAddress encodes the given taproot output key as a bech32m
+// segwit v1 address for the given network.
+func encodeTaprootAddress(
+	outputKey []byte,
+	chainParams *chaincfg.Params,
+) (string, error) {
+	program, err := bech32.ConvertBits(outputKey, 8, 5, true)
+	if err != nil {
+		return "", fmt.Errorf("failed to convert witness program: [%v]", err)
+	}
+
+	data := append([]byte{taprootWitnessVersion}, program...)
+
+	return encodeBech32m(chainParams.Bech32HRPSegwit, data), nil
+}
+
+// encodeBech32m encodes the given 5-bit data with the given human readable
+// part using the bech32m checksum.
+func encodeBech32m(humanReadablePart string, data []byte) string {
+	values := bech32ExpandHumanReadablePart(humanReadablePart)
+	values = append(values, data...)
+	values = append(values, 0, 0, 0, 0, 0, 0)
+
+	checksum := bech32Polymod(values) ^ bech32mConstant
+
+	encoded := &strings.Builder{}
+	encoded.WriteString(humanReadablePart)
+	encoded.WriteString("1")
+	for _, value := range data {
+		encoded.WriteByte(bech32Charset[value])
+	}
+	for i := 0; i < 6; i++ {
+		encoded.WriteByte(bech32Charset[(checksum>>uint(5*(5-i)))&31])
+	}
+
+	return encoded.String()
+}
+
+func bech32ExpandHumanReadablePart(humanReadablePart string) []byte {
+	expanded := make([]byte, 0, len(humanReadablePart)*2+1)
+	for i := 0; i < len(humanReadablePart); i++ {
+		expanded = append(expanded, humanReadablePart[i]>>5)
+	}
+	expanded = append(expanded, 0)
+	for i := 0; i < len(humanReadablePart); i++ {
+		expanded = append(expanded, humanReadablePart[i]&31)
+	}
+
+	return expanded
+}
+
+func bech32Polymod(values []byte) uint32 {
+	generator := []uint32{
+		0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3,
+	}
+
+	checksum := uint32(1)
+	for _, value := range values {
+		top := checksum >> 25
+		checksum = (checksum&0x1ffffff)<<5 ^ uint32(value)
+		for i := 0; i < 5; i++ {
+			if (top>>uint(i))&1 == 1 {
+				checksum ^= generator[i]
+			}
+		}
+	}
+
+	return checksum
+}
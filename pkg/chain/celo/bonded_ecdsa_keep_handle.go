@@ -14,15 +14,19 @@ import (
 	"github.com/keep-network/keep-common/pkg/chain/ethlike"
 	"github.com/keep-network/keep-common/pkg/subscription"
 	"github.com/keep-network/keep-ecdsa/pkg/chain"
+	"github.com/keep-network/keep-ecdsa/pkg/chain/gen/celo/abi"
 	"github.com/keep-network/keep-ecdsa/pkg/chain/gen/celo/contract"
 	"github.com/keep-network/keep-ecdsa/pkg/ecdsa"
 	"github.com/keep-network/keep-ecdsa/pkg/utils/byteutils"
 )
 
 type bondedEcdsaKeepHandle struct {
-	keepID     chain.ID
-	operatorID chain.ID
-	contract   *contract.BondedECDSAKeep
+	keepID       chain.ID
+	keepAddress  common.Address
+	operatorID   chain.ID
+	contract     *contract.BondedECDSAKeep
+	blockCounter *ethlike.BlockCounter
+	client       celoutil.CeloClient
 }
 
 func (cc *celoChain) GetKeepWithID(
@@ -52,9 +56,12 @@ func (cc *celoChain) GetKeepWithID(
 	}
 
 	return &bondedEcdsaKeepHandle{
-		keepID:     keepID,
-		operatorID: cc.OperatorID(),
-		contract:   bondedECDSAKeepContract,
+		keepID:       keepID,
+		keepAddress:  keepAddress,
+		operatorID:   cc.OperatorID(),
+		contract:     bondedECDSAKeepContract,
+		blockCounter: cc.blockCounter,
+		client:       cc.client,
 	}, nil
 }
 
@@ -75,28 +82,172 @@ func (cc *celoChain) GetKeepAtIndex(
 	return cc.GetKeepWithID(celoChainID(keepAddress))
 }
 
+// GetKeepsForOperator returns IDs of all keeps the given operator is a member
+// of. It scans all keeps created by the factory.
+func (cc *celoChain) GetKeepsForOperator(
+	operatorID chain.ID,
+) ([]chain.ID, error) {
+	if _, err := fromChainID(operatorID); err != nil {
+		return nil, fmt.Errorf(
+			"unable to interpret operator ID [%v]: [%v]",
+			operatorID,
+			err,
+		)
+	}
+
+	keepCount, err := cc.bondedECDSAKeepFactoryContract.GetKeepCount()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get keep count: [%v]", err)
+	}
+
+	// Keeps already checked during this call are remembered so members of
+	// a keep the operator does not belong to are never read twice.
+	checkedKeeps := make(map[common.Address]bool)
+
+	keepIDs := make([]chain.ID, 0)
+	for i := uint64(0); i < keepCount.Uint64(); i++ {
+		keepIndex := new(big.Int).SetUint64(i)
+
+		keepAddress, err := cc.bondedECDSAKeepFactoryContract.GetKeepAtIndex(
+			keepIndex,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to look up keep address for index [%v]: [%v]",
+				keepIndex,
+				err,
+			)
+		}
+
+		if checkedKeeps[keepAddress] {
+			continue
+		}
+		checkedKeeps[keepAddress] = true
+
+		keep, err := cc.GetKeepWithID(celoChainID(keepAddress))
+		if err != nil {
+			return nil, err
+		}
+
+		members, err := keep.GetMembers()
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to get members of keep [%v]: [%v]",
+				keepAddress.String(),
+				err,
+			)
+		}
+
+		for _, member := range members {
+			if member.String() == operatorID.String() {
+				keepIDs = append(keepIDs, keep.ID())
+				break
+			}
+		}
+	}
+
+	return keepIDs, nil
+}
+
+// GetApplicationKeeps returns IDs of all keeps opened by the given
+// application. Keeps are looked up with the application filter applied to
+// the keep creation events emitted by the factory, so keeps of other
+// applications are not inspected.
+func (cc *celoChain) GetApplicationKeeps(
+	applicationID chain.ID,
+) ([]chain.ID, error) {
+	applicationAddress, err := fromChainID(applicationID)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"unable to interpret application ID [%v]: [%v]",
+			applicationID,
+			err,
+		)
+	}
+
+	events, err := cc.bondedECDSAKeepFactoryContract.PastBondedECDSAKeepCreatedEvents(
+		0,
+		nil, // latest block
+		nil,
+		nil,
+		[]common.Address{applicationAddress},
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get keeps created for application [%v]: [%v]",
+			applicationAddress.String(),
+			err,
+		)
+	}
+
+	// Make sure keeps are in the order they were created.
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Raw.BlockNumber < events[j].Raw.BlockNumber
+	})
+
+	keepIDs := make([]chain.ID, 0, len(events))
+	for _, event := range events {
+		keepIDs = append(keepIDs, celoChainID(event.KeepAddress))
+	}
+
+	return keepIDs, nil
+}
+
+// GetMembersBatch returns members of all given keeps, fetching them
+// concurrently.
+func (cc *celoChain) GetMembersBatch(
+	keepIDs []chain.ID,
+) (map[chain.ID][]chain.ID, error) {
+	return chain.GetMembersBatch(
+		keepIDs,
+		chain.DefaultBatchConcurrency,
+		func(keepID chain.ID) ([]chain.ID, error) {
+			keep, err := cc.GetKeepWithID(keepID)
+			if err != nil {
+				return nil, err
+			}
+
+			return keep.GetMembers()
+		},
+	)
+}
+
 func (bekh *bondedEcdsaKeepHandle) ID() chain.ID {
 	return bekh.keepID
 }
 
 // OnSignatureRequested installs a callback that is invoked on-chain
-// when a keep's signature is requested.
+// when a keep's signature is requested. If a non-zero number of past blocks
+// is set in the options, signature requests from those blocks are replayed
+// to the callback first. The subscription is re-established if it drops and
+// signature requests missed in the meantime are delivered to the callback.
 func (bekh *bondedEcdsaKeepHandle) OnSignatureRequested(
 	handler func(event *chain.SignatureRequestedEvent),
+	opts ...chain.SubscribeOpts,
 ) (subscription.EventSubscription, error) {
-	onEvent := func(
-		Digest [32]uint8,
-		blockNumber uint64,
-	) {
+	source, err := bekh.signatureRequestedEventSource()
+	if err != nil {
+		return nil, err
+	}
+
+	startBlock, err := bekh.startBlock(chain.PastBlocks(opts))
+	if err != nil {
+		return nil, err
+	}
+
+	onEvent := func(keepEvent interface{}) {
+		event := keepEvent.(*abi.BondedECDSAKeepSignatureRequested)
 		handler(&chain.SignatureRequestedEvent{
-			Digest:      Digest,
-			BlockNumber: blockNumber,
+			Digest:      event.Digest,
+			BlockNumber: event.Raw.BlockNumber,
 		})
 	}
-	return bekh.contract.SignatureRequested(
-		nil,
-		nil,
-	).OnEvent(onEvent), nil
+
+	return chain.SubscribeWithBackfill(
+		source,
+		bekh.backfillOpts(startBlock, celoutil.DefaultSubscribeOptsTick),
+		onEvent,
+	)
 }
 
 // OnConflictingPublicKeySubmitted installs a callback that is invoked when an
@@ -139,11 +290,36 @@ func (bekh *bondedEcdsaKeepHandle) OnPublicKeyPublished(
 }
 
 // SubmitKeepPublicKey submits a public key to a keep contract deployed under
-// a given address.
+// a given address. The transaction is not submitted if the public key is
+// all-zero or the operator is not a member of the keep.
 func (bekh *bondedEcdsaKeepHandle) SubmitKeepPublicKey(
 	publicKey [64]byte,
 ) error {
+	if err := chain.ValidatePublicKey(publicKey); err != nil {
+		return fmt.Errorf(
+			"invalid public key for keep [%s]: [%v]",
+			bekh.keepID,
+			err,
+		)
+	}
+
 	submitPubKey := func() error {
+		members, err := bekh.GetMembers()
+		if err != nil {
+			return fmt.Errorf("could not get keep members: [%v]", err)
+		}
+
+		if err := chain.ValidatePublicKeySubmitter(
+			bekh.operatorID,
+			members,
+		); err != nil {
+			return fmt.Errorf(
+				"invalid public key submission for keep [%s]: [%v]",
+				bekh.keepID,
+				err,
+			)
+		}
+
 		transaction, err := bekh.contract.SubmitPublicKey(
 			publicKey[:],
 			celoutil.TransactionOptions{
@@ -206,30 +382,59 @@ func (bekh *bondedEcdsaKeepHandle) SubmitSignature(
 }
 
 // OnKeepClosed installs a callback that is invoked on-chain when keep is closed.
+// The subscription is re-established if it drops and the callback is invoked
+// if the keep has been closed in the meantime.
 func (bekh *bondedEcdsaKeepHandle) OnKeepClosed(
 	handler func(event *chain.KeepClosedEvent),
 ) (subscription.EventSubscription, error) {
-	onEvent := func(blockNumber uint64) {
-		handler(&chain.KeepClosedEvent{BlockNumber: blockNumber})
+	source, err := bekh.keepClosedEventSource()
+	if err != nil {
+		return nil, err
 	}
-	return bekh.contract.KeepClosed(&ethlike.SubscribeOpts{
-		Tick:       4 * time.Hour,
-		PastBlocks: 2000,
-	}).OnEvent(onEvent), nil
+
+	startBlock, err := bekh.startBlock(keepLifecycleEventsPastBlocks)
+	if err != nil {
+		return nil, err
+	}
+
+	onEvent := func(keepEvent interface{}) {
+		event := keepEvent.(*abi.BondedECDSAKeepKeepClosed)
+		handler(&chain.KeepClosedEvent{BlockNumber: event.Raw.BlockNumber})
+	}
+
+	return chain.SubscribeWithBackfill(
+		source,
+		bekh.backfillOpts(startBlock, keepLifecycleEventsTick),
+		onEvent,
+	)
 }
 
 // OnKeepTerminated installs a callback that is invoked on-chain when keep
-// is terminated.
+// is terminated. The subscription is re-established if it drops and the
+// callback is invoked if the keep has been terminated in the meantime.
 func (bekh *bondedEcdsaKeepHandle) OnKeepTerminated(
 	handler func(event *chain.KeepTerminatedEvent),
 ) (subscription.EventSubscription, error) {
-	onEvent := func(blockNumber uint64) {
-		handler(&chain.KeepTerminatedEvent{BlockNumber: blockNumber})
+	source, err := bekh.keepTerminatedEventSource()
+	if err != nil {
+		return nil, err
+	}
+
+	startBlock, err := bekh.startBlock(keepLifecycleEventsPastBlocks)
+	if err != nil {
+		return nil, err
+	}
+
+	onEvent := func(keepEvent interface{}) {
+		event := keepEvent.(*abi.BondedECDSAKeepKeepTerminated)
+		handler(&chain.KeepTerminatedEvent{BlockNumber: event.Raw.BlockNumber})
 	}
-	return bekh.contract.KeepTerminated(&ethlike.SubscribeOpts{
-		Tick:       4 * time.Hour,
-		PastBlocks: 2000,
-	}).OnEvent(onEvent), nil
+
+	return chain.SubscribeWithBackfill(
+		source,
+		bekh.backfillOpts(startBlock, keepLifecycleEventsTick),
+		onEvent,
+	)
 }
 
 // IsAwaitingSignature checks if the keep is waiting for a signature to be
@@ -367,6 +572,66 @@ func (bekh *bondedEcdsaKeepHandle) PastSignatureSubmittedEvents(
 	return result, nil
 }
 
+// PastKeepClosedEvents returns all keep closed events for the given keep
+// which occurred after the provided start block. Returned events are sorted
+// by the block number in the ascending order.
+func (bekh *bondedEcdsaKeepHandle) PastKeepClosedEvents(
+	startBlock uint64,
+) ([]*chain.KeepClosedEvent, error) {
+	events, err := bekh.contract.PastKeepClosedEvents(
+		startBlock,
+		nil, // latest block
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*chain.KeepClosedEvent, 0)
+
+	for _, event := range events {
+		result = append(result, &chain.KeepClosedEvent{
+			BlockNumber: event.Raw.BlockNumber,
+		})
+	}
+
+	// Make sure events are sorted by block number in ascending order.
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].BlockNumber < result[j].BlockNumber
+	})
+
+	return result, nil
+}
+
+// PastKeepTerminatedEvents returns all keep terminated events for the given
+// keep which occurred after the provided start block. Returned events are
+// sorted by the block number in the ascending order.
+func (bekh *bondedEcdsaKeepHandle) PastKeepTerminatedEvents(
+	startBlock uint64,
+) ([]*chain.KeepTerminatedEvent, error) {
+	events, err := bekh.contract.PastKeepTerminatedEvents(
+		startBlock,
+		nil, // latest block
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*chain.KeepTerminatedEvent, 0)
+
+	for _, event := range events {
+		result = append(result, &chain.KeepTerminatedEvent{
+			BlockNumber: event.Raw.BlockNumber,
+		})
+	}
+
+	// Make sure events are sorted by block number in ascending order.
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].BlockNumber < result[j].BlockNumber
+	})
+
+	return result, nil
+}
+
 // TODO Move to keep-common and parametrize by number of retries and delay?
 func withRetry(fn func() error) error {
 	const numberOfRetries = 10
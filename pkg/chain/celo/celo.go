@@ -99,6 +99,8 @@ func (cc *celoChain) OnBondedECDSAKeepCreated(
 		handler(&chain.BondedECDSAKeepCreatedEvent{
 			Keep:                 keep,
 			MemberIDs:            memberIDs,
+			OwnerID:              celoChainID(Owner),
+			ApplicationID:        celoChainID(Application),
 			HonestThreshold:      HonestThreshold.Uint64(),
 			BlockNumber:          blockNumber,
 			ThisOperatorIsMember: thisOperatorIsMember,
@@ -175,3 +177,14 @@ func (cc *celoChain) weiBalanceOf(address common.Address) (*celo.Wei, error) {
 
 	return celo.WrapWei(balance), err
 }
+
+// contractDeployed checks if there is a contract deployed under the given
+// address.
+func (cc *celoChain) contractDeployed(address common.Address) (bool, error) {
+	code, err := cc.client.CodeAt(context.Background(), address, nil)
+	if err != nil {
+		return false, err
+	}
+
+	return len(code) > 0, nil
+}
@@ -27,6 +27,7 @@ import (
 const (
 	BondedECDSAKeepFactoryContractName = "BondedECDSAKeepFactory"
 	TBTCSystemContractName             = "TBTCSystem"
+	KeepBondingContractName            = "KeepBonding"
 )
 
 // TODO: revisit those constants values and adjust them to Celo blockchain.
@@ -53,6 +54,7 @@ type celoChain struct {
 	chainID                        *big.Int
 	bondedECDSAKeepFactoryContract *contract.BondedECDSAKeepFactory
 	tbtcSystemAddress              common.Address
+	keepBondingContract            *keepBonding
 	blockCounter                   *ethlike.BlockCounter
 	miningWaiter                   *ethlike.MiningWaiter
 	nonceManager                   *ethlike.NonceManager
@@ -156,6 +158,26 @@ func Connect(
 		return nil, err
 	}
 
+	var keepBondingContract *keepBonding
+	keepBondingAddress, err := config.ContractAddress(KeepBondingContractName)
+	if err == nil {
+		keepBondingContract, err = newKeepBonding(
+			keepBondingAddress,
+			accountKey.Address,
+			wrappedClient,
+		)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// A missing KeepBonding address should only mean that bond amounts
+		// can't be read, not that the whole client fails to start.
+		logger.Warning(
+			"KeepBonding address not configured; " +
+				"bond amounts will not be available",
+		)
+	}
+
 	celo := &celoChain{
 		config:                         config,
 		accountKey:                     accountKey,
@@ -163,6 +185,7 @@ func Connect(
 		chainID:                        chainID,
 		bondedECDSAKeepFactoryContract: bondedECDSAKeepFactoryContract,
 		tbtcSystemAddress:              tbtcSystemAddress,
+		keepBondingContract:            keepBondingContract,
 		blockCounter:                   blockCounter,
 		nonceManager:                   nonceManager,
 		miningWaiter:                   miningWaiter,
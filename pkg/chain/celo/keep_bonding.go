@@ -0,0 +1,268 @@
+//+build celo
+
+package celo
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/celo-org/celo-blockchain/accounts/abi"
+	"github.com/celo-org/celo-blockchain/accounts/abi/bind"
+	"github.com/celo-org/celo-blockchain/common"
+	"github.com/celo-org/celo-blockchain/core/types"
+	"github.com/celo-org/celo-blockchain/event"
+
+	"github.com/keep-network/keep-common/pkg/chain/celo/celoutil"
+	"github.com/keep-network/keep-common/pkg/subscription"
+
+	"github.com/keep-network/keep-ecdsa/pkg/chain"
+)
+
+// keepBondingABI is the part of the KeepBonding contract ABI the client uses
+// to read bond amounts and watch bond seizures.
+const keepBondingABI = `[{"constant":true,"inputs":[{"name":"operator","type":"address"},{"name":"holder","type":"address"},{"name":"referenceID","type":"uint256"}],"name":"bondAmount","outputs":[{"name":"","type":"uint256"}],"payable":false,"stateMutability":"view","type":"function"},{"anonymous":false,"inputs":[{"indexed":true,"name":"operator","type":"address"},{"indexed":true,"name":"referenceID","type":"uint256"},{"indexed":false,"name":"destination","type":"address"},{"indexed":false,"name":"amount","type":"uint256"}],"name":"BondSeized","type":"event"}]`
+
+// keepBonding is a read-only handle to the KeepBonding contract.
+type keepBonding struct {
+	contract      *bind.BoundContract
+	callerOptions *bind.CallOpts
+}
+
+func newKeepBonding(
+	address common.Address,
+	callerAddress common.Address,
+	backend bind.ContractBackend,
+) (*keepBonding, error) {
+	contractABI, err := abi.JSON(strings.NewReader(keepBondingABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse KeepBonding ABI: [%v]", err)
+	}
+
+	return &keepBonding{
+		contract: bind.NewBoundContract(
+			address,
+			contractABI,
+			backend,
+			backend,
+			backend,
+		),
+		callerOptions: &bind.CallOpts{From: callerAddress},
+	}, nil
+}
+
+// bondAmount returns the amount of wei locked in the bond of the given
+// operator, held by the given holder under the given reference ID.
+func (kb *keepBonding) bondAmount(
+	operator common.Address,
+	holder common.Address,
+	referenceID *big.Int,
+) (*big.Int, error) {
+	var result []interface{}
+	err := kb.contract.Call(
+		kb.callerOptions,
+		&result,
+		"bondAmount",
+		operator,
+		holder,
+		referenceID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return *abi.ConvertType(result[0], new(*big.Int)).(**big.Int), nil
+}
+
+// keepBondingBondSeized represents a BondSeized event emitted by the
+// KeepBonding contract.
+type keepBondingBondSeized struct {
+	Operator    common.Address
+	ReferenceID *big.Int
+	Destination common.Address
+	Amount      *big.Int
+	Raw         types.Log
+}
+
+// watchBondSeized installs a callback invoked for each BondSeized event
+// emitted for bonds with the given reference ID.
+func (kb *keepBonding) watchBondSeized(
+	referenceID *big.Int,
+	handler func(event *keepBondingBondSeized),
+) subscription.EventSubscription {
+	logsChan := make(chan types.Log)
+
+	subscribeFn := func(ctx context.Context) (event.Subscription, error) {
+		logs, sub, err := kb.contract.WatchLogs(
+			&bind.WatchOpts{Context: ctx},
+			"BondSeized",
+			nil, // any operator
+			[]interface{}{referenceID},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		return event.NewSubscription(func(quit <-chan struct{}) error {
+			defer sub.Unsubscribe()
+			for {
+				select {
+				case log := <-logs:
+					select {
+					case logsChan <- log:
+					case <-quit:
+						return nil
+					}
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			}
+		}), nil
+	}
+
+	thresholdViolatedFn := func(elapsed time.Duration) {
+		logger.Errorf(
+			"subscription to event BondSeized had to be "+
+				"retried [%s] since the last attempt; please inspect "+
+				"host chain connectivity",
+			elapsed,
+		)
+	}
+
+	subscriptionFailedFn := func(err error) {
+		logger.Errorf(
+			"subscription to event BondSeized failed "+
+				"with error: [%v]; resubscription attempt will be "+
+				"performed",
+			err,
+		)
+	}
+
+	sub := celoutil.WithResubscription(
+		celoutil.SubscriptionBackoffMax,
+		subscribeFn,
+		celoutil.SubscriptionAlertThreshold,
+		thresholdViolatedFn,
+		subscriptionFailedFn,
+	)
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case log := <-logsChan:
+				bondSeized := new(keepBondingBondSeized)
+				err := kb.contract.UnpackLog(bondSeized, "BondSeized", log)
+				if err != nil {
+					logger.Errorf("failed to unpack BondSeized event: [%v]", err)
+					continue
+				}
+				bondSeized.Raw = log
+
+				handler(bondSeized)
+			}
+		}
+	}()
+
+	return subscription.NewEventSubscription(func() {
+		sub.Unsubscribe()
+		cancelCtx()
+	})
+}
+
+// GetBondAmount returns the amount of wei the operator has bonded for the
+// given keep to the given holder. Keeps use their own address as the bond
+// reference ID.
+func (cc *celoChain) GetBondAmount(
+	keepID chain.ID,
+	operatorID chain.ID,
+	holderID chain.ID,
+) (*big.Int, error) {
+	if cc.keepBondingContract == nil {
+		return nil, fmt.Errorf("KeepBonding address unset")
+	}
+
+	keepAddress, err := fromChainID(keepID)
+	if err != nil {
+		return nil, err
+	}
+
+	operatorAddress, err := fromChainID(operatorID)
+	if err != nil {
+		return nil, err
+	}
+
+	holderAddress, err := fromChainID(holderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return cc.keepBondingContract.bondAmount(
+		operatorAddress,
+		holderAddress,
+		new(big.Int).SetBytes(keepAddress.Bytes()),
+	)
+}
+
+// GetTotalBondedAmount returns the amount of wei the operator has bonded
+// across all keeps it is a member of.
+func (cc *celoChain) GetTotalBondedAmount(
+	operatorID chain.ID,
+) (*big.Int, error) {
+	keepIDs, err := cc.GetKeepsForOperator(operatorID)
+	if err != nil {
+		return nil, err
+	}
+
+	total := big.NewInt(0)
+	for _, keepID := range keepIDs {
+		bondAmount, err := cc.GetBondAmount(keepID, operatorID, keepID)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to get bond amount for keep [%v]: [%v]",
+				keepID,
+				err,
+			)
+		}
+
+		total.Add(total, bondAmount)
+	}
+
+	return total, nil
+}
+
+// OnBondSeized installs a callback that is invoked when a bond of any member
+// of the given keep is seized.
+func (cc *celoChain) OnBondSeized(
+	keepID chain.ID,
+	handler func(event *chain.BondSeizedEvent),
+) (subscription.EventSubscription, error) {
+	if cc.keepBondingContract == nil {
+		return nil, fmt.Errorf("KeepBonding address unset")
+	}
+
+	keepAddress, err := fromChainID(keepID)
+	if err != nil {
+		return nil, err
+	}
+
+	onEvent := func(event *keepBondingBondSeized) {
+		handler(&chain.BondSeizedEvent{
+			KeepID:      keepID,
+			Operator:    celoChainID(event.Operator),
+			Amount:      event.Amount,
+			BlockNumber: event.Raw.BlockNumber,
+		})
+	}
+
+	return cc.keepBondingContract.watchBondSeized(
+		new(big.Int).SetBytes(keepAddress.Bytes()),
+		onEvent,
+	), nil
+}
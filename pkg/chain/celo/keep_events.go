@@ -0,0 +1,291 @@
+//+build celo
+
+package celo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/celo-org/celo-blockchain/accounts/abi/bind"
+	"github.com/celo-org/celo-blockchain/core/types"
+	"github.com/celo-org/celo-blockchain/event"
+
+	"github.com/keep-network/keep-common/pkg/subscription"
+
+	"github.com/keep-network/keep-ecdsa/pkg/chain"
+	"github.com/keep-network/keep-ecdsa/pkg/chain/gen/celo/abi"
+)
+
+const (
+	// keepLifecycleEventsPastBlocks determines how many past blocks are
+	// looked up for keep closed and terminated events when the subscription
+	// is installed.
+	keepLifecycleEventsPastBlocks = 2000
+	// keepLifecycleEventsTick determines how often keep closed and
+	// terminated events are looked up to catch events missed by the
+	// subscription.
+	keepLifecycleEventsTick = 4 * time.Hour
+)
+
+// watchKeepEvent installs a subscription using the given watch function and
+// starts forwarding events with the given forward function. The returned
+// channel receives a value when the subscription drops.
+func watchKeepEvent(
+	watchFn func(opts *bind.WatchOpts) (event.Subscription, error),
+	forwardFn func(ctx context.Context),
+) (subscription.EventSubscription, <-chan error) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	droppedChan := make(chan error, 1)
+
+	sub, err := watchFn(&bind.WatchOpts{Context: ctx})
+	if err != nil {
+		droppedChan <- err
+		return subscription.NewEventSubscription(cancelCtx), droppedChan
+	}
+
+	go forwardFn(ctx)
+
+	go func() {
+		select {
+		case err := <-sub.Err():
+			droppedChan <- err
+		case <-ctx.Done():
+		}
+	}()
+
+	return subscription.NewEventSubscription(func() {
+		sub.Unsubscribe()
+		cancelCtx()
+	}), droppedChan
+}
+
+// keepEventSource returns an event source for the given keep event type,
+// with the block number and key resolved from the event's raw log.
+func (bekh *bondedEcdsaKeepHandle) keepEventSource(
+	name string,
+	subscribeFn func(
+		handler func(keepEvent interface{}),
+	) (subscription.EventSubscription, <-chan error),
+	pastEventsFn func(startBlock uint64, endBlock uint64) ([]interface{}, error),
+	rawLogFn func(event interface{}) types.Log,
+) *chain.EventSource {
+	return &chain.EventSource{
+		Name:         name,
+		Subscribe:    subscribeFn,
+		PastEvents:   pastEventsFn,
+		CurrentBlock: bekh.blockCounter.CurrentBlock,
+		BlockNumber: func(keepEvent interface{}) uint64 {
+			return rawLogFn(keepEvent).BlockNumber
+		},
+		Key: func(keepEvent interface{}) string {
+			rawLog := rawLogFn(keepEvent)
+			return fmt.Sprintf("%v-%v", rawLog.TxHash.Hex(), rawLog.Index)
+		},
+	}
+}
+
+func (bekh *bondedEcdsaKeepHandle) keepClosedEventSource() (
+	*chain.EventSource,
+	error,
+) {
+	filterer, err := abi.NewBondedECDSAKeepFilterer(bekh.keepAddress, bekh.client)
+	if err != nil {
+		return nil, err
+	}
+
+	subscribeFn := func(
+		handler func(keepEvent interface{}),
+	) (subscription.EventSubscription, <-chan error) {
+		sink := make(chan *abi.BondedECDSAKeepKeepClosed)
+
+		return watchKeepEvent(
+			func(opts *bind.WatchOpts) (event.Subscription, error) {
+				return filterer.WatchKeepClosed(opts, sink)
+			},
+			func(ctx context.Context) {
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case keepEvent := <-sink:
+						handler(keepEvent)
+					}
+				}
+			},
+		)
+	}
+
+	pastEventsFn := func(
+		startBlock uint64,
+		endBlock uint64,
+	) ([]interface{}, error) {
+		events, err := bekh.contract.PastKeepClosedEvents(startBlock, &endBlock)
+		if err != nil {
+			return nil, err
+		}
+
+		result := make([]interface{}, len(events))
+		for i, keepEvent := range events {
+			result[i] = keepEvent
+		}
+		return result, nil
+	}
+
+	return bekh.keepEventSource(
+		"KeepClosed",
+		subscribeFn,
+		pastEventsFn,
+		func(keepEvent interface{}) types.Log {
+			return keepEvent.(*abi.BondedECDSAKeepKeepClosed).Raw
+		},
+	), nil
+}
+
+func (bekh *bondedEcdsaKeepHandle) keepTerminatedEventSource() (
+	*chain.EventSource,
+	error,
+) {
+	filterer, err := abi.NewBondedECDSAKeepFilterer(bekh.keepAddress, bekh.client)
+	if err != nil {
+		return nil, err
+	}
+
+	subscribeFn := func(
+		handler func(keepEvent interface{}),
+	) (subscription.EventSubscription, <-chan error) {
+		sink := make(chan *abi.BondedECDSAKeepKeepTerminated)
+
+		return watchKeepEvent(
+			func(opts *bind.WatchOpts) (event.Subscription, error) {
+				return filterer.WatchKeepTerminated(opts, sink)
+			},
+			func(ctx context.Context) {
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case keepEvent := <-sink:
+						handler(keepEvent)
+					}
+				}
+			},
+		)
+	}
+
+	pastEventsFn := func(
+		startBlock uint64,
+		endBlock uint64,
+	) ([]interface{}, error) {
+		events, err := bekh.contract.PastKeepTerminatedEvents(
+			startBlock,
+			&endBlock,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		result := make([]interface{}, len(events))
+		for i, keepEvent := range events {
+			result[i] = keepEvent
+		}
+		return result, nil
+	}
+
+	return bekh.keepEventSource(
+		"KeepTerminated",
+		subscribeFn,
+		pastEventsFn,
+		func(keepEvent interface{}) types.Log {
+			return keepEvent.(*abi.BondedECDSAKeepKeepTerminated).Raw
+		},
+	), nil
+}
+
+func (bekh *bondedEcdsaKeepHandle) signatureRequestedEventSource() (
+	*chain.EventSource,
+	error,
+) {
+	filterer, err := abi.NewBondedECDSAKeepFilterer(bekh.keepAddress, bekh.client)
+	if err != nil {
+		return nil, err
+	}
+
+	subscribeFn := func(
+		handler func(keepEvent interface{}),
+	) (subscription.EventSubscription, <-chan error) {
+		sink := make(chan *abi.BondedECDSAKeepSignatureRequested)
+
+		return watchKeepEvent(
+			func(opts *bind.WatchOpts) (event.Subscription, error) {
+				return filterer.WatchSignatureRequested(opts, sink, nil)
+			},
+			func(ctx context.Context) {
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case keepEvent := <-sink:
+						handler(keepEvent)
+					}
+				}
+			},
+		)
+	}
+
+	pastEventsFn := func(
+		startBlock uint64,
+		endBlock uint64,
+	) ([]interface{}, error) {
+		events, err := bekh.contract.PastSignatureRequestedEvents(
+			startBlock,
+			&endBlock,
+			nil,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		result := make([]interface{}, len(events))
+		for i, keepEvent := range events {
+			result[i] = keepEvent
+		}
+		return result, nil
+	}
+
+	return bekh.keepEventSource(
+		"SignatureRequested",
+		subscribeFn,
+		pastEventsFn,
+		func(keepEvent interface{}) types.Log {
+			return keepEvent.(*abi.BondedECDSAKeepSignatureRequested).Raw
+		},
+	), nil
+}
+
+// startBlock returns the block the given number of past blocks before the
+// current block.
+func (bekh *bondedEcdsaKeepHandle) startBlock(pastBlocks uint64) (uint64, error) {
+	currentBlock, err := bekh.blockCounter.CurrentBlock()
+	if err != nil {
+		return 0, err
+	}
+
+	if currentBlock < pastBlocks {
+		return 0, nil
+	}
+
+	return currentBlock - pastBlocks, nil
+}
+
+// backfillOpts returns options of a keep event subscription with the given
+// start block and past events lookup tick.
+func (bekh *bondedEcdsaKeepHandle) backfillOpts(
+	startBlock uint64,
+	tick time.Duration,
+) chain.BackfillOpts {
+	return chain.BackfillOpts{
+		StartBlock: startBlock,
+		Tick:       tick,
+	}
+}
@@ -0,0 +1,73 @@
+//+build celo
+
+package celo
+
+import (
+	"context"
+	"time"
+
+	"github.com/keep-network/keep-common/pkg/subscription"
+	"github.com/keep-network/keep-ecdsa/pkg/chain"
+)
+
+// operatorAuthorizationCheckTick determines how often the authorization of
+// the operator is checked for deauthorization subscriptions.
+const operatorAuthorizationCheckTick = 10 * time.Minute
+
+// OnOperatorDeauthorized installs a callback that is invoked when the
+// factory's authorization to operate on the stake of this chain's operator is
+// revoked. Contracts do not emit an event on deauthorization, so the
+// authorization is checked periodically and the callback is invoked when the
+// operator authorized during the previous check is no longer authorized.
+func (cc *celoChain) OnOperatorDeauthorized(
+	handler func(operator chain.ID),
+) subscription.EventSubscription {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+
+	operatorID := cc.OperatorID()
+
+	go watchDeauthorization(
+		ctx,
+		func() (bool, error) {
+			return cc.IsOperatorAuthorized(operatorID)
+		},
+		operatorAuthorizationCheckTick,
+		func() {
+			handler(operatorID)
+		},
+	)
+
+	return subscription.NewEventSubscription(cancelCtx)
+}
+
+// watchDeauthorization checks the authorization every tick until the context
+// is done and calls onDeauthorized when the authorization found during the
+// previous check is revoked. The first check is done immediately.
+func watchDeauthorization(
+	ctx context.Context,
+	isAuthorized func() (bool, error),
+	tick time.Duration,
+	onDeauthorized func(),
+) {
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	wasAuthorized := false
+	for {
+		authorized, err := isAuthorized()
+		if err != nil {
+			logger.Errorf("failed to check operator authorization: [%v]", err)
+		} else {
+			if wasAuthorized && !authorized {
+				onDeauthorized()
+			}
+			wasAuthorized = authorized
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/big"
 	"sort"
+	"time"
 
 	"github.com/celo-org/celo-blockchain/common"
 	"github.com/keep-network/keep-common/pkg/chain/celo/celoutil"
@@ -226,6 +227,27 @@ func (ta *tbtcApplication) OnDepositGotRedemptionSignature(
 	).OnEvent(onEvent)
 }
 
+// OnDepositFunded installs a callback that is invoked when an
+// on-chain notification of a deposit funding is seen.
+func (ta *tbtcApplication) OnDepositFunded(
+	handler func(depositAddress string),
+) subscription.EventSubscription {
+	onEvent := func(
+		DepositContractAddress common.Address,
+		Txid [32]uint8,
+		Timestamp *big.Int,
+		blockNumber uint64,
+	) {
+		handler(DepositContractAddress.Hex())
+	}
+
+	return ta.tbtcSystemContract.Funded(
+		nil,
+		nil,
+		nil,
+	).OnEvent(onEvent)
+}
+
 // OnDepositRedeemed installs a callback that is invoked when an
 // on-chain notification of a deposit redemption is seen.
 func (ta *tbtcApplication) OnDepositRedeemed(
@@ -247,6 +269,40 @@ func (ta *tbtcApplication) OnDepositRedeemed(
 	).OnEvent(onEvent)
 }
 
+// PastDepositCreatedEvents returns all deposit created events which occurred
+// after the provided start block. Returned events are sorted by the block
+// number in the ascending order.
+func (ta *tbtcApplication) PastDepositCreatedEvents(
+	startBlock uint64,
+) ([]*chain.DepositCreatedEvent, error) {
+	events, err := ta.tbtcSystemContract.PastCreatedEvents(
+		startBlock,
+		nil,
+		nil,
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*chain.DepositCreatedEvent, 0)
+
+	for _, event := range events {
+		result = append(result, &chain.DepositCreatedEvent{
+			DepositAddress: event.DepositContractAddress.Hex(),
+			KeepAddress:    event.KeepAddress.Hex(),
+			BlockNumber:    event.Raw.BlockNumber,
+		})
+	}
+
+	// Make sure events are sorted by block number in ascending order.
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].BlockNumber < result[j].BlockNumber
+	})
+
+	return result, nil
+}
+
 // PastDepositRedemptionRequestedEvents returns all redemption requested
 // events for the given deposit which occurred after the provided start block.
 // Returned events are sorted by the block number in the ascending order.
@@ -255,7 +311,11 @@ func (ta *tbtcApplication) PastDepositRedemptionRequestedEvents(
 	depositAddress string,
 ) ([]*chain.DepositRedemptionRequestedEvent, error) {
 	if !common.IsHexAddress(depositAddress) {
-		return nil, fmt.Errorf("incorrect deposit contract address")
+		return nil, fmt.Errorf(
+			"[%v] is not a deposit address: [%w]",
+			depositAddress,
+			chain.ErrInvalidDepositAddress,
+		)
 	}
 	events, err := ta.tbtcSystemContract.PastRedemptionRequestedEvents(
 		startBlock,
@@ -309,6 +369,78 @@ func (ta *tbtcApplication) Keep(
 	return ta.chainHandle.GetKeepWithID(celoChainID(keepAddress))
 }
 
+// IsDepositKeepActive checks if the underlying keep for the provided deposit
+// is still active.
+func (ta *tbtcApplication) IsDepositKeepActive(
+	depositAddress string,
+) (bool, error) {
+	keep, err := ta.Keep(depositAddress)
+	if err != nil {
+		return false, err
+	}
+
+	return keep.IsActive()
+}
+
+// GetDepositsForOperator returns addresses of all deposits whose keeps
+// include the given operator as a member. Only keeps opened by the tBTC
+// application are inspected. Members of each keep are read once and the
+// deposit, which is the keep owner, is read only for keeps the operator
+// is a member of.
+func (ta *tbtcApplication) GetDepositsForOperator(
+	operator chain.ID,
+) ([]string, error) {
+	keepIDs, err := ta.chainHandle.GetApplicationKeeps(ta.ID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tBTC keeps: [%v]", err)
+	}
+
+	keepsMembers, err := ta.chainHandle.GetMembersBatch(keepIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get members of tBTC keeps: [%v]", err)
+	}
+
+	depositAddresses := make([]string, 0)
+	for _, keepID := range keepIDs {
+		if !containsID(keepsMembers[keepID], operator) {
+			continue
+		}
+
+		keep, err := ta.chainHandle.GetKeepWithID(keepID)
+		if err != nil {
+			return nil, err
+		}
+
+		owner, err := keep.GetOwner()
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to get owner of keep [%v]: [%v]",
+				keepID,
+				err,
+			)
+		}
+
+		depositAddress, err := fromChainID(owner)
+		if err != nil {
+			return nil, err
+		}
+
+		depositAddresses = append(depositAddresses, depositAddress.Hex())
+	}
+
+	return depositAddresses, nil
+}
+
+func containsID(ids []chain.ID, id chain.ID) bool {
+	for _, candidate := range ids {
+		if candidate.String() == id.String() {
+			return true
+		}
+	}
+
+	return false
+}
+
 // RetrieveSignerPubkey retrieves the signer public key for the
 // provided deposit.
 func (ta *tbtcApplication) RetrieveSignerPubkey(
@@ -332,6 +464,46 @@ func (ta *tbtcApplication) RetrieveSignerPubkey(
 	return nil
 }
 
+// ProvideBTCFundingProof provides the proof of the bitcoin funding
+// transaction for the provided deposit.
+func (ta *tbtcApplication) ProvideBTCFundingProof(
+	depositAddress string,
+	txVersion [4]uint8,
+	txInputVector []uint8,
+	txOutputVector []uint8,
+	txLocktime [4]uint8,
+	fundingOutputIndex uint8,
+	merkleProof []uint8,
+	txIndexInBlock *big.Int,
+	bitcoinHeaders []uint8,
+) error {
+	deposit, err := ta.getDepositContract(depositAddress)
+	if err != nil {
+		return err
+	}
+
+	transaction, err := deposit.ProvideBTCFundingProof(
+		txVersion,
+		txInputVector,
+		txOutputVector,
+		txLocktime,
+		fundingOutputIndex,
+		merkleProof,
+		txIndexInBlock,
+		bitcoinHeaders,
+	)
+	if err != nil {
+		return err
+	}
+
+	logger.Debugf(
+		"submitted ProvideBTCFundingProof transaction with hash: [%s]",
+		transaction.Hash(),
+	)
+
+	return nil
+}
+
 // ProvideRedemptionSignature provides the redemption signature for the
 // provided deposit.
 func (ta *tbtcApplication) ProvideRedemptionSignature(
@@ -483,7 +655,27 @@ func (ta *tbtcApplication) getDepositContract(
 	address string,
 ) (*tbtcchain.Deposit, error) {
 	if !common.IsHexAddress(address) {
-		return nil, fmt.Errorf("incorrect deposit contract address")
+		return nil, fmt.Errorf(
+			"[%v] is not a deposit address: [%w]",
+			address,
+			chain.ErrInvalidDepositAddress,
+		)
+	}
+
+	deployed, err := ta.chainHandle.contractDeployed(common.HexToAddress(address))
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not check if deposit [%v] exists: [%v]",
+			address,
+			err,
+		)
+	}
+	if !deployed {
+		return nil, fmt.Errorf(
+			"no deposit with address [%v]: [%w]",
+			address,
+			chain.ErrDepositNotFound,
+		)
 	}
 
 	depositContract, err := tbtcchain.NewDeposit(
@@ -502,3 +694,9 @@ func (ta *tbtcApplication) getDepositContract(
 
 	return depositContract, nil
 }
+
+// RedemptionProofTimeout returns the time after the latest redemption request
+// of a deposit within which the redemption proof must be provided.
+func (ta *tbtcApplication) RedemptionProofTimeout() (time.Duration, error) {
+	return chain.RedemptionProofTimeout, nil
+}
@@ -5,6 +5,7 @@ package chain
 
 import (
 	cecdsa "crypto/ecdsa"
+	"errors"
 	"fmt"
 	"math/big"
 	"time"
@@ -14,6 +15,19 @@ import (
 	"github.com/keep-network/keep-ecdsa/pkg/ecdsa"
 )
 
+// ErrGasPriceTooHigh is an error returned when a transaction is not submitted
+// because the current gas price exceeds the maximum gas price configured for
+// the chain. The submission can be retried once the gas price drops.
+var ErrGasPriceTooHigh = errors.New("gas price too high")
+
+// ErrKeepNotFound is an error returned when there is no keep under the given
+// address.
+var ErrKeepNotFound = errors.New("keep not found")
+
+// ErrTransactionNotConfirmed is an error returned when a submitted transaction
+// has not collected the required number of confirmations in time.
+var ErrTransactionNotConfirmed = errors.New("transaction not confirmed")
+
 // ID represents a generic id on a given chain. The underlying chain's name is
 // provided by the ChainName func, and a method is provided to check whether the
 // ID is for a particular chain.
@@ -104,6 +118,12 @@ type BondedECDSAKeepFactory interface {
 	// operate on stake represented by the provided operator.
 	IsOperatorAuthorized(operator ID) (bool, error)
 
+	// OnOperatorDeauthorized installs a callback that is invoked when the
+	// factory's authorization to operate on the operator's stake is revoked.
+	OnOperatorDeauthorized(
+		handler func(operator ID),
+	) subscription.EventSubscription
+
 	// GetKeepCount returns number of keeps.
 	GetKeepCount() (*big.Int, error)
 
@@ -111,6 +131,32 @@ type BondedECDSAKeepFactory interface {
 	GetKeepAtIndex(keepIndex *big.Int) (BondedECDSAKeepHandle, error)
 	// GetKeepWithID returns a handle to the keep with the given ID.
 	GetKeepWithID(keepID ID) (BondedECDSAKeepHandle, error)
+	// GetKeepsForOperator returns IDs of all keeps the given operator is
+	// a member of. It scans all keeps created by the factory so it is
+	// expensive and should not be called often.
+	GetKeepsForOperator(operator ID) ([]ID, error)
+	// GetApplicationKeeps returns IDs of all keeps opened by the given
+	// application, in the order they were created. Keeps belonging to other
+	// applications are never inspected.
+	GetApplicationKeeps(application ID) ([]ID, error)
+	// GetMembersBatch returns members of all given keeps, fetching them
+	// concurrently. Members of keeps that could be fetched are returned even
+	// if fetching some of them failed; in such case a *BatchError is returned
+	// as well.
+	GetMembersBatch(keepIDs []ID) (map[ID][]ID, error)
+
+	// GetBondAmount returns the amount of wei the operator has bonded for
+	// the given keep to the given holder.
+	GetBondAmount(keepID ID, operator ID, holder ID) (*big.Int, error)
+	// GetTotalBondedAmount returns the amount of wei the operator has bonded
+	// across all keeps it is a member of.
+	GetTotalBondedAmount(operator ID) (*big.Int, error)
+	// OnBondSeized installs a callback that is invoked when a bond of any
+	// member of the given keep is seized.
+	OnBondSeized(
+		keepID ID,
+		handler func(event *BondSeizedEvent),
+	) (subscription.EventSubscription, error)
 }
 
 // BondedECDSAKeepHandle is an interface that provides ability to interact with
@@ -123,9 +169,13 @@ type BondedECDSAKeepHandle interface {
 	ID() ID
 
 	// OnSignatureRequested installs a callback that is invoked when an on-chain
-	// notification of a new signing request for a given keep is seen.
+	// notification of a new signing request for a given keep is seen. If
+	// subscribe options with a non-zero PastBlocks value are passed, signing
+	// requests from that many past blocks are delivered to the callback
+	// before new ones.
 	OnSignatureRequested(
 		handler func(event *SignatureRequestedEvent),
+		opts ...SubscribeOpts,
 	) (subscription.EventSubscription, error)
 
 	// OnConflictingPublicKeySubmitted installs a callback that is invoked upon
@@ -142,11 +192,13 @@ type BondedECDSAKeepHandle interface {
 	) (subscription.EventSubscription, error)
 
 	// SubmitKeepPublicKey submits a 64-byte serialized public key to a keep
-	// contract deployed under a given address.
+	// contract deployed under a given address. Returns ErrGasPriceTooHigh
+	// error if the current gas price exceeds the configured maximum.
 	SubmitKeepPublicKey(publicKey [64]byte) error
 
 	// SubmitSignature submits a signature to a keep contract deployed under a
-	// given address.
+	// given address. Returns ErrGasPriceTooHigh error if the current gas price
+	// exceeds the configured maximum.
 	SubmitSignature(signature *ecdsa.Signature) error
 
 	// OnKeepClosed installs a callback that will be called on closing the
@@ -211,6 +263,18 @@ type BondedECDSAKeepHandle interface {
 	PastSignatureSubmittedEvents(
 		startBlock uint64,
 	) ([]*SignatureSubmittedEvent, error)
+
+	// PastKeepClosedEvents returns all keep closed events for the given keep
+	// which occurred after the provided start block. All implementations
+	// should return those events sorted by the block number in the
+	// ascending order.
+	PastKeepClosedEvents(startBlock uint64) ([]*KeepClosedEvent, error)
+
+	// PastKeepTerminatedEvents returns all keep terminated events for the
+	// given keep which occurred after the provided start block. All
+	// implementations should return those events sorted by the block number
+	// in the ascending order.
+	PastKeepTerminatedEvents(startBlock uint64) ([]*KeepTerminatedEvent, error)
 }
 
 // BondedECDSAKeepApplicationHandle is a handle to a specific application that
@@ -225,7 +289,8 @@ type BondedECDSAKeepApplicationHandle interface {
 	ID() ID
 
 	// RegisterAsMemberCandidate registers this instance's operator as a
-	// candidate to be selected to a keep.
+	// candidate to be selected to a keep. Returns ErrGasPriceTooHigh error if
+	// the current gas price exceeds the configured maximum.
 	RegisterAsMemberCandidate() error
 
 	// IsRegisteredForApplication checks if this instance's operator is
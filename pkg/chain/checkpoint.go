@@ -0,0 +1,160 @@
+package chain
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// BlockCheckpointStore persists the last block processed by event
+// subscriptions so they can resume from that block after a restart.
+type BlockCheckpointStore interface {
+	// LastBlock returns the last processed block recorded for the given
+	// key. The returned flag is false if nothing has been recorded yet.
+	LastBlock(key string) (uint64, bool, error)
+	// SaveLastBlock records the last processed block for the given key.
+	SaveLastBlock(key string, block uint64) error
+}
+
+// BlockCheckpointKey returns the checkpoint store key of the subscription
+// to the given event type of the given keep.
+func BlockCheckpointKey(keepID ID, eventName string) string {
+	return fmt.Sprintf("%s-%s", keepID.String(), eventName)
+}
+
+// MemoryBlockCheckpointStore is a BlockCheckpointStore keeping checkpoints
+// in memory only.
+type MemoryBlockCheckpointStore struct {
+	mutex       sync.RWMutex
+	checkpoints map[string]uint64
+}
+
+// NewMemoryBlockCheckpointStore creates a new, empty in-memory checkpoint
+// store.
+func NewMemoryBlockCheckpointStore() *MemoryBlockCheckpointStore {
+	return &MemoryBlockCheckpointStore{
+		checkpoints: make(map[string]uint64),
+	}
+}
+
+// LastBlock returns the last processed block recorded for the given key.
+func (mbcs *MemoryBlockCheckpointStore) LastBlock(
+	key string,
+) (uint64, bool, error) {
+	mbcs.mutex.RLock()
+	defer mbcs.mutex.RUnlock()
+
+	block, ok := mbcs.checkpoints[key]
+	return block, ok, nil
+}
+
+// SaveLastBlock records the last processed block for the given key.
+func (mbcs *MemoryBlockCheckpointStore) SaveLastBlock(
+	key string,
+	block uint64,
+) error {
+	mbcs.mutex.Lock()
+	defer mbcs.mutex.Unlock()
+
+	mbcs.checkpoints[key] = block
+	return nil
+}
+
+// FileBlockCheckpointStore is a BlockCheckpointStore keeping checkpoints in
+// a single JSON file. The file is rewritten on every update.
+type FileBlockCheckpointStore struct {
+	path string
+
+	mutex       sync.RWMutex
+	checkpoints map[string]uint64
+}
+
+// NewFileBlockCheckpointStore creates a checkpoint store backed by the file
+// under the given path. Checkpoints already stored in the file are loaded.
+func NewFileBlockCheckpointStore(
+	path string,
+) (*FileBlockCheckpointStore, error) {
+	checkpoints := make(map[string]uint64)
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf(
+			"failed to read block checkpoints file [%s]: [%v]",
+			path,
+			err,
+		)
+	}
+
+	if len(content) > 0 {
+		if err := json.Unmarshal(content, &checkpoints); err != nil {
+			return nil, fmt.Errorf(
+				"failed to parse block checkpoints file [%s]: [%v]",
+				path,
+				err,
+			)
+		}
+	}
+
+	return &FileBlockCheckpointStore{
+		path:        path,
+		checkpoints: checkpoints,
+	}, nil
+}
+
+// LastBlock returns the last processed block recorded for the given key.
+func (fbcs *FileBlockCheckpointStore) LastBlock(
+	key string,
+) (uint64, bool, error) {
+	fbcs.mutex.RLock()
+	defer fbcs.mutex.RUnlock()
+
+	block, ok := fbcs.checkpoints[key]
+	return block, ok, nil
+}
+
+// SaveLastBlock records the last processed block for the given key and
+// writes all checkpoints to the file.
+func (fbcs *FileBlockCheckpointStore) SaveLastBlock(
+	key string,
+	block uint64,
+) error {
+	fbcs.mutex.Lock()
+	defer fbcs.mutex.Unlock()
+
+	if current, ok := fbcs.checkpoints[key]; ok && current == block {
+		return nil
+	}
+
+	fbcs.checkpoints[key] = block
+
+	content, err := json.Marshal(fbcs.checkpoints)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so a crash in the middle of writing
+	// does not corrupt checkpoints stored so far.
+	tempFile, err := ioutil.TempFile(filepath.Dir(fbcs.path), ".checkpoints-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: [%v]", err)
+	}
+	defer os.Remove(tempFile.Name())
+
+	if _, err := tempFile.Write(content); err != nil {
+		tempFile.Close()
+		return fmt.Errorf("failed to write block checkpoints: [%v]", err)
+	}
+
+	if err := tempFile.Close(); err != nil {
+		return fmt.Errorf("failed to write block checkpoints: [%v]", err)
+	}
+
+	if err := os.Rename(tempFile.Name(), fbcs.path); err != nil {
+		return fmt.Errorf("failed to write block checkpoints: [%v]", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package chain
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileBlockCheckpointStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "checkpoints")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "checkpoints.json")
+
+	store, err := NewFileBlockCheckpointStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, ok, err := store.LastBlock("keep-KeepClosed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok {
+		t.Errorf("expected no checkpoint in an empty store")
+	}
+
+	if err := store.SaveLastBlock("keep-KeepClosed", 100); err != nil {
+		t.Fatal(err)
+	}
+
+	// checkpoints are loaded by a new store backed by the same file
+	reopenedStore, err := NewFileBlockCheckpointStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	block, ok, err := reopenedStore.LastBlock("keep-KeepClosed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok || block != 100 {
+		t.Errorf(
+			"unexpected checkpoint\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			100,
+			block,
+		)
+	}
+}
@@ -5,9 +5,12 @@ package ethereum
 import (
 	"context"
 	"math/big"
+	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/keep-network/keep-common/pkg/chain/ethereum"
+	"github.com/keep-network/keep-common/pkg/subscription"
 )
 
 // Values related with balance monitoring.
@@ -50,3 +53,110 @@ func (ec *ethereumChain) initializeBalanceMonitoring(
 		alertThreshold,
 	)
 }
+
+// BalanceThresholdMonitor checks the balance of an address periodically and
+// notifies handlers registered with OnBalanceBelow once the balance drops
+// below their thresholds.
+type BalanceThresholdMonitor struct {
+	balanceSource func(address common.Address) (*ethereum.Wei, error)
+
+	handlersMutex sync.Mutex
+	handlers      map[int]*balanceBelowHandler
+	nextHandlerID int
+}
+
+type balanceBelowHandler struct {
+	threshold *ethereum.Wei
+	handler   func(balance *ethereum.Wei)
+	// isBelow is set when the last checked balance was below the threshold
+	// so the handler is not notified again until the balance recovers.
+	isBelow bool
+}
+
+// NewBalanceThresholdMonitor creates a new BalanceThresholdMonitor using
+// the given source of balances.
+func NewBalanceThresholdMonitor(
+	balanceSource func(address common.Address) (*ethereum.Wei, error),
+) *BalanceThresholdMonitor {
+	return &BalanceThresholdMonitor{
+		balanceSource: balanceSource,
+		handlers:      make(map[int]*balanceBelowHandler),
+	}
+}
+
+// OnBalanceBelow installs a callback that is invoked with the current balance
+// when a check finds the balance below the threshold while the previous check
+// found it at or above the threshold. The callback is not invoked again until
+// the balance gets back to at least the threshold.
+func (btm *BalanceThresholdMonitor) OnBalanceBelow(
+	threshold *ethereum.Wei,
+	handler func(balance *ethereum.Wei),
+) subscription.EventSubscription {
+	btm.handlersMutex.Lock()
+	defer btm.handlersMutex.Unlock()
+
+	handlerID := btm.nextHandlerID
+	btm.nextHandlerID++
+
+	btm.handlers[handlerID] = &balanceBelowHandler{
+		threshold: threshold,
+		handler:   handler,
+	}
+
+	return subscription.NewEventSubscription(func() {
+		btm.handlersMutex.Lock()
+		defer btm.handlersMutex.Unlock()
+
+		delete(btm.handlers, handlerID)
+	})
+}
+
+// Observe starts checking the balance of the given address every tick until
+// the context is done. The first check is done immediately.
+func (btm *BalanceThresholdMonitor) Observe(
+	ctx context.Context,
+	address common.Address,
+	tick time.Duration,
+) {
+	go func() {
+		ticker := time.NewTicker(tick)
+		defer ticker.Stop()
+
+		for {
+			btm.check(address)
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (btm *BalanceThresholdMonitor) check(address common.Address) {
+	balance, err := btm.balanceSource(address)
+	if err != nil {
+		logger.Errorf(
+			"failed to get balance of address [%v]: [%v]",
+			address.Hex(),
+			err,
+		)
+		return
+	}
+
+	btm.handlersMutex.Lock()
+	var triggeredHandlers []func(balance *ethereum.Wei)
+	for _, handler := range btm.handlers {
+		isBelow := balance.Cmp(handler.threshold.Int) < 0
+		if isBelow && !handler.isBelow {
+			triggeredHandlers = append(triggeredHandlers, handler.handler)
+		}
+		handler.isBelow = isBelow
+	}
+	btm.handlersMutex.Unlock()
+
+	for _, handler := range triggeredHandlers {
+		handler(balance)
+	}
+}
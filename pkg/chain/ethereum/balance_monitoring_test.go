@@ -0,0 +1,70 @@
+//+build !celo
+
+package ethereum
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/keep-network/keep-common/pkg/chain/ethereum"
+)
+
+func TestBalanceThresholdMonitorOnBalanceBelow(t *testing.T) {
+	balances := []int64{10, 8, 4, 3, 9, 2, 1}
+	check := 0
+
+	monitor := NewBalanceThresholdMonitor(
+		func(address common.Address) (*ethereum.Wei, error) {
+			return ethereum.WrapWei(big.NewInt(balances[check])), nil
+		},
+	)
+
+	var notifiedBalances []int64
+	monitor.OnBalanceBelow(
+		ethereum.WrapWei(big.NewInt(5)),
+		func(balance *ethereum.Wei) {
+			notifiedBalances = append(notifiedBalances, balance.Int64())
+		},
+	)
+
+	address := common.HexToAddress("0x41048F9B90290A2e96D07f537F3A7E97620E9e47")
+	for check = range balances {
+		monitor.check(address)
+	}
+
+	// the handler is notified once the balance crosses the threshold and
+	// again only after the balance has recovered in the meantime
+	expectedNotifiedBalances := []int64{4, 2}
+	if !reflect.DeepEqual(expectedNotifiedBalances, notifiedBalances) {
+		t.Errorf(
+			"unexpected notified balances\nexpected: %v\nactual:   %v",
+			expectedNotifiedBalances,
+			notifiedBalances,
+		)
+	}
+}
+
+func TestBalanceThresholdMonitorUnsubscribe(t *testing.T) {
+	monitor := NewBalanceThresholdMonitor(
+		func(address common.Address) (*ethereum.Wei, error) {
+			return ethereum.WrapWei(big.NewInt(1)), nil
+		},
+	)
+
+	notifications := 0
+	subscription := monitor.OnBalanceBelow(
+		ethereum.WrapWei(big.NewInt(5)),
+		func(balance *ethereum.Wei) {
+			notifications++
+		},
+	)
+	subscription.Unsubscribe()
+
+	monitor.check(common.HexToAddress("0x41048F9B90290A2e96D07f537F3A7E97620E9e47"))
+
+	if notifications != 0 {
+		t.Errorf("unexpected notifications after unsubscribing: [%v]", notifications)
+	}
+}
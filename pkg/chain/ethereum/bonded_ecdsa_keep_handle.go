@@ -1,18 +1,23 @@
-//+build !celo
+//go:build !celo
+// +build !celo
 
 package ethereum
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 	"sort"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
 	"github.com/keep-network/keep-common/pkg/chain/ethlike"
 	"github.com/keep-network/keep-common/pkg/subscription"
 	"github.com/keep-network/keep-ecdsa/pkg/chain"
+	"github.com/keep-network/keep-ecdsa/pkg/chain/gen/ethereum/abi"
 	"github.com/keep-network/keep-ecdsa/pkg/chain/gen/ethereum/contract"
 	"github.com/keep-network/keep-ecdsa/pkg/ecdsa"
 	"github.com/keep-network/keep-ecdsa/pkg/utils/byteutils"
@@ -22,8 +27,43 @@ type bondedEcdsaKeepHandle struct {
 	keepAddress     common.Address
 	operatorAddress common.Address
 	contract        *contract.BondedECDSAKeep
+	keepContracts   *keepContractCache
+	blockCounter    *ethlike.BlockCounter
+	client          ethutil.EthereumClient
+	feeStrategy     *FeeStrategy
+
+	maxSubmissionGasPrice *big.Int
+
+	submitPublicKeyRetries    int
+	submitPublicKeyRetryDelay time.Duration
+
+	submitSignatureGasMargin float64
+
+	submissionConfirmations       uint64
+	submissionConfirmationTimeout time.Duration
+
+	blockCheckpoints chain.BlockCheckpointStore
+
+	transactionMetrics *TransactionMetrics
+
+	pastEventsChunkSize   uint64
+	pastEventsConcurrency int
+
+	readRetries      int
+	readRetryBackoff time.Duration
+
+	miningWaiter           *ethlike.MiningWaiter
+	submissionMiningWaiter *submissionMiningWaiter
+	dynamicFeeSubmitter    *dynamicFeeSubmitter
+	newContract            func(
+		miningWaiter *ethlike.MiningWaiter,
+	) (*contract.BondedECDSAKeep, error)
 }
 
+// submitSignatureFallbackGasLimit is the gas limit used for a signature
+// submission when its gas could not be estimated.
+const submitSignatureFallbackGasLimit = 150000
+
 func (ec *ethereumChain) GetKeepWithID(
 	keepID chain.ID,
 ) (chain.BondedECDSAKeepHandle, error) {
@@ -36,19 +76,24 @@ func (ec *ethereumChain) GetKeepWithID(
 		)
 	}
 
-	bondedECDSAKeepContract, err := contract.NewBondedECDSAKeep(
+	bondedECDSAKeepContract, err := ec.keepContracts.get(
 		keepAddress,
-		ec.chainID,
-		ec.accountKey,
-		ec.client,
-		ec.nonceManager,
-		ec.miningWaiter,
-		ec.blockCounter,
-		ec.transactionMutex,
+		func() (*contract.BondedECDSAKeep, error) {
+			return contract.NewBondedECDSAKeep(
+				keepAddress,
+				ec.chainID,
+				ec.accountKey,
+				ec.client,
+				ec.nonceManager,
+				ec.miningWaiter,
+				ec.blockCounter,
+				ec.transactionMutex,
+			)
+		},
 	)
 	if err != nil {
 		return nil, fmt.Errorf(
-			"failed to resolve contract for keep with id [%v]: [%v]",
+			"failed to resolve contract for keep with id [%v]: [%w]",
 			keepID,
 			err,
 		)
@@ -58,6 +103,48 @@ func (ec *ethereumChain) GetKeepWithID(
 		keepAddress:     keepAddress,
 		operatorAddress: ec.operatorAddress(),
 		contract:        bondedECDSAKeepContract,
+		keepContracts:   ec.keepContracts,
+		blockCounter:    ec.blockCounter,
+		client:          ec.client,
+		feeStrategy:     ec.feeStrategy,
+
+		maxSubmissionGasPrice: ec.maxSubmissionGasPrice,
+
+		submitPublicKeyRetries:    ec.submitPublicKeyRetries,
+		submitPublicKeyRetryDelay: ec.submitPublicKeyRetryDelay,
+
+		submitSignatureGasMargin: ec.submitSignatureGasMargin,
+
+		submissionConfirmations:       ec.submissionConfirmations,
+		submissionConfirmationTimeout: ec.submissionConfirmationTimeout,
+
+		blockCheckpoints: ec.blockCheckpoints,
+
+		transactionMetrics: ec.transactionMetrics,
+
+		pastEventsChunkSize:   ec.pastEventsChunkSize,
+		pastEventsConcurrency: ec.pastEventsConcurrency,
+
+		readRetries:      ec.readRetries,
+		readRetryBackoff: ec.readRetryBackoff,
+
+		miningWaiter:           ec.miningWaiter,
+		submissionMiningWaiter: ec.submissionMiningWaiter,
+		dynamicFeeSubmitter:    ec.dynamicFeeSubmitter,
+		newContract: func(
+			miningWaiter *ethlike.MiningWaiter,
+		) (*contract.BondedECDSAKeep, error) {
+			return contract.NewBondedECDSAKeep(
+				keepAddress,
+				ec.chainID,
+				ec.accountKey,
+				ec.client,
+				ec.nonceManager,
+				miningWaiter,
+				ec.blockCounter,
+				ec.transactionMutex,
+			)
+		},
 	}, nil
 }
 
@@ -79,28 +166,172 @@ func (ec *ethereumChain) GetKeepAtIndex(
 	return ec.GetKeepWithID(ethereumChainID(keepAddress))
 }
 
+// GetKeepsForOperator returns IDs of all keeps the given operator is a member
+// of. It scans all keeps created by the factory.
+func (ec *ethereumChain) GetKeepsForOperator(
+	operatorID chain.ID,
+) ([]chain.ID, error) {
+	if _, err := fromChainID(operatorID); err != nil {
+		return nil, fmt.Errorf(
+			"unable to interpret operator ID [%v]: [%v]",
+			operatorID,
+			err,
+		)
+	}
+
+	keepCount, err := ec.bondedECDSAKeepFactoryContract.GetKeepCount()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get keep count: [%v]", err)
+	}
+
+	// Keeps already checked during this call are remembered so members of
+	// a keep the operator does not belong to are never read twice.
+	checkedKeeps := make(map[common.Address]bool)
+
+	keepIDs := make([]chain.ID, 0)
+	for i := uint64(0); i < keepCount.Uint64(); i++ {
+		keepIndex := new(big.Int).SetUint64(i)
+
+		keepAddress, err := ec.bondedECDSAKeepFactoryContract.GetKeepAtIndex(
+			keepIndex,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to look up keep address for index [%v]: [%v]",
+				keepIndex,
+				err,
+			)
+		}
+
+		if checkedKeeps[keepAddress] {
+			continue
+		}
+		checkedKeeps[keepAddress] = true
+
+		keep, err := ec.GetKeepWithID(ethereumChainID(keepAddress))
+		if err != nil {
+			return nil, err
+		}
+
+		members, err := keep.GetMembers()
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to get members of keep [%v]: [%v]",
+				keepAddress.String(),
+				err,
+			)
+		}
+
+		for _, member := range members {
+			if member.String() == operatorID.String() {
+				keepIDs = append(keepIDs, keep.ID())
+				break
+			}
+		}
+	}
+
+	return keepIDs, nil
+}
+
+// GetApplicationKeeps returns IDs of all keeps opened by the given
+// application. Keeps are looked up with the application filter applied to
+// the keep creation events emitted by the factory, so keeps of other
+// applications are not inspected.
+func (ec *ethereumChain) GetApplicationKeeps(
+	applicationID chain.ID,
+) ([]chain.ID, error) {
+	applicationAddress, err := fromChainID(applicationID)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"unable to interpret application ID [%v]: [%v]",
+			applicationID,
+			err,
+		)
+	}
+
+	events, err := ec.bondedECDSAKeepFactoryContract.PastBondedECDSAKeepCreatedEvents(
+		0,
+		nil, // latest block
+		nil,
+		nil,
+		[]common.Address{applicationAddress},
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get keeps created for application [%v]: [%v]",
+			applicationAddress.String(),
+			err,
+		)
+	}
+
+	// Make sure keeps are in the order they were created.
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Raw.BlockNumber < events[j].Raw.BlockNumber
+	})
+
+	keepIDs := make([]chain.ID, 0, len(events))
+	for _, event := range events {
+		keepIDs = append(keepIDs, ethereumChainID(event.KeepAddress))
+	}
+
+	return keepIDs, nil
+}
+
+// GetMembersBatch returns members of all given keeps, fetching them
+// concurrently.
+func (ec *ethereumChain) GetMembersBatch(
+	keepIDs []chain.ID,
+) (map[chain.ID][]chain.ID, error) {
+	return chain.GetMembersBatch(
+		keepIDs,
+		chain.DefaultBatchConcurrency,
+		func(keepID chain.ID) ([]chain.ID, error) {
+			keep, err := ec.GetKeepWithID(keepID)
+			if err != nil {
+				return nil, err
+			}
+
+			return keep.GetMembers()
+		},
+	)
+}
+
 func (bekh *bondedEcdsaKeepHandle) ID() chain.ID {
 	return ethereumChainID(bekh.keepAddress)
 }
 
 // OnSignatureRequested installs a callback that is invoked on-chain
-// when a keep's signature is requested.
+// when a keep's signature is requested. If a non-zero number of past blocks
+// is set in the options, signature requests from those blocks are replayed
+// to the callback first. The subscription is re-established if it drops and
+// signature requests missed in the meantime are delivered to the callback.
 func (bekh *bondedEcdsaKeepHandle) OnSignatureRequested(
 	handler func(event *chain.SignatureRequestedEvent),
+	opts ...chain.SubscribeOpts,
 ) (subscription.EventSubscription, error) {
-	onEvent := func(
-		Digest [32]uint8,
-		blockNumber uint64,
-	) {
+	source, err := bekh.signatureRequestedEventSource()
+	if err != nil {
+		return nil, err
+	}
+
+	startBlock, err := bekh.startBlock(chain.PastBlocks(opts))
+	if err != nil {
+		return nil, err
+	}
+
+	onEvent := func(keepEvent interface{}) {
+		event := keepEvent.(*abi.BondedECDSAKeepSignatureRequested)
 		handler(&chain.SignatureRequestedEvent{
-			Digest:      Digest,
-			BlockNumber: blockNumber,
+			Digest:      event.Digest,
+			BlockNumber: event.Raw.BlockNumber,
 		})
 	}
-	return bekh.contract.SignatureRequested(
-		nil,
-		nil,
-	).OnEvent(onEvent), nil
+
+	return chain.SubscribeWithBackfill(
+		source,
+		bekh.backfillOpts(startBlock, ethutil.DefaultSubscribeOptsTick, source.Name),
+		onEvent,
+	)
 }
 
 // OnConflictingPublicKeySubmitted installs a callback that is invoked when an
@@ -143,16 +374,69 @@ func (bekh *bondedEcdsaKeepHandle) OnPublicKeyPublished(
 }
 
 // SubmitKeepPublicKey submits a public key to a keep contract deployed under
-// a given address.
+// a given address. Returns chain.ErrGasPriceTooHigh error if the gas price
+// exceeds the max submission gas price.
+// If submission confirmations are configured, waits until the transaction
+// is confirmed and returns chain.ErrTransactionNotConfirmed error if it is
+// not confirmed in time. The transaction is not submitted if the public key
+// is all-zero or the operator is not a member of the keep.
 func (bekh *bondedEcdsaKeepHandle) SubmitKeepPublicKey(
 	publicKey [64]byte,
 ) error {
+	if err := chain.ValidatePublicKey(publicKey); err != nil {
+		return fmt.Errorf(
+			"invalid public key for keep [%s]: [%v]",
+			bekh.keepAddress.Hex(),
+			err,
+		)
+	}
+
+	// Members are read with the read retry policy of the chain, so the
+	// check is not repeated by the submission retries below.
+	members, err := bekh.GetMembers()
+	if err != nil {
+		return fmt.Errorf("could not get keep members: [%v]", err)
+	}
+
+	if err := chain.ValidatePublicKeySubmitter(
+		ethereumChainID(bekh.operatorAddress),
+		members,
+	); err != nil {
+		return fmt.Errorf(
+			"invalid public key submission for keep [%s]: [%v]",
+			bekh.keepAddress.Hex(),
+			err,
+		)
+	}
+
+	fees, err := bekh.feeStrategy.transactionFees(bekh.client)
+	if err != nil {
+		return err
+	}
+
+	if err := checkMaxGasPrice(
+		fees.maxFeePerGas(),
+		bekh.maxSubmissionGasPrice,
+	); err != nil {
+		return err
+	}
+
+	transactor, err := bekh.keepTransactor(
+		350000, // enough for a group size of 16
+		fees,
+	)
+	if err != nil {
+		return err
+	}
+
+	var transaction *types.Transaction
 	submitPubKey := func() error {
-		transaction, err := bekh.contract.SubmitPublicKey(
-			publicKey[:],
-			ethutil.TransactionOptions{
-				GasLimit: 350000, // enough for a group size of 16
-			},
+		startTime := time.Now()
+		transaction, err = transactor.SubmitPublicKey(publicKey[:])
+		bekh.transactionMetrics.record(
+			SubmitKeepPublicKeyOperation,
+			startTime,
+			err,
 		)
 		if err != nil {
 			return err
@@ -169,16 +453,24 @@ func (bekh *bondedEcdsaKeepHandle) SubmitKeepPublicKey(
 	// a new cloned contract has not been registered by the ethereum node. Common
 	// case is when Ethereum nodes are behind a load balancer and not fully synced
 	// with each other. To mitigate this issue, a client will retry submitting
-	// a public key up to 10 times with a 250ms interval.
-	if err := withRetry(submitPubKey); err != nil {
+	// a public key according to the retry policy configured for the chain.
+	if err := withRetry(
+		bekh.submitPublicKeyRetries,
+		bekh.submitPublicKeyRetryDelay,
+		submitPubKey,
+	); err != nil {
 		return err
 	}
 
-	return nil
+	return bekh.waitForSubmissionConfirmations(transaction)
 }
 
 // SubmitSignature submits a signature to a keep contract deployed under a
-// given address.
+// given address. Returns chain.ErrGasPriceTooHigh error if the gas price
+// exceeds the max submission gas price.
+// If submission confirmations are configured, waits until the transaction
+// is confirmed and returns chain.ErrTransactionNotConfirmed error if it is
+// not confirmed in time.
 func (bekh *bondedEcdsaKeepHandle) SubmitSignature(
 	signature *ecdsa.Signature,
 ) error {
@@ -192,11 +484,42 @@ func (bekh *bondedEcdsaKeepHandle) SubmitSignature(
 		return err
 	}
 
-	transaction, err := bekh.contract.SubmitSignature(
+	gasLimit := estimateGasLimit(
+		func() (uint64, error) {
+			return bekh.contract.SubmitSignatureGasEstimate(
+				signatureR,
+				signatureS,
+				uint8(signature.RecoveryID),
+			)
+		},
+		bekh.submitSignatureGasMargin,
+		submitSignatureFallbackGasLimit,
+	)
+
+	fees, err := bekh.feeStrategy.transactionFees(bekh.client)
+	if err != nil {
+		return err
+	}
+
+	if err := checkMaxGasPrice(
+		fees.maxFeePerGas(),
+		bekh.maxSubmissionGasPrice,
+	); err != nil {
+		return err
+	}
+
+	transactor, err := bekh.keepTransactor(gasLimit, fees)
+	if err != nil {
+		return err
+	}
+
+	startTime := time.Now()
+	transaction, err := transactor.SubmitSignature(
 		signatureR,
 		signatureS,
 		uint8(signature.RecoveryID),
 	)
+	bekh.transactionMetrics.record(SubmitSignatureOperation, startTime, err)
 	if err != nil {
 		return err
 	}
@@ -206,50 +529,112 @@ func (bekh *bondedEcdsaKeepHandle) SubmitSignature(
 		transaction.Hash(),
 	)
 
-	return nil
+	return bekh.waitForSubmissionConfirmations(transaction)
 }
 
 // OnKeepClosed installs a callback that is invoked on-chain when keep is closed.
+// The subscription is re-established if it drops and the callback is invoked
+// if the keep has been closed in the meantime.
 func (bekh *bondedEcdsaKeepHandle) OnKeepClosed(
 	handler func(event *chain.KeepClosedEvent),
 ) (subscription.EventSubscription, error) {
-	onEvent := func(blockNumber uint64) {
-		handler(&chain.KeepClosedEvent{BlockNumber: blockNumber})
+	source, err := bekh.keepClosedEventSource()
+	if err != nil {
+		return nil, err
 	}
-	return bekh.contract.KeepClosed(&ethlike.SubscribeOpts{
-		Tick:       4 * time.Hour,
-		PastBlocks: 2000,
-	}).OnEvent(onEvent), nil
+
+	startBlock, err := bekh.startBlock(keepLifecycleEventsPastBlocks)
+	if err != nil {
+		return nil, err
+	}
+
+	onEvent := func(keepEvent interface{}) {
+		event := keepEvent.(*abi.BondedECDSAKeepKeepClosed)
+		bekh.keepContracts.evict(bekh.keepAddress)
+		handler(&chain.KeepClosedEvent{BlockNumber: event.Raw.BlockNumber})
+	}
+
+	return chain.SubscribeWithBackfill(
+		source,
+		bekh.backfillOpts(startBlock, keepLifecycleEventsTick, source.Name),
+		onEvent,
+	)
 }
 
 // OnKeepTerminated installs a callback that is invoked on-chain when keep
-// is terminated.
+// is terminated. The subscription is re-established if it drops and the
+// callback is invoked if the keep has been terminated in the meantime.
 func (bekh *bondedEcdsaKeepHandle) OnKeepTerminated(
 	handler func(event *chain.KeepTerminatedEvent),
 ) (subscription.EventSubscription, error) {
-	onEvent := func(blockNumber uint64) {
-		handler(&chain.KeepTerminatedEvent{BlockNumber: blockNumber})
+	source, err := bekh.keepTerminatedEventSource()
+	if err != nil {
+		return nil, err
+	}
+
+	startBlock, err := bekh.startBlock(keepLifecycleEventsPastBlocks)
+	if err != nil {
+		return nil, err
+	}
+
+	onEvent := func(keepEvent interface{}) {
+		event := keepEvent.(*abi.BondedECDSAKeepKeepTerminated)
+		bekh.keepContracts.evict(bekh.keepAddress)
+		handler(&chain.KeepTerminatedEvent{BlockNumber: event.Raw.BlockNumber})
 	}
-	return bekh.contract.KeepTerminated(&ethlike.SubscribeOpts{
-		Tick:       4 * time.Hour,
-		PastBlocks: 2000,
-	}).OnEvent(onEvent), nil
+
+	return chain.SubscribeWithBackfill(
+		source,
+		bekh.backfillOpts(startBlock, keepLifecycleEventsTick, source.Name),
+		onEvent,
+	)
 }
 
 // IsAwaitingSignature checks if the keep is waiting for a signature to be
 // calculated for the given digest.
 func (bekh *bondedEcdsaKeepHandle) IsAwaitingSignature(digest [32]byte) (bool, error) {
-	return bekh.contract.IsAwaitingSignature(digest)
+	var isAwaitingSignature bool
+	err := bekh.withReadRetry(context.Background(), func() (err error) {
+		isAwaitingSignature, err = bekh.contract.IsAwaitingSignature(digest)
+		return
+	})
+
+	return isAwaitingSignature, err
 }
 
 // IsActive checks for current state of a keep on-chain.
 func (bekh *bondedEcdsaKeepHandle) IsActive() (bool, error) {
-	return bekh.contract.IsActive()
+	return bekh.IsActiveCtx(context.Background())
+}
+
+// IsActiveCtx checks for current state of a keep on-chain. It returns
+// the context error if the context is done before the call completes.
+func (bekh *bondedEcdsaKeepHandle) IsActiveCtx(
+	ctx context.Context,
+) (bool, error) {
+	var isActive bool
+	err := bekh.withReadRetry(ctx, func() error {
+		return callWithContext(ctx, func() (err error) {
+			isActive, err = bekh.contract.IsActive()
+			return
+		})
+	})
+	if err == nil && !isActive {
+		bekh.keepContracts.evict(bekh.keepAddress)
+	}
+
+	return isActive, err
 }
 
 // LatestDigest returns the latest digest requested to be signed.
 func (bekh *bondedEcdsaKeepHandle) LatestDigest() ([32]byte, error) {
-	return bekh.contract.Digest()
+	var digest [32]byte
+	err := bekh.withReadRetry(context.Background(), func() (err error) {
+		digest, err = bekh.contract.Digest()
+		return
+	})
+
+	return digest, err
 }
 
 // SignatureRequestedBlock returns block number from the moment when a
@@ -269,12 +654,43 @@ func (bekh *bondedEcdsaKeepHandle) SignatureRequestedBlock(
 // GetPublicKey returns keep's public key. If there is no public key yet,
 // an empty slice is returned.
 func (bekh *bondedEcdsaKeepHandle) GetPublicKey() ([]uint8, error) {
-	return bekh.contract.GetPublicKey()
+	return bekh.GetPublicKeyCtx(context.Background())
+}
+
+// GetPublicKeyCtx returns keep's public key. If there is no public key yet,
+// an empty slice is returned. It returns the context error if the context
+// is done before the call completes.
+func (bekh *bondedEcdsaKeepHandle) GetPublicKeyCtx(
+	ctx context.Context,
+) ([]uint8, error) {
+	var publicKey []uint8
+	err := bekh.withReadRetry(ctx, func() error {
+		return callWithContext(ctx, func() (err error) {
+			publicKey, err = bekh.contract.GetPublicKey()
+			return
+		})
+	})
+
+	return publicKey, err
 }
 
 // GetMembers returns keep's members.
 func (bekh *bondedEcdsaKeepHandle) GetMembers() ([]chain.ID, error) {
-	memberAddresses, err := bekh.contract.GetMembers()
+	return bekh.GetMembersCtx(context.Background())
+}
+
+// GetMembersCtx returns keep's members. It returns the context error if the
+// context is done before the call completes.
+func (bekh *bondedEcdsaKeepHandle) GetMembersCtx(
+	ctx context.Context,
+) ([]chain.ID, error) {
+	var memberAddresses []common.Address
+	err := bekh.withReadRetry(ctx, func() error {
+		return callWithContext(ctx, func() (err error) {
+			memberAddresses, err = bekh.contract.GetMembers()
+			return
+		})
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -322,7 +738,21 @@ func (bekh *bondedEcdsaKeepHandle) OperatorIndex() (int, error) {
 
 // GetHonestThreshold returns keep's honest threshold.
 func (bekh *bondedEcdsaKeepHandle) GetHonestThreshold() (uint64, error) {
-	threshold, err := bekh.contract.HonestThreshold()
+	return bekh.GetHonestThresholdCtx(context.Background())
+}
+
+// GetHonestThresholdCtx returns keep's honest threshold. It returns the
+// context error if the context is done before the call completes.
+func (bekh *bondedEcdsaKeepHandle) GetHonestThresholdCtx(
+	ctx context.Context,
+) (uint64, error) {
+	var threshold *big.Int
+	err := bekh.withReadRetry(ctx, func() error {
+		return callWithContext(ctx, func() (err error) {
+			threshold, err = bekh.contract.HonestThreshold()
+			return
+		})
+	})
 	if err != nil {
 		return 0, err
 	}
@@ -348,10 +778,27 @@ func (bekh *bondedEcdsaKeepHandle) GetOpenedTimestamp() (time.Time, error) {
 func (bekh *bondedEcdsaKeepHandle) PastSignatureSubmittedEvents(
 	startBlock uint64,
 ) ([]*chain.SignatureSubmittedEvent, error) {
-	events, err := bekh.contract.PastSignatureSubmittedEvents(
+	eventsMutex := &sync.Mutex{}
+	events := make([]*abi.BondedECDSAKeepSignatureSubmitted, 0)
+
+	err := bekh.fetchPastEvents(
 		startBlock,
-		nil, // latest block
-		nil,
+		func(startBlock uint64, endBlock *uint64) error {
+			chunkEvents, err := bekh.contract.PastSignatureSubmittedEvents(
+				startBlock,
+				endBlock,
+				nil,
+			)
+			if err != nil {
+				return err
+			}
+
+			eventsMutex.Lock()
+			events = append(events, chunkEvents...)
+			eventsMutex.Unlock()
+
+			return nil
+		},
 	)
 	if err != nil {
 		return nil, err
@@ -377,16 +824,172 @@ func (bekh *bondedEcdsaKeepHandle) PastSignatureSubmittedEvents(
 	return result, nil
 }
 
-// TODO Move to keep-common and parametrize by number of retries and delay?
-func withRetry(fn func() error) error {
-	const numberOfRetries = 10
-	const delay = 12 * time.Second
+// PastKeepClosedEvents returns all keep closed events for the given keep
+// which occurred after the provided start block. Returned events are sorted
+// by the block number in the ascending order.
+func (bekh *bondedEcdsaKeepHandle) PastKeepClosedEvents(
+	startBlock uint64,
+) ([]*chain.KeepClosedEvent, error) {
+	eventsMutex := &sync.Mutex{}
+	events := make([]*abi.BondedECDSAKeepKeepClosed, 0)
+
+	err := bekh.fetchPastEvents(
+		startBlock,
+		func(startBlock uint64, endBlock *uint64) error {
+			chunkEvents, err := bekh.contract.PastKeepClosedEvents(
+				startBlock,
+				endBlock,
+			)
+			if err != nil {
+				return err
+			}
+
+			eventsMutex.Lock()
+			events = append(events, chunkEvents...)
+			eventsMutex.Unlock()
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*chain.KeepClosedEvent, 0)
+
+	for _, event := range events {
+		result = append(result, &chain.KeepClosedEvent{
+			BlockNumber: event.Raw.BlockNumber,
+		})
+	}
+
+	// Make sure events are sorted by block number in ascending order.
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].BlockNumber < result[j].BlockNumber
+	})
+
+	return result, nil
+}
+
+// PastKeepTerminatedEvents returns all keep terminated events for the given
+// keep which occurred after the provided start block. Returned events are
+// sorted by the block number in the ascending order.
+func (bekh *bondedEcdsaKeepHandle) PastKeepTerminatedEvents(
+	startBlock uint64,
+) ([]*chain.KeepTerminatedEvent, error) {
+	eventsMutex := &sync.Mutex{}
+	events := make([]*abi.BondedECDSAKeepKeepTerminated, 0)
+
+	err := bekh.fetchPastEvents(
+		startBlock,
+		func(startBlock uint64, endBlock *uint64) error {
+			chunkEvents, err := bekh.contract.PastKeepTerminatedEvents(
+				startBlock,
+				endBlock,
+			)
+			if err != nil {
+				return err
+			}
+
+			eventsMutex.Lock()
+			events = append(events, chunkEvents...)
+			eventsMutex.Unlock()
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*chain.KeepTerminatedEvent, 0)
+
+	for _, event := range events {
+		result = append(result, &chain.KeepTerminatedEvent{
+			BlockNumber: event.Raw.BlockNumber,
+		})
+	}
+
+	// Make sure events are sorted by block number in ascending order.
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].BlockNumber < result[j].BlockNumber
+	})
+
+	return result, nil
+}
+
+// callWithContext executes the given chain call and waits for its result
+// unless the context is done first. In such a case, the context error is
+// returned immediately and the result of the call is discarded once it
+// completes.
+func callWithContext(ctx context.Context, call func() error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	resultChan := make(chan error, 1)
+	go func() {
+		resultChan <- call()
+	}()
 
+	select {
+	case err := <-resultChan:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// estimateGasLimit returns the gas limit for a transaction based on the gas
+// estimate returned by the given function with the safety margin, expressed
+// as a fraction of the estimate, added on top. If the gas could not be
+// estimated, the fallback gas limit is returned.
+func estimateGasLimit(
+	estimateGas func() (uint64, error),
+	margin float64,
+	fallbackGasLimit uint64,
+) uint64 {
+	gasEstimate, err := estimateGas()
+	if err != nil {
+		logger.Warningf(
+			"failed to estimate gas; using fallback gas limit [%v]: [%v]",
+			fallbackGasLimit,
+			err,
+		)
+		return fallbackGasLimit
+	}
+
+	return gasLimitWithMargin(gasEstimate, margin)
+}
+
+// gasLimitWithMargin adds the safety margin, expressed as a fraction of the
+// gas estimate, on top of the gas estimate.
+func gasLimitWithMargin(gasEstimate uint64, margin float64) uint64 {
+	return uint64(float64(gasEstimate) * (1 + margin))
+}
+
+// withReadRetry executes the read-only chain call fn with the retry policy
+// configured for the keep handle.
+func (bekh *bondedEcdsaKeepHandle) withReadRetry(
+	ctx context.Context,
+	fn func() error,
+) error {
+	return withReadRetry(ctx, bekh.readRetries, bekh.readRetryBackoff, fn)
+}
+
+// withRetry executes fn until it succeeds or the number of retries is
+// reached. The given delay is applied between consecutive attempts.
+// TODO Move to keep-common?
+func withRetry(
+	numberOfRetries int,
+	delay time.Duration,
+	fn func() error,
+) error {
 	for i := 1; ; i++ {
 		err := fn()
 		if err != nil {
 			logger.Errorf("Error occurred [%v]; on [%v] retry", err, i)
-			if i == numberOfRetries {
+			if i >= numberOfRetries {
 				return err
 			}
 			time.Sleep(delay)
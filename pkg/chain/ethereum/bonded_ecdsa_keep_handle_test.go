@@ -0,0 +1,165 @@
+//+build !celo
+
+package ethereum
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestWithRetry_GivesUpAfterConfiguredRetries(t *testing.T) {
+	attempts := 0
+	fn := func() error {
+		attempts++
+		return fmt.Errorf("failure")
+	}
+
+	err := withRetry(2, time.Millisecond, fn)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	expectedAttempts := 2
+	if expectedAttempts != attempts {
+		t.Errorf(
+			"unexpected number of attempts\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedAttempts,
+			attempts,
+		)
+	}
+}
+
+func TestWithRetry_SucceedsOnRetry(t *testing.T) {
+	attempts := 0
+	fn := func() error {
+		attempts++
+		if attempts < 2 {
+			return fmt.Errorf("failure")
+		}
+		return nil
+	}
+
+	err := withRetry(3, time.Millisecond, fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedAttempts := 2
+	if expectedAttempts != attempts {
+		t.Errorf(
+			"unexpected number of attempts\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedAttempts,
+			attempts,
+		)
+	}
+}
+
+func TestIsActiveCtx_CancelledContext(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	cancelCtx()
+
+	// The contract is not set so any attempt to reach the chain would panic.
+	keep := &bondedEcdsaKeepHandle{}
+
+	_, err := keep.IsActiveCtx(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf(
+			"unexpected error\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			context.Canceled,
+			err,
+		)
+	}
+}
+
+func TestCallWithContext_Timeout(t *testing.T) {
+	ctx, cancelCtx := context.WithTimeout(
+		context.Background(),
+		10*time.Millisecond,
+	)
+	defer cancelCtx()
+
+	blockChan := make(chan struct{})
+	defer close(blockChan)
+
+	err := callWithContext(ctx, func() error {
+		<-blockChan
+		return nil
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf(
+			"unexpected error\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			context.DeadlineExceeded,
+			err,
+		)
+	}
+}
+
+func TestCallWithContext_CallCompleted(t *testing.T) {
+	expectedErr := fmt.Errorf("call error")
+
+	err := callWithContext(context.Background(), func() error {
+		return expectedErr
+	})
+	if err != expectedErr {
+		t.Errorf(
+			"unexpected error\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedErr,
+			err,
+		)
+	}
+}
+
+func TestEstimateGasLimit_AppliesMargin(t *testing.T) {
+	gasLimit := estimateGasLimit(
+		func() (uint64, error) {
+			return 100000, nil
+		},
+		0.2,
+		150000,
+	)
+
+	expectedGasLimit := uint64(120000)
+	if expectedGasLimit != gasLimit {
+		t.Errorf(
+			"unexpected gas limit\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedGasLimit,
+			gasLimit,
+		)
+	}
+}
+
+func TestEstimateGasLimit_FallsBackOnEstimationFailure(t *testing.T) {
+	gasLimit := estimateGasLimit(
+		func() (uint64, error) {
+			return 0, fmt.Errorf("execution reverted")
+		},
+		0.2,
+		150000,
+	)
+
+	expectedGasLimit := uint64(150000)
+	if expectedGasLimit != gasLimit {
+		t.Errorf(
+			"unexpected gas limit\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedGasLimit,
+			gasLimit,
+		)
+	}
+}
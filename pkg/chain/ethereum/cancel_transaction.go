@@ -0,0 +1,217 @@
+//+build !celo
+
+package ethereum
+
+import (
+	"context"
+	cecdsa "crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// cancelTransactionGasLimit is the gas limit of a plain ether transfer.
+const cancelTransactionGasLimit = 21000
+
+// cancelTransactionGasPriceBump is the percentage the gas price of a stuck
+// transaction is increased by for its replacement. Nodes accept a replacement
+// of a pending transaction only if it pays a gas price at least 10% higher.
+const cancelTransactionGasPriceBump = 10
+
+// TransactionCanceler is implemented by chain handles able to cancel pending
+// transactions submitted by the operator.
+type TransactionCanceler interface {
+	// CancelPendingTransaction replaces the pending transaction with the given
+	// nonce with a zero-value transfer to the operator's own account. It
+	// returns the hash of the replacement transaction.
+	CancelPendingTransaction(nonce uint64) (common.Hash, error)
+}
+
+// pendingBlockNumber is the block number the Ethereum client resolves to the
+// pending block.
+var pendingBlockNumber = big.NewInt(-1)
+
+// transactionSender is the part of the Ethereum client suggesting gas prices,
+// providing the pending block and sending transactions.
+type transactionSender interface {
+	gasPriceSuggester
+	BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error)
+	SendTransaction(ctx context.Context, transaction *types.Transaction) error
+}
+
+// pendingNonceSource provides the nonce of the next transaction of the
+// operator.
+type pendingNonceSource interface {
+	CurrentNonce() (uint64, error)
+}
+
+// CancelPendingTransaction replaces the pending transaction with the given
+// nonce with a zero-value transfer to the operator's own account. The gas
+// price of the pending transaction is looked up in the pending block and the
+// replacement pays it increased by 10% or the gas price currently suggested
+// by the node, whichever is higher. If the pending transaction is not part
+// of the pending block, the replacement pays the suggested gas price
+// increased by 10%. It is meant to unblock transactions waiting behind
+// a transaction stuck in the mempool. Transaction submission is serialized
+// with other transactions of the operator. It returns the hash of the
+// replacement transaction.
+func (ec *ethereumChain) CancelPendingTransaction(
+	nonce uint64,
+) (common.Hash, error) {
+	ec.transactionMutex.Lock()
+	defer ec.transactionMutex.Unlock()
+
+	transaction, err := cancelPendingTransaction(
+		ec.client,
+		ec.nonceManager,
+		ec.accountKey.PrivateKey,
+		ec.chainID,
+		nonce,
+	)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	logger.Infof(
+		"submitted transaction [%s] cancelling pending transaction "+
+			"with nonce [%v]",
+		transaction.Hash().Hex(),
+		nonce,
+	)
+
+	return transaction.Hash(), nil
+}
+
+func cancelPendingTransaction(
+	client transactionSender,
+	nonceSource pendingNonceSource,
+	privateKey *cecdsa.PrivateKey,
+	chainID *big.Int,
+	nonce uint64,
+) (*types.Transaction, error) {
+	pendingNonce, err := nonceSource.CurrentNonce()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve account nonce: [%v]", err)
+	}
+
+	if nonce >= pendingNonce {
+		return nil, fmt.Errorf(
+			"no pending transaction with nonce [%v]; next nonce is [%v]",
+			nonce,
+			pendingNonce,
+		)
+	}
+
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 1*time.Minute)
+	defer cancelCtx()
+
+	suggestedGasPrice, err := client.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get suggested gas price: [%v]", err)
+	}
+
+	operatorAddress := crypto.PubkeyToAddress(privateKey.PublicKey)
+	signer := types.LatestSignerForChainID(chainID)
+
+	stuckGasPrice, err := pendingTransactionGasPrice(
+		ctx,
+		client,
+		signer,
+		operatorAddress,
+		nonce,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var gasPrice *big.Int
+	if stuckGasPrice != nil {
+		gasPrice = bumpGasPrice(stuckGasPrice)
+		if suggestedGasPrice.Cmp(gasPrice) > 0 {
+			gasPrice = suggestedGasPrice
+		}
+	} else {
+		logger.Warningf(
+			"pending transaction with nonce [%v] not found in the pending "+
+				"block; cancelling it with suggested gas price increased "+
+				"by [%v]%%",
+			nonce,
+			cancelTransactionGasPriceBump,
+		)
+		gasPrice = bumpGasPrice(suggestedGasPrice)
+	}
+
+	transaction, err := types.SignTx(
+		types.NewTransaction(
+			nonce,
+			operatorAddress,
+			big.NewInt(0),
+			cancelTransactionGasLimit,
+			gasPrice,
+			nil,
+		),
+		signer,
+		privateKey,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not sign cancelling transaction: [%v]",
+			err,
+		)
+	}
+
+	if err := client.SendTransaction(ctx, transaction); err != nil {
+		return nil, fmt.Errorf(
+			"could not send cancelling transaction: [%v]",
+			err,
+		)
+	}
+
+	return transaction, nil
+}
+
+// pendingTransactionGasPrice looks up the transaction with the given nonce
+// sent from the given address in the pending block and returns its gas
+// price. For dynamic fee transactions, the fee cap is returned. Returns nil
+// if the transaction is not part of the pending block.
+func pendingTransactionGasPrice(
+	ctx context.Context,
+	client transactionSender,
+	signer types.Signer,
+	from common.Address,
+	nonce uint64,
+) (*big.Int, error) {
+	pendingBlock, err := client.BlockByNumber(ctx, pendingBlockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("could not get pending block: [%v]", err)
+	}
+
+	for _, transaction := range pendingBlock.Transactions() {
+		if transaction.Nonce() != nonce {
+			continue
+		}
+
+		sender, err := types.Sender(signer, transaction)
+		if err != nil || sender != from {
+			continue
+		}
+
+		return transaction.GasPrice(), nil
+	}
+
+	return nil, nil
+}
+
+// bumpGasPrice returns the given gas price increased by the percentage nodes
+// require from a replacement of a pending transaction.
+func bumpGasPrice(gasPrice *big.Int) *big.Int {
+	bumpedGasPrice := new(big.Int).Mul(
+		gasPrice,
+		big.NewInt(100+cancelTransactionGasPriceBump),
+	)
+	return bumpedGasPrice.Div(bumpedGasPrice, big.NewInt(100))
+}
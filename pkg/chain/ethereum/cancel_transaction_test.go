@@ -0,0 +1,282 @@
+//+build !celo
+
+package ethereum
+
+import (
+	"context"
+	cecdsa "crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+type transactionSenderStub struct {
+	suggestedGasPrice   *big.Int
+	pendingTransactions []*types.Transaction
+	sentTransactions    []*types.Transaction
+}
+
+func (tss *transactionSenderStub) SuggestGasPrice(
+	ctx context.Context,
+) (*big.Int, error) {
+	return tss.suggestedGasPrice, nil
+}
+
+func (tss *transactionSenderStub) BlockByNumber(
+	ctx context.Context,
+	number *big.Int,
+) (*types.Block, error) {
+	if number.Cmp(pendingBlockNumber) != 0 {
+		return nil, fmt.Errorf("unexpected block number [%v]", number)
+	}
+
+	return types.NewBlockWithHeader(&types.Header{}).WithBody(
+		tss.pendingTransactions,
+		nil,
+	), nil
+}
+
+func (tss *transactionSenderStub) SendTransaction(
+	ctx context.Context,
+	transaction *types.Transaction,
+) error {
+	tss.sentTransactions = append(tss.sentTransactions, transaction)
+	return nil
+}
+
+type pendingNonceSourceStub struct {
+	pendingNonce uint64
+}
+
+func (pnss *pendingNonceSourceStub) CurrentNonce() (uint64, error) {
+	return pnss.pendingNonce, nil
+}
+
+func TestCancelPendingTransaction(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	operatorAddress := crypto.PubkeyToAddress(privateKey.PublicKey)
+
+	chainID := big.NewInt(1101)
+	nonce := uint64(7)
+
+	client := &transactionSenderStub{
+		suggestedGasPrice: big.NewInt(40000000000),
+		pendingTransactions: []*types.Transaction{
+			signedTransaction(t, privateKey, chainID, nonce, big.NewInt(20000000000)),
+		},
+	}
+
+	transaction, err := cancelPendingTransaction(
+		client,
+		&pendingNonceSourceStub{pendingNonce: 10},
+		privateKey,
+		chainID,
+		nonce,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(client.sentTransactions) != 1 {
+		t.Fatalf(
+			"unexpected number of sent transactions\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			1,
+			len(client.sentTransactions),
+		)
+	}
+
+	if client.sentTransactions[0] != transaction {
+		t.Errorf("returned transaction should be the sent one")
+	}
+
+	if transaction.Nonce() != nonce {
+		t.Errorf(
+			"unexpected nonce\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			nonce,
+			transaction.Nonce(),
+		)
+	}
+
+	if transaction.GasPrice().Cmp(client.suggestedGasPrice) != 0 {
+		t.Errorf(
+			"unexpected gas price\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			client.suggestedGasPrice,
+			transaction.GasPrice(),
+		)
+	}
+
+	if *transaction.To() != operatorAddress {
+		t.Errorf(
+			"unexpected recipient\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			operatorAddress.Hex(),
+			transaction.To().Hex(),
+		)
+	}
+
+	if transaction.Value().Sign() != 0 {
+		t.Errorf("transaction should not transfer any value")
+	}
+
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), transaction)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sender != operatorAddress {
+		t.Errorf(
+			"unexpected sender\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			operatorAddress.Hex(),
+			sender.Hex(),
+		)
+	}
+}
+
+func TestCancelPendingTransaction_SuggestedGasPriceBelowStuck(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chainID := big.NewInt(1101)
+
+	client := &transactionSenderStub{
+		suggestedGasPrice: big.NewInt(30000000000),
+		pendingTransactions: []*types.Transaction{
+			signedTransaction(t, privateKey, chainID, 6, big.NewInt(90000000000)),
+			signedTransaction(t, privateKey, chainID, 7, big.NewInt(50000000000)),
+		},
+	}
+
+	transaction, err := cancelPendingTransaction(
+		client,
+		&pendingNonceSourceStub{pendingNonce: 10},
+		privateKey,
+		chainID,
+		7,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedGasPrice := big.NewInt(55000000000)
+	if transaction.GasPrice().Cmp(expectedGasPrice) != 0 {
+		t.Errorf(
+			"unexpected gas price\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedGasPrice,
+			transaction.GasPrice(),
+		)
+	}
+}
+
+func TestCancelPendingTransaction_NotInPendingBlock(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	otherPrivateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chainID := big.NewInt(1101)
+
+	client := &transactionSenderStub{
+		suggestedGasPrice: big.NewInt(30000000000),
+		pendingTransactions: []*types.Transaction{
+			// same nonce but sent from another account
+			signedTransaction(t, otherPrivateKey, chainID, 7, big.NewInt(90000000000)),
+		},
+	}
+
+	transaction, err := cancelPendingTransaction(
+		client,
+		&pendingNonceSourceStub{pendingNonce: 10},
+		privateKey,
+		chainID,
+		7,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedGasPrice := big.NewInt(33000000000)
+	if transaction.GasPrice().Cmp(expectedGasPrice) != 0 {
+		t.Errorf(
+			"unexpected gas price\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedGasPrice,
+			transaction.GasPrice(),
+		)
+	}
+}
+
+func TestCancelPendingTransaction_NoPendingTransaction(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := &transactionSenderStub{suggestedGasPrice: big.NewInt(1)}
+
+	_, err = cancelPendingTransaction(
+		client,
+		&pendingNonceSourceStub{pendingNonce: 10},
+		privateKey,
+		big.NewInt(1101),
+		10,
+	)
+	if err == nil {
+		t.Fatal("expected error for nonce without pending transaction")
+	}
+
+	if len(client.sentTransactions) != 0 {
+		t.Errorf("no transaction should be sent")
+	}
+}
+
+func signedTransaction(
+	t *testing.T,
+	privateKey *cecdsa.PrivateKey,
+	chainID *big.Int,
+	nonce uint64,
+	gasPrice *big.Int,
+) *types.Transaction {
+	transaction, err := types.SignTx(
+		types.NewTransaction(
+			nonce,
+			common.HexToAddress("0x1"),
+			big.NewInt(0),
+			100000,
+			gasPrice,
+			nil,
+		),
+		types.LatestSignerForChainID(chainID),
+		privateKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return transaction
+}
@@ -0,0 +1,130 @@
+//+build !celo
+
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/keep-network/keep-ecdsa/pkg/chain"
+)
+
+// confirmationsCheckInterval is the interval in which the number of
+// confirmations of a submitted transaction is checked.
+const confirmationsCheckInterval = 5 * time.Second
+
+// transactionReceiptSource is the part of the Ethereum client providing
+// receipts of mined transactions.
+type transactionReceiptSource interface {
+	TransactionReceipt(
+		ctx context.Context,
+		transactionHash common.Hash,
+	) (*types.Receipt, error)
+}
+
+// currentBlockSource provides the number of the current block.
+type currentBlockSource interface {
+	CurrentBlock() (uint64, error)
+}
+
+// waitForConfirmations waits until the transaction with the given hash is
+// mined and has the given number of confirmations, where the block the
+// transaction is mined in is the first confirmation. Returns the receipt of
+// the confirmed transaction. Returns chain.ErrTransactionNotConfirmed error if
+// the transaction is not confirmed within the timeout and an error if the
+// transaction is reverted.
+func waitForConfirmations(
+	client transactionReceiptSource,
+	blockCounter currentBlockSource,
+	transactionHash common.Hash,
+	confirmations uint64,
+	timeout time.Duration,
+	checkInterval time.Duration,
+) (*types.Receipt, error) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), timeout)
+	defer cancelCtx()
+
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			// The receipt is not available until the transaction is mined.
+			receipt, err := client.TransactionReceipt(ctx, transactionHash)
+			if err != nil || receipt == nil {
+				continue
+			}
+
+			if receipt.Status == types.ReceiptStatusFailed {
+				return receipt, fmt.Errorf(
+					"transaction [%s] reverted in block [%v]",
+					transactionHash.Hex(),
+					receipt.BlockNumber,
+				)
+			}
+
+			currentBlock, err := blockCounter.CurrentBlock()
+			if err != nil {
+				logger.Warningf(
+					"could not get current block while waiting for "+
+						"confirmations of transaction [%s]: [%v]",
+					transactionHash.Hex(),
+					err,
+				)
+				continue
+			}
+
+			minedBlock := receipt.BlockNumber.Uint64()
+			if currentBlock >= minedBlock &&
+				currentBlock-minedBlock+1 >= confirmations {
+				return receipt, nil
+			}
+		case <-ctx.Done():
+			return nil, fmt.Errorf(
+				"transaction [%s] has not got [%v] confirmations "+
+					"within [%v]: [%w]",
+				transactionHash.Hex(),
+				confirmations,
+				timeout,
+				chain.ErrTransactionNotConfirmed,
+			)
+		}
+	}
+}
+
+// waitForSubmissionConfirmations waits until the given transaction submitted
+// to the keep contract gets the number of confirmations configured for the
+// chain. It returns immediately if no confirmations are required.
+func (bekh *bondedEcdsaKeepHandle) waitForSubmissionConfirmations(
+	transaction *types.Transaction,
+) error {
+	if bekh.submissionConfirmations == 0 {
+		return nil
+	}
+
+	receipt, err := waitForConfirmations(
+		bekh.client,
+		bekh.blockCounter,
+		transaction.Hash(),
+		bekh.submissionConfirmations,
+		bekh.submissionConfirmationTimeout,
+		confirmationsCheckInterval,
+	)
+	if err != nil {
+		return err
+	}
+
+	logger.Debugf(
+		"transaction with hash [%s] mined in block [%v] got [%v] confirmations",
+		transaction.Hash(),
+		receipt.BlockNumber,
+		bekh.submissionConfirmations,
+	)
+
+	return nil
+}
@@ -0,0 +1,112 @@
+//+build !celo
+
+package ethereum
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/keep-network/keep-ecdsa/pkg/chain"
+)
+
+// chainStub mines a new block on every transaction receipt check. The
+// transaction is mined in the given block, or never if it is zero.
+type chainStub struct {
+	mutex        sync.Mutex
+	currentBlock uint64
+	minedBlock   uint64
+}
+
+func (cs *chainStub) TransactionReceipt(
+	ctx context.Context,
+	transactionHash common.Hash,
+) (*types.Receipt, error) {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+
+	cs.currentBlock++
+
+	if cs.minedBlock == 0 || cs.currentBlock < cs.minedBlock {
+		return nil, fmt.Errorf("not found")
+	}
+
+	return &types.Receipt{
+		Status:      types.ReceiptStatusSuccessful,
+		TxHash:      transactionHash,
+		BlockNumber: new(big.Int).SetUint64(cs.minedBlock),
+	}, nil
+}
+
+func (cs *chainStub) CurrentBlock() (uint64, error) {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+
+	return cs.currentBlock, nil
+}
+
+func TestWaitForConfirmations_Confirmed(t *testing.T) {
+	stub := &chainStub{currentBlock: 10, minedBlock: 11}
+
+	receipt, err := waitForConfirmations(
+		stub,
+		stub,
+		common.HexToHash("0x01"),
+		3,
+		5*time.Second,
+		10*time.Millisecond,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedBlock := uint64(11)
+	if receipt.BlockNumber.Uint64() != expectedBlock {
+		t.Errorf(
+			"unexpected receipt block\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedBlock,
+			receipt.BlockNumber,
+		)
+	}
+
+	minConfirmedBlock := uint64(13)
+	if stub.currentBlock < minConfirmedBlock {
+		t.Errorf(
+			"transaction not confirmed enough\n"+
+				"expected: [>= %v]\n"+
+				"actual:   [%v]",
+			minConfirmedBlock,
+			stub.currentBlock,
+		)
+	}
+}
+
+func TestWaitForConfirmations_Timeout(t *testing.T) {
+	stub := &chainStub{currentBlock: 10}
+
+	_, err := waitForConfirmations(
+		stub,
+		stub,
+		common.HexToHash("0x01"),
+		3,
+		100*time.Millisecond,
+		10*time.Millisecond,
+	)
+	if !errors.Is(err, chain.ErrTransactionNotConfirmed) {
+		t.Errorf(
+			"unexpected error\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			chain.ErrTransactionNotConfirmed,
+			err,
+		)
+	}
+}
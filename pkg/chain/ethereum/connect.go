@@ -14,6 +14,7 @@ import (
 	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
 	"github.com/keep-network/keep-common/pkg/chain/ethlike"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
@@ -27,6 +28,7 @@ import (
 const (
 	BondedECDSAKeepFactoryContractName = "BondedECDSAKeepFactory"
 	TBTCSystemContractName             = "TBTCSystem"
+	KeepBondingContractName            = "KeepBonding"
 )
 
 var (
@@ -42,6 +44,33 @@ var (
 	// allowed gas price is reached, no further resubmission attempts are
 	// performed. This value can be overwritten in the configuration file.
 	DefaultMaxGasPrice = big.NewInt(1000000000000) // 1000 Gwei
+
+	// DefaultSubmitPublicKeyRetries is the default maximum number of attempts
+	// to submit a public key to a keep contract.
+	DefaultSubmitPublicKeyRetries = 10
+
+	// DefaultSubmitPublicKeyRetryDelay is the default delay between consecutive
+	// attempts to submit a public key to a keep contract.
+	DefaultSubmitPublicKeyRetryDelay = 12 * time.Second
+
+	// DefaultSubmitSignatureGasMargin is the default safety margin added on
+	// top of the gas estimate of a signature submission. It is expressed as
+	// a fraction of the estimate.
+	DefaultSubmitSignatureGasMargin = 0.2
+
+	// DefaultSubmissionConfirmationTimeout is the default maximum time to
+	// wait for the required number of confirmations of a transaction submitted
+	// to a keep contract.
+	DefaultSubmissionConfirmationTimeout = 10 * time.Minute
+
+	// DefaultReadRetries is the default maximum number of attempts of
+	// a read-only call to a keep contract.
+	DefaultReadRetries = 3
+
+	// DefaultReadRetryBackoff is the default delay before the second attempt
+	// of a read-only call to a keep contract. The delay doubles after each
+	// consecutive attempt.
+	DefaultReadRetryBackoff = 500 * time.Millisecond
 )
 
 // ethereumChain is an implementation of ethereum blockchain interface.
@@ -51,7 +80,9 @@ type ethereumChain struct {
 	client                         ethutil.EthereumClient
 	chainID                        *big.Int
 	bondedECDSAKeepFactoryContract *contract.BondedECDSAKeepFactory
+	keepContracts                  *keepContractCache
 	tbtcSystemAddress              common.Address
+	keepBondingContract            *keepBonding
 	blockCounter                   *ethlike.BlockCounter
 	miningWaiter                   *ethlike.MiningWaiter
 	nonceManager                   *ethlike.NonceManager
@@ -69,14 +100,197 @@ type ethereumChain struct {
 	// nonce. Serializing submission ensures that each nonce is requested after
 	// a previous transaction has been submitted.
 	transactionMutex *sync.Mutex
+
+	// feeStrategy determines fees of transactions submitted to keep
+	// contracts. dynamicFeeSubmitter submits dynamic fee transactions; it is
+	// nil if the fee strategy is not enabled.
+	feeStrategy         *FeeStrategy
+	dynamicFeeSubmitter *dynamicFeeSubmitter
+
+	// maxSubmissionGasPrice is the maximum gas price keep transactions are
+	// submitted with. It is nil if there is no such ceiling.
+	maxSubmissionGasPrice *big.Int
+
+	// submitPublicKeyRetries and submitPublicKeyRetryDelay determine the
+	// retry policy of the public key submission.
+	submitPublicKeyRetries    int
+	submitPublicKeyRetryDelay time.Duration
+
+	// submitSignatureGasMargin is the safety margin added on top of the gas
+	// estimate of a signature submission.
+	submitSignatureGasMargin float64
+
+	// submissionConfirmations is the number of confirmations transactions
+	// submitted to keep contracts are waited for. Transactions are not waited
+	// for if it is zero.
+	submissionConfirmations       uint64
+	submissionConfirmationTimeout time.Duration
+
+	// blockCheckpoints persists the last block processed by keep event
+	// subscriptions. It is nil if checkpoints are not persisted.
+	blockCheckpoints chain.BlockCheckpointStore
+
+	// transactionMetrics collects metrics of keep transaction submissions.
+	// It is nil if transaction metrics are not collected.
+	transactionMetrics *TransactionMetrics
+
+	// pastEventsChunkSize is the maximum number of blocks past keep events
+	// are fetched for in a single call. Past events are fetched in a single
+	// call if it is zero. pastEventsConcurrency is the maximum number of such
+	// calls executed at the same time.
+	pastEventsChunkSize   uint64
+	pastEventsConcurrency int
+
+	// readRetries and readRetryBackoff determine the retry policy of
+	// frequently executed read-only calls to keep contracts.
+	readRetries      int
+	readRetryBackoff time.Duration
+
+	// submissionMiningWaiter determines how public key and signature
+	// submissions are waited for to be mined and resubmitted. It is nil if
+	// the mining waiter shared by all contracts is used.
+	submissionMiningWaiter *submissionMiningWaiter
+}
+
+// ConnectOption customizes the chain handle created by Connect.
+type ConnectOption func(ec *ethereumChain)
+
+// WithFeeStrategy sets the fee strategy used for transactions submitted to
+// keep contracts. If not set, legacy transactions priced at the gas price
+// suggested by the connected node are submitted.
+func WithFeeStrategy(feeStrategy *FeeStrategy) ConnectOption {
+	return func(ec *ethereumChain) {
+		ec.feeStrategy = feeStrategy
+	}
+}
+
+// WithMaxSubmissionGasPrice sets the maximum gas price keep transactions are
+// submitted with. If the gas price a transaction would be submitted with is
+// higher, the transaction is not submitted and chain.ErrGasPriceTooHigh error
+// is returned so the caller can retry later. If not set, transactions are
+// submitted regardless of the gas price.
+func WithMaxSubmissionGasPrice(maxGasPrice *big.Int) ConnectOption {
+	return func(ec *ethereumChain) {
+		ec.maxSubmissionGasPrice = maxGasPrice
+	}
+}
+
+// WithSubmitPublicKeyRetry sets the maximum number of attempts and the delay
+// between consecutive attempts used when submitting a public key to a keep
+// contract. If not set, DefaultSubmitPublicKeyRetries and
+// DefaultSubmitPublicKeyRetryDelay are used.
+func WithSubmitPublicKeyRetry(
+	numberOfRetries int,
+	delay time.Duration,
+) ConnectOption {
+	return func(ec *ethereumChain) {
+		ec.submitPublicKeyRetries = numberOfRetries
+		ec.submitPublicKeyRetryDelay = delay
+	}
+}
+
+// WithSubmitSignatureGasMargin sets the safety margin added on top of the
+// gas estimate of a signature submission, expressed as a fraction of the
+// estimate. If not set, DefaultSubmitSignatureGasMargin is used.
+func WithSubmitSignatureGasMargin(margin float64) ConnectOption {
+	return func(ec *ethereumChain) {
+		ec.submitSignatureGasMargin = margin
+	}
+}
+
+// WithSubmissionConfirmations makes submissions of public keys and
+// signatures to keep contracts wait until the submitted transaction is mined
+// and gets the given number of confirmations. If the transaction is not
+// confirmed within the timeout, chain.ErrTransactionNotConfirmed error is
+// returned. If not set, submissions return right after the transaction is
+// sent.
+func WithSubmissionConfirmations(
+	confirmations uint64,
+	timeout time.Duration,
+) ConnectOption {
+	return func(ec *ethereumChain) {
+		ec.submissionConfirmations = confirmations
+		ec.submissionConfirmationTimeout = timeout
+	}
+}
+
+// WithBlockCheckpointStore sets the store keep event subscriptions persist
+// their last processed block in, so they resume from that block after
+// a restart. If not set, subscriptions look up a fixed number of past blocks
+// when installed.
+func WithBlockCheckpointStore(store chain.BlockCheckpointStore) ConnectOption {
+	return func(ec *ethereumChain) {
+		ec.blockCheckpoints = store
+	}
+}
+
+// WithTransactionMetrics sets the collector of metrics of transactions
+// submitted by the client. If not set, transaction metrics are not collected.
+func WithTransactionMetrics(
+	transactionMetrics *TransactionMetrics,
+) ConnectOption {
+	return func(ec *ethereumChain) {
+		ec.transactionMetrics = transactionMetrics
+	}
+}
+
+// WithPastEventsChunking makes past keep events be fetched in block ranges
+// having at most the given number of blocks, so a single call does not
+// exceed the block range limit of the Ethereum node. At most concurrency
+// ranges are fetched at the same time. If not set, past events are fetched
+// in a single call.
+func WithPastEventsChunking(chunkSize uint64, concurrency int) ConnectOption {
+	return func(ec *ethereumChain) {
+		ec.pastEventsChunkSize = chunkSize
+		ec.pastEventsConcurrency = concurrency
+	}
+}
+
+// WithReadRetry sets the maximum number of attempts and the initial backoff
+// used when frequently executed read-only calls to keep contracts fail. The
+// backoff doubles after each attempt. Errors which are not going to change
+// when the call is retried are not retried. If not set, DefaultReadRetries
+// and DefaultReadRetryBackoff are used.
+func WithReadRetry(maxAttempts int, initialBackoff time.Duration) ConnectOption {
+	return func(ec *ethereumChain) {
+		ec.readRetries = maxAttempts
+		ec.readRetryBackoff = initialBackoff
+	}
+}
+
+// WithSubmissionMiningWaiter sets how public key and signature submissions
+// are waited for to be mined. A submitted transaction which is not mined
+// within the check interval is resubmitted with a 20% higher gas price, at
+// most maxResubmissions times and never above the max gas price from the
+// config. If not set, the mining check interval from the config is used and
+// transactions are resubmitted until they reach the max gas price.
+//
+// Resubmissions happen in the background, after the submission call has
+// returned. Retries of the public key submission configured with
+// WithSubmitPublicKeyRetry only repeat submissions which failed to be sent,
+// so they never overlap with resubmissions of a sent transaction. If
+// submission confirmations are configured, the confirmation timeout should
+// cover the check interval multiplied by the number of resubmissions.
+func WithSubmissionMiningWaiter(
+	checkInterval time.Duration,
+	maxResubmissions int,
+) ConnectOption {
+	return func(ec *ethereumChain) {
+		ec.submissionMiningWaiter = &submissionMiningWaiter{
+			checkInterval:    checkInterval,
+			maxResubmissions: maxResubmissions,
+		}
+	}
 }
 
 // Connect performs initialization for communication with Ethereum blockchain
-// based on provided config.
+// based on provided config. Optional connect options can be passed to
+// customize the returned chain handle.
 func Connect(
 	ctx context.Context,
 	accountKey *keystore.Key,
 	config *ethereum.Config,
+	options ...ConnectOption,
 ) (chain.Handle, error) {
 	client, err := ethclient.Dial(config.URL)
 	if err != nil {
@@ -154,17 +368,104 @@ func Connect(
 		return nil, err
 	}
 
+	var keepBondingContract *keepBonding
+	keepBondingAddress, err := config.ContractAddress(KeepBondingContractName)
+	if err == nil {
+		keepBondingContract, err = newKeepBonding(
+			keepBondingAddress,
+			accountKey.Address,
+			wrappedClient,
+		)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// A missing KeepBonding address should only mean that bond amounts
+		// can't be read, not that the whole client fails to start.
+		logger.Warning(
+			"KeepBonding address not configured; " +
+				"bond amounts will not be available",
+		)
+	}
+
 	ethereum := &ethereumChain{
 		config:                         config,
 		accountKey:                     accountKey,
 		client:                         wrappedClient,
 		chainID:                        chainID,
 		bondedECDSAKeepFactoryContract: bondedECDSAKeepFactoryContract,
+		keepContracts:                  newKeepContractCache(),
 		tbtcSystemAddress:              tbtcSystemAddress,
+		keepBondingContract:            keepBondingContract,
 		blockCounter:                   blockCounter,
 		nonceManager:                   nonceManager,
 		miningWaiter:                   miningWaiter,
 		transactionMutex:               transactionMutex,
+		submitPublicKeyRetries:         DefaultSubmitPublicKeyRetries,
+		submitPublicKeyRetryDelay:      DefaultSubmitPublicKeyRetryDelay,
+		submitSignatureGasMargin:       DefaultSubmitSignatureGasMargin,
+		submissionConfirmationTimeout:  DefaultSubmissionConfirmationTimeout,
+		readRetries:                    DefaultReadRetries,
+		readRetryBackoff:               DefaultReadRetryBackoff,
+	}
+
+	for _, option := range options {
+		option(ethereum)
+	}
+
+	if ethereum.submissionMiningWaiter != nil {
+		ethereum.submissionMiningWaiter.maxGasPrice = maxGasPrice
+		ethereum.submissionMiningWaiter.newMiningWaiter = func(
+			checkInterval time.Duration,
+			maxGasPrice *big.Int,
+		) *ethlike.MiningWaiter {
+			return ethutil.NewMiningWaiter(
+				wrappedClient,
+				checkInterval,
+				maxGasPrice,
+			)
+		}
+
+		logger.Infof(
+			"using [%v] submission mining check interval with at most [%v] "+
+				"resubmissions",
+			ethereum.submissionMiningWaiter.checkInterval,
+			ethereum.submissionMiningWaiter.maxResubmissions,
+		)
+	}
+
+	if ethereum.feeStrategy.enabled() {
+		transactorOptions, err := bind.NewKeyedTransactorWithChainID(
+			accountKey.PrivateKey,
+			chainID,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to instantiate dynamic fee transactor: [%v]",
+				err,
+			)
+		}
+
+		ethereum.dynamicFeeSubmitter = &dynamicFeeSubmitter{
+			backend:           wrappedClient,
+			transactorOptions: transactorOptions,
+			nonceManager:      nonceManager,
+			transactionMutex:  transactionMutex,
+		}
+
+		logger.Infof(
+			"using EIP-1559 fee strategy with [%v] wei max fee per gas "+
+				"and [%v] wei max priority fee per gas",
+			ethereum.feeStrategy.MaxFeePerGas,
+			ethereum.feeStrategy.MaxPriorityFeePerGas,
+		)
+	}
+
+	if ethereum.maxSubmissionGasPrice != nil {
+		logger.Infof(
+			"using [%v] wei max submission gas price",
+			ethereum.maxSubmissionGasPrice,
+		)
 	}
 
 	ethereum.initializeBalanceMonitoring(ctx)
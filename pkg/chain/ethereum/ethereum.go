@@ -107,6 +107,8 @@ func (ec *ethereumChain) OnBondedECDSAKeepCreated(
 		handler(&chain.BondedECDSAKeepCreatedEvent{
 			Keep:                 keep,
 			MemberIDs:            memberIDs,
+			OwnerID:              ethereumChainID(Owner),
+			ApplicationID:        ethereumChainID(Application),
 			HonestThreshold:      HonestThreshold.Uint64(),
 			BlockNumber:          blockNumber,
 			ThisOperatorIsMember: thisOperatorIsMember,
@@ -184,3 +186,20 @@ func (ec *ethereumChain) WeiBalanceOf(
 func (ec *ethereumChain) BalanceMonitor() (*ethutil.BalanceMonitor, error) {
 	return ethutil.NewBalanceMonitor(ec.WeiBalanceOf), nil
 }
+
+// BalanceThresholdMonitor returns a monitor notifying about the balance
+// dropping below registered thresholds.
+func (ec *ethereumChain) BalanceThresholdMonitor() (*BalanceThresholdMonitor, error) {
+	return NewBalanceThresholdMonitor(ec.WeiBalanceOf), nil
+}
+
+// contractDeployed checks if there is a contract deployed under the given
+// address.
+func (ec *ethereumChain) contractDeployed(address common.Address) (bool, error) {
+	code, err := ec.client.CodeAt(context.Background(), address, nil)
+	if err != nil {
+		return false, err
+	}
+
+	return len(code) > 0, nil
+}
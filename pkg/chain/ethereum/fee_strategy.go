@@ -0,0 +1,224 @@
+//+build !celo
+
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
+	"github.com/keep-network/keep-ecdsa/pkg/chain"
+)
+
+// FeeStrategy determines fees of transactions submitted to keep contracts.
+// If the connected node supports EIP-1559, that is, the latest block header
+// carries a base fee, transactions are submitted as dynamic fee transactions
+// with MaxFeePerGas as their fee cap and MaxPriorityFeePerGas as their tip
+// cap. Otherwise, legacy transactions priced at the gas price suggested by
+// the node are submitted.
+type FeeStrategy struct {
+	// MaxFeePerGas is the maximum fee per gas, including the base fee, the
+	// client is willing to pay for a transaction.
+	MaxFeePerGas *big.Int
+	// MaxPriorityFeePerGas is the maximum fee per gas paid on top of the base
+	// fee to get the transaction included. If not set, the priority fee
+	// suggested by the connected node is used.
+	MaxPriorityFeePerGas *big.Int
+}
+
+// enabled returns true if the strategy submits dynamic fee transactions to
+// nodes supporting EIP-1559.
+func (fs *FeeStrategy) enabled() bool {
+	return fs != nil && fs.MaxFeePerGas != nil
+}
+
+// feeOracle is the part of the Ethereum client providing the latest block
+// header and fees suggested by the connected node.
+type feeOracle interface {
+	gasPriceSuggester
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+}
+
+// transactionFees holds fees a transaction is submitted with. Legacy
+// transactions carry only gasPrice, dynamic fee transactions carry only
+// gasFeeCap and gasTipCap.
+type transactionFees struct {
+	gasPrice  *big.Int
+	gasFeeCap *big.Int
+	gasTipCap *big.Int
+}
+
+// dynamic returns true if the fees are fees of a dynamic fee transaction.
+func (tf *transactionFees) dynamic() bool {
+	return tf.gasFeeCap != nil
+}
+
+// maxFeePerGas returns the maximum price per gas a transaction submitted
+// with the fees may pay.
+func (tf *transactionFees) maxFeePerGas() *big.Int {
+	if tf.dynamic() {
+		return tf.gasFeeCap
+	}
+
+	return tf.gasPrice
+}
+
+// apply sets the fees on the given transactor options.
+func (tf *transactionFees) apply(options *bind.TransactOpts) {
+	options.GasPrice = tf.gasPrice
+	options.GasFeeCap = tf.gasFeeCap
+	options.GasTipCap = tf.gasTipCap
+}
+
+// transactionFees returns fees of a transaction submitted according to the
+// strategy. Dynamic fees are returned only if the strategy is enabled and
+// the latest block header carries a base fee; legacy fees with the gas price
+// suggested by the node are returned otherwise.
+func (fs *FeeStrategy) transactionFees(
+	client feeOracle,
+) (*transactionFees, error) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 1*time.Minute)
+	defer cancelCtx()
+
+	if fs.enabled() {
+		header, err := client.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"could not get latest block header: [%v]",
+				err,
+			)
+		}
+
+		if header.BaseFee != nil {
+			return fs.dynamicFees(ctx, client, header.BaseFee)
+		}
+
+		logger.Warningf(
+			"connected node does not support EIP-1559; " +
+				"falling back to legacy gas price",
+		)
+	}
+
+	gasPrice, err := client.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get suggested gas price: [%v]", err)
+	}
+
+	return &transactionFees{gasPrice: gasPrice}, nil
+}
+
+// dynamicFees returns fees of a dynamic fee transaction. The priority fee
+// never exceeds the maximum fee per gas as nodes reject such transactions.
+func (fs *FeeStrategy) dynamicFees(
+	ctx context.Context,
+	client feeOracle,
+	baseFee *big.Int,
+) (*transactionFees, error) {
+	gasTipCap := fs.MaxPriorityFeePerGas
+	if gasTipCap == nil {
+		suggestedGasTipCap, err := client.SuggestGasTipCap(ctx)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"could not get suggested priority fee: [%v]",
+				err,
+			)
+		}
+
+		gasTipCap = suggestedGasTipCap
+	}
+
+	if gasTipCap.Cmp(fs.MaxFeePerGas) > 0 {
+		gasTipCap = fs.MaxFeePerGas
+	}
+
+	if baseFee.Cmp(fs.MaxFeePerGas) > 0 {
+		logger.Warningf(
+			"base fee [%v] wei exceeds max fee per gas [%v] wei; "+
+				"transaction will not be mined until the base fee drops",
+			baseFee,
+			fs.MaxFeePerGas,
+		)
+	}
+
+	return &transactionFees{
+		gasFeeCap: new(big.Int).Set(fs.MaxFeePerGas),
+		gasTipCap: new(big.Int).Set(gasTipCap),
+	}, nil
+}
+
+// gasPriceSuggester is the part of the Ethereum client providing the gas
+// price suggested by the connected node.
+type gasPriceSuggester interface {
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+}
+
+// checkGasPrice ensures the gas price a transaction is going to be submitted
+// with does not exceed the maximum gas price. If the transaction options do
+// not carry a gas price, contract bindings use the gas price suggested by the
+// node so that price is checked instead. A nil maximum gas price disables
+// the check. Returns chain.ErrGasPriceTooHigh error if the gas price is
+// above the maximum.
+func checkGasPrice(
+	client gasPriceSuggester,
+	maxGasPrice *big.Int,
+	options ethutil.TransactionOptions,
+) error {
+	if maxGasPrice == nil {
+		return nil
+	}
+
+	gasPrice, err := transactionGasPrice(client, options)
+	if err != nil {
+		return err
+	}
+
+	return checkMaxGasPrice(gasPrice, maxGasPrice)
+}
+
+// checkMaxGasPrice ensures the given gas price does not exceed the maximum
+// gas price. A nil maximum gas price disables the check. Returns
+// chain.ErrGasPriceTooHigh error if the gas price is above the maximum.
+func checkMaxGasPrice(gasPrice *big.Int, maxGasPrice *big.Int) error {
+	if maxGasPrice == nil {
+		return nil
+	}
+
+	if gasPrice.Cmp(maxGasPrice) > 0 {
+		return fmt.Errorf(
+			"gas price [%v] wei exceeds max submission gas price [%v] wei: [%w]",
+			gasPrice,
+			maxGasPrice,
+			chain.ErrGasPriceTooHigh,
+		)
+	}
+
+	return nil
+}
+
+// transactionGasPrice returns the gas price a transaction is going to be
+// submitted with. If the transaction options do not carry a gas price,
+// contract bindings use the gas price suggested by the node so that price is
+// returned instead.
+func transactionGasPrice(
+	client gasPriceSuggester,
+	options ethutil.TransactionOptions,
+) (*big.Int, error) {
+	if options.GasPrice != nil {
+		return options.GasPrice, nil
+	}
+
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 1*time.Minute)
+	defer cancelCtx()
+
+	suggestedGasPrice, err := client.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get suggested gas price: [%v]", err)
+	}
+
+	return suggestedGasPrice, nil
+}
@@ -0,0 +1,273 @@
+//go:build !celo
+// +build !celo
+
+package ethereum
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
+	"github.com/keep-network/keep-ecdsa/pkg/chain"
+)
+
+type feeOracleStub struct {
+	baseFee            *big.Int
+	suggestedGasPrice  *big.Int
+	suggestedGasTipCap *big.Int
+}
+
+func (fos *feeOracleStub) HeaderByNumber(
+	ctx context.Context,
+	number *big.Int,
+) (*types.Header, error) {
+	return &types.Header{BaseFee: fos.baseFee}, nil
+}
+
+func (fos *feeOracleStub) SuggestGasPrice(
+	ctx context.Context,
+) (*big.Int, error) {
+	return fos.suggestedGasPrice, nil
+}
+
+func (fos *feeOracleStub) SuggestGasTipCap(
+	ctx context.Context,
+) (*big.Int, error) {
+	return fos.suggestedGasTipCap, nil
+}
+
+func TestFeeStrategyTransactionFees(t *testing.T) {
+	var tests = map[string]struct {
+		feeStrategy  *FeeStrategy
+		baseFee      *big.Int
+		expectedFees *transactionFees
+	}{
+		"no strategy": {
+			feeStrategy: nil,
+			baseFee:     big.NewInt(100),
+			expectedFees: &transactionFees{
+				gasPrice: big.NewInt(150),
+			},
+		},
+		"strategy without max fee per gas": {
+			feeStrategy: &FeeStrategy{
+				MaxPriorityFeePerGas: big.NewInt(2),
+			},
+			baseFee: big.NewInt(100),
+			expectedFees: &transactionFees{
+				gasPrice: big.NewInt(150),
+			},
+		},
+		"node not supporting EIP-1559": {
+			feeStrategy: &FeeStrategy{
+				MaxFeePerGas:         big.NewInt(200),
+				MaxPriorityFeePerGas: big.NewInt(2),
+			},
+			baseFee: nil,
+			expectedFees: &transactionFees{
+				gasPrice: big.NewInt(150),
+			},
+		},
+		"dynamic fees": {
+			feeStrategy: &FeeStrategy{
+				MaxFeePerGas:         big.NewInt(200),
+				MaxPriorityFeePerGas: big.NewInt(2),
+			},
+			baseFee: big.NewInt(100),
+			expectedFees: &transactionFees{
+				gasFeeCap: big.NewInt(200),
+				gasTipCap: big.NewInt(2),
+			},
+		},
+		"dynamic fees without max priority fee per gas": {
+			feeStrategy: &FeeStrategy{
+				MaxFeePerGas: big.NewInt(200),
+			},
+			baseFee: big.NewInt(100),
+			expectedFees: &transactionFees{
+				gasFeeCap: big.NewInt(200),
+				gasTipCap: big.NewInt(3),
+			},
+		},
+		"dynamic fees with max priority fee above max fee per gas": {
+			feeStrategy: &FeeStrategy{
+				MaxFeePerGas:         big.NewInt(200),
+				MaxPriorityFeePerGas: big.NewInt(250),
+			},
+			baseFee: big.NewInt(100),
+			expectedFees: &transactionFees{
+				gasFeeCap: big.NewInt(200),
+				gasTipCap: big.NewInt(200),
+			},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			fees, err := test.feeStrategy.transactionFees(
+				&feeOracleStub{
+					baseFee:            test.baseFee,
+					suggestedGasPrice:  big.NewInt(150),
+					suggestedGasTipCap: big.NewInt(3),
+				},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(test.expectedFees, fees) {
+				t.Errorf(
+					"unexpected transaction fees\n"+
+						"expected: [%+v]\n"+
+						"actual:   [%+v]",
+					test.expectedFees,
+					fees,
+				)
+			}
+		})
+	}
+}
+
+func TestTransactionFeesApply(t *testing.T) {
+	var tests = map[string]struct {
+		fees              *transactionFees
+		expectedGasPrice  *big.Int
+		expectedGasFeeCap *big.Int
+		expectedGasTipCap *big.Int
+	}{
+		"legacy fees": {
+			fees:             &transactionFees{gasPrice: big.NewInt(150)},
+			expectedGasPrice: big.NewInt(150),
+		},
+		"dynamic fees": {
+			fees: &transactionFees{
+				gasFeeCap: big.NewInt(200),
+				gasTipCap: big.NewInt(2),
+			},
+			expectedGasFeeCap: big.NewInt(200),
+			expectedGasTipCap: big.NewInt(2),
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			// Options set by the mode not in use must be cleared.
+			options := &bind.TransactOpts{
+				GasPrice:  big.NewInt(1),
+				GasFeeCap: big.NewInt(1),
+				GasTipCap: big.NewInt(1),
+			}
+
+			test.fees.apply(options)
+
+			if !reflect.DeepEqual(test.expectedGasPrice, options.GasPrice) {
+				t.Errorf(
+					"unexpected gas price\n"+
+						"expected: [%v]\n"+
+						"actual:   [%v]",
+					test.expectedGasPrice,
+					options.GasPrice,
+				)
+			}
+			if !reflect.DeepEqual(test.expectedGasFeeCap, options.GasFeeCap) {
+				t.Errorf(
+					"unexpected gas fee cap\n"+
+						"expected: [%v]\n"+
+						"actual:   [%v]",
+					test.expectedGasFeeCap,
+					options.GasFeeCap,
+				)
+			}
+			if !reflect.DeepEqual(test.expectedGasTipCap, options.GasTipCap) {
+				t.Errorf(
+					"unexpected gas tip cap\n"+
+						"expected: [%v]\n"+
+						"actual:   [%v]",
+					test.expectedGasTipCap,
+					options.GasTipCap,
+				)
+			}
+		})
+	}
+}
+
+type gasPriceSuggesterStub struct {
+	suggestedGasPrice *big.Int
+}
+
+func (gpss *gasPriceSuggesterStub) SuggestGasPrice(
+	ctx context.Context,
+) (*big.Int, error) {
+	return gpss.suggestedGasPrice, nil
+}
+
+func TestCheckGasPrice(t *testing.T) {
+	var tests = map[string]struct {
+		suggestedGasPrice *big.Int
+		maxGasPrice       *big.Int
+		options           ethutil.TransactionOptions
+		expectedError     error
+	}{
+		"no max gas price": {
+			suggestedGasPrice: big.NewInt(500),
+			maxGasPrice:       nil,
+			expectedError:     nil,
+		},
+		"suggested gas price below max gas price": {
+			suggestedGasPrice: big.NewInt(99),
+			maxGasPrice:       big.NewInt(100),
+			expectedError:     nil,
+		},
+		"suggested gas price equal to max gas price": {
+			suggestedGasPrice: big.NewInt(100),
+			maxGasPrice:       big.NewInt(100),
+			expectedError:     nil,
+		},
+		"suggested gas price above max gas price": {
+			suggestedGasPrice: big.NewInt(500),
+			maxGasPrice:       big.NewInt(100),
+			expectedError:     chain.ErrGasPriceTooHigh,
+		},
+		"options gas price below max gas price": {
+			suggestedGasPrice: big.NewInt(500),
+			maxGasPrice:       big.NewInt(100),
+			options: ethutil.TransactionOptions{
+				GasPrice: big.NewInt(90),
+			},
+			expectedError: nil,
+		},
+		"options gas price above max gas price": {
+			suggestedGasPrice: big.NewInt(10),
+			maxGasPrice:       big.NewInt(100),
+			options: ethutil.TransactionOptions{
+				GasPrice: big.NewInt(101),
+			},
+			expectedError: chain.ErrGasPriceTooHigh,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			err := checkGasPrice(
+				&gasPriceSuggesterStub{test.suggestedGasPrice},
+				test.maxGasPrice,
+				test.options,
+			)
+
+			if !errors.Is(err, test.expectedError) {
+				t.Errorf(
+					"unexpected error\n"+
+						"expected: [%v]\n"+
+						"actual:   [%v]",
+					test.expectedError,
+					err,
+				)
+			}
+		})
+	}
+}
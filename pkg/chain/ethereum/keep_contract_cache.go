@@ -0,0 +1,73 @@
+//+build !celo
+
+package ethereum
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/keep-network/keep-ecdsa/pkg/chain/gen/ethereum/contract"
+)
+
+// keepContractCache holds keep contract bindings so a binding for the given
+// keep is constructed once and reused by all handles of that keep.
+//
+// Constructing a binding parses the whole keep contract ABI, which accounts
+// for almost all allocations of a keep handle lookup. BenchmarkKeepContract
+// compares cached and uncached lookups; run it with -benchmem to see the
+// difference.
+type keepContractCache struct {
+	mutex     sync.Mutex
+	contracts map[common.Address]*contract.BondedECDSAKeep
+}
+
+func newKeepContractCache() *keepContractCache {
+	return &keepContractCache{
+		contracts: make(map[common.Address]*contract.BondedECDSAKeep),
+	}
+}
+
+// get returns the cached binding for the given keep address. If there is no
+// binding cached yet, it is created with newContractFn and cached. The cache
+// is not locked while the binding is created so lookups of other keeps do not
+// wait for it. Failed creations are not cached.
+func (kcc *keepContractCache) get(
+	keepAddress common.Address,
+	newContractFn func() (*contract.BondedECDSAKeep, error),
+) (*contract.BondedECDSAKeep, error) {
+	kcc.mutex.Lock()
+	keepContract, ok := kcc.contracts[keepAddress]
+	kcc.mutex.Unlock()
+
+	if ok {
+		return keepContract, nil
+	}
+
+	keepContract, err := newContractFn()
+	if err != nil {
+		return nil, err
+	}
+
+	kcc.mutex.Lock()
+	defer kcc.mutex.Unlock()
+
+	// Another lookup might have cached a binding in the meantime; prefer it
+	// so all handles of the keep share the same binding.
+	if cachedContract, ok := kcc.contracts[keepAddress]; ok {
+		return cachedContract, nil
+	}
+
+	kcc.contracts[keepAddress] = keepContract
+
+	return keepContract, nil
+}
+
+// evict removes the binding for the given keep address from the cache. It
+// should be called once the keep is closed or terminated and its binding is
+// not expected to be needed anymore.
+func (kcc *keepContractCache) evict(keepAddress common.Address) {
+	kcc.mutex.Lock()
+	defer kcc.mutex.Unlock()
+
+	delete(kcc.contracts, keepAddress)
+}
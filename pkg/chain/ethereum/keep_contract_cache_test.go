@@ -0,0 +1,174 @@
+//+build !celo
+
+package ethereum
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/keep-network/keep-ecdsa/pkg/chain/gen/ethereum/contract"
+)
+
+func TestKeepContractCache_SameAddress(t *testing.T) {
+	cache := newKeepContractCache()
+	keepAddress := common.HexToAddress("0x1111111111111111111111111111111111111111")
+
+	createdContracts := 0
+	newContractFn := func() (*contract.BondedECDSAKeep, error) {
+		createdContracts++
+		return &contract.BondedECDSAKeep{}, nil
+	}
+
+	first, err := cache.get(keepAddress, newContractFn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := cache.get(keepAddress, newContractFn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if first != second {
+		t.Errorf(
+			"unexpected binding\n"+
+				"expected: [%p]\n"+
+				"actual:   [%p]",
+			first,
+			second,
+		)
+	}
+
+	if createdContracts != 1 {
+		t.Errorf(
+			"unexpected number of created bindings\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			1,
+			createdContracts,
+		)
+	}
+}
+
+func TestKeepContractCache_Evict(t *testing.T) {
+	cache := newKeepContractCache()
+	keepAddress := common.HexToAddress("0x1111111111111111111111111111111111111111")
+
+	newContractFn := func() (*contract.BondedECDSAKeep, error) {
+		return &contract.BondedECDSAKeep{}, nil
+	}
+
+	first, err := cache.get(keepAddress, newContractFn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cache.evict(keepAddress)
+
+	second, err := cache.get(keepAddress, newContractFn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if first == second {
+		t.Errorf("expected a new binding to be created after eviction")
+	}
+}
+
+func TestKeepContractCache_FailedCreationNotCached(t *testing.T) {
+	cache := newKeepContractCache()
+	keepAddress := common.HexToAddress("0x1111111111111111111111111111111111111111")
+
+	_, err := cache.get(
+		keepAddress,
+		func() (*contract.BondedECDSAKeep, error) {
+			return nil, fmt.Errorf("could not create binding")
+		},
+	)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	createdContracts := 0
+	_, err = cache.get(
+		keepAddress,
+		func() (*contract.BondedECDSAKeep, error) {
+			createdContracts++
+			return &contract.BondedECDSAKeep{}, nil
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if createdContracts != 1 {
+		t.Errorf(
+			"unexpected number of created bindings\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			1,
+			createdContracts,
+		)
+	}
+}
+
+func BenchmarkKeepContract_Uncached(b *testing.B) {
+	_, newContractFn := benchmarkKeepContractFn(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := newContractFn(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkKeepContract_Cached(b *testing.B) {
+	keepAddress, newContractFn := benchmarkKeepContractFn(b)
+	cache := newKeepContractCache()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := cache.get(keepAddress, newContractFn); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func benchmarkKeepContractFn(b *testing.B) (
+	common.Address,
+	func() (*contract.BondedECDSAKeep, error),
+) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	accountKey := &keystore.Key{
+		Address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+		PrivateKey: privateKey,
+	}
+
+	keepAddress := common.HexToAddress("0x1111111111111111111111111111111111111111")
+
+	return keepAddress, func() (*contract.BondedECDSAKeep, error) {
+		return contract.NewBondedECDSAKeep(
+			keepAddress,
+			big.NewInt(1),
+			accountKey,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+		)
+	}
+}
@@ -0,0 +1,250 @@
+//+build !celo
+
+package ethereum
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
+	"github.com/keep-network/keep-common/pkg/chain/ethlike"
+
+	"github.com/keep-network/keep-ecdsa/pkg/chain/gen/ethereum/abi"
+	"github.com/keep-network/keep-ecdsa/pkg/chain/gen/ethereum/contract"
+)
+
+// keepTransactor submits public keys and signatures to a keep contract.
+type keepTransactor interface {
+	SubmitPublicKey(publicKey []byte) (*types.Transaction, error)
+	SubmitSignature(
+		signatureR [32]byte,
+		signatureS [32]byte,
+		recoveryID uint8,
+	) (*types.Transaction, error)
+}
+
+// legacyKeepTransactor submits legacy transactions to a keep contract
+// through the keep contract binding.
+type legacyKeepTransactor struct {
+	contract *contract.BondedECDSAKeep
+	options  ethutil.TransactionOptions
+}
+
+func (lkt *legacyKeepTransactor) SubmitPublicKey(
+	publicKey []byte,
+) (*types.Transaction, error) {
+	return lkt.contract.SubmitPublicKey(publicKey, lkt.options)
+}
+
+func (lkt *legacyKeepTransactor) SubmitSignature(
+	signatureR [32]byte,
+	signatureS [32]byte,
+	recoveryID uint8,
+) (*types.Transaction, error) {
+	return lkt.contract.SubmitSignature(
+		signatureR,
+		signatureS,
+		recoveryID,
+		lkt.options,
+	)
+}
+
+// dynamicFeeSubmitter submits dynamic fee transactions on behalf of the
+// operator. Contract bindings submit legacy transactions only, so dynamic
+// fee transactions are built with the low-level contract transactors. The
+// submitter shares the nonce manager and the transaction mutex with contract
+// bindings so all transactions of the operator are serialized.
+type dynamicFeeSubmitter struct {
+	backend           bind.ContractTransactor
+	transactorOptions *bind.TransactOpts
+	nonceManager      *ethlike.NonceManager
+	transactionMutex  *sync.Mutex
+}
+
+// submit submits a dynamic fee transaction with the given gas limit and fees
+// using the given submit function. If the mining waiter is set, the
+// transaction is resubmitted with higher fees until it is mined.
+func (dfs *dynamicFeeSubmitter) submit(
+	name string,
+	gasLimit uint64,
+	fees *transactionFees,
+	miningWaiter *ethlike.MiningWaiter,
+	submitFn func(options *bind.TransactOpts) (*types.Transaction, error),
+) (*types.Transaction, error) {
+	dfs.transactionMutex.Lock()
+	defer dfs.transactionMutex.Unlock()
+
+	// create a copy
+	options := new(bind.TransactOpts)
+	*options = *dfs.transactorOptions
+	options.GasLimit = gasLimit
+	fees.apply(options)
+
+	nonce, err := dfs.nonceManager.CurrentNonce()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve account nonce: [%v]", err)
+	}
+
+	options.Nonce = new(big.Int).SetUint64(nonce)
+
+	transaction, err := submitFn(options)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infof(
+		"submitted dynamic fee transaction %s with id: [%s] and nonce [%v]",
+		name,
+		transaction.Hash(),
+		transaction.Nonce(),
+	)
+
+	if miningWaiter != nil {
+		go miningWaiter.ForceMining(
+			&ethlike.Transaction{
+				Hash:     ethlike.Hash(transaction.Hash()),
+				GasPrice: transaction.GasFeeCap(),
+			},
+			func(newGasFeeCap *big.Int) (*ethlike.Transaction, error) {
+				options.GasTipCap = resubmissionGasTipCap(
+					options.GasTipCap,
+					options.GasFeeCap,
+					newGasFeeCap,
+				)
+				options.GasFeeCap = newGasFeeCap
+
+				transaction, err := submitFn(options)
+				if err != nil {
+					return nil, err
+				}
+
+				logger.Infof(
+					"resubmitted dynamic fee transaction %s with id: [%s] "+
+						"and nonce [%v]",
+					name,
+					transaction.Hash(),
+					transaction.Nonce(),
+				)
+
+				return &ethlike.Transaction{
+					Hash:     ethlike.Hash(transaction.Hash()),
+					GasPrice: transaction.GasFeeCap(),
+				}, nil
+			},
+		)
+	}
+
+	dfs.nonceManager.IncrementNonce()
+
+	return transaction, nil
+}
+
+// resubmissionGasTipCap returns the priority fee of a resubmitted dynamic fee
+// transaction. The mining waiter increases only the fee cap of the
+// transaction, while nodes accept a replacement only if both its fee cap and
+// priority fee are higher, so the priority fee is increased in the same
+// proportion as the fee cap.
+func resubmissionGasTipCap(
+	gasTipCap *big.Int,
+	gasFeeCap *big.Int,
+	newGasFeeCap *big.Int,
+) *big.Int {
+	newGasTipCap := new(big.Int).Mul(gasTipCap, newGasFeeCap)
+	return newGasTipCap.Div(newGasTipCap, gasFeeCap)
+}
+
+// dynamicFeeKeepTransactor submits dynamic fee transactions to a keep
+// contract.
+type dynamicFeeKeepTransactor struct {
+	submitter    *dynamicFeeSubmitter
+	transactor   *abi.BondedECDSAKeepTransactor
+	gasLimit     uint64
+	fees         *transactionFees
+	miningWaiter *ethlike.MiningWaiter
+}
+
+func (dfkt *dynamicFeeKeepTransactor) SubmitPublicKey(
+	publicKey []byte,
+) (*types.Transaction, error) {
+	return dfkt.submitter.submit(
+		"submitPublicKey",
+		dfkt.gasLimit,
+		dfkt.fees,
+		dfkt.miningWaiter,
+		func(options *bind.TransactOpts) (*types.Transaction, error) {
+			return dfkt.transactor.SubmitPublicKey(options, publicKey)
+		},
+	)
+}
+
+func (dfkt *dynamicFeeKeepTransactor) SubmitSignature(
+	signatureR [32]byte,
+	signatureS [32]byte,
+	recoveryID uint8,
+) (*types.Transaction, error) {
+	return dfkt.submitter.submit(
+		"submitSignature",
+		dfkt.gasLimit,
+		dfkt.fees,
+		dfkt.miningWaiter,
+		func(options *bind.TransactOpts) (*types.Transaction, error) {
+			return dfkt.transactor.SubmitSignature(
+				options,
+				signatureR,
+				signatureS,
+				recoveryID,
+			)
+		},
+	)
+}
+
+// keepTransactor returns the transactor a keep transaction with the given gas
+// limit and fees should be submitted with. Dynamic fee transactions are
+// submitted with the dynamic fee submitter of the chain, legacy transactions
+// with the keep contract binding.
+func (bekh *bondedEcdsaKeepHandle) keepTransactor(
+	gasLimit uint64,
+	fees *transactionFees,
+) (keepTransactor, error) {
+	if !fees.dynamic() {
+		submissionContract, err := bekh.submissionContract(fees.gasPrice)
+		if err != nil {
+			return nil, err
+		}
+
+		return &legacyKeepTransactor{
+			contract: submissionContract,
+			options: ethutil.TransactionOptions{
+				GasLimit: gasLimit,
+				GasPrice: fees.gasPrice,
+			},
+		}, nil
+	}
+
+	if bekh.dynamicFeeSubmitter == nil {
+		return nil, fmt.Errorf("dynamic fee submitter is not configured")
+	}
+
+	transactor, err := abi.NewBondedECDSAKeepTransactor(
+		bekh.keepAddress,
+		bekh.dynamicFeeSubmitter.backend,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to instantiate transactor for keep [%s]: [%v]",
+			bekh.keepAddress.Hex(),
+			err,
+		)
+	}
+
+	return &dynamicFeeKeepTransactor{
+		submitter:    bekh.dynamicFeeSubmitter,
+		transactor:   transactor,
+		gasLimit:     gasLimit,
+		fees:         fees,
+		miningWaiter: bekh.resubmissionMiningWaiter(fees.gasFeeCap),
+	}, nil
+}
@@ -0,0 +1,305 @@
+//+build !celo
+
+package ethereum
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
+	"github.com/keep-network/keep-common/pkg/chain/ethlike"
+
+	"github.com/keep-network/keep-ecdsa/pkg/chain/gen/ethereum/abi"
+	"github.com/keep-network/keep-ecdsa/pkg/chain/gen/ethereum/contract"
+)
+
+type contractTransactorStub struct {
+	baseFee         *big.Int
+	sentTransaction *types.Transaction
+}
+
+func (cts *contractTransactorStub) HeaderByNumber(
+	ctx context.Context,
+	number *big.Int,
+) (*types.Header, error) {
+	return &types.Header{BaseFee: cts.baseFee}, nil
+}
+
+func (cts *contractTransactorStub) PendingCodeAt(
+	ctx context.Context,
+	account common.Address,
+) ([]byte, error) {
+	return []byte{0x1}, nil
+}
+
+func (cts *contractTransactorStub) PendingNonceAt(
+	ctx context.Context,
+	account common.Address,
+) (uint64, error) {
+	return 0, nil
+}
+
+func (cts *contractTransactorStub) SuggestGasPrice(
+	ctx context.Context,
+) (*big.Int, error) {
+	return big.NewInt(150), nil
+}
+
+func (cts *contractTransactorStub) SuggestGasTipCap(
+	ctx context.Context,
+) (*big.Int, error) {
+	return big.NewInt(3), nil
+}
+
+func (cts *contractTransactorStub) EstimateGas(
+	ctx context.Context,
+	call ethereum.CallMsg,
+) (uint64, error) {
+	return 100000, nil
+}
+
+func (cts *contractTransactorStub) SendTransaction(
+	ctx context.Context,
+	transaction *types.Transaction,
+) error {
+	cts.sentTransaction = transaction
+	return nil
+}
+
+func TestKeepTransactor(t *testing.T) {
+	sharedContract := &contract.BondedECDSAKeep{}
+	submitter := &dynamicFeeSubmitter{backend: &contractTransactorStub{}}
+	miningWaiter := &ethlike.MiningWaiter{}
+
+	keep := &bondedEcdsaKeepHandle{
+		keepAddress:         common.HexToAddress("0x1"),
+		contract:            sharedContract,
+		miningWaiter:        miningWaiter,
+		dynamicFeeSubmitter: submitter,
+	}
+
+	t.Run("legacy fees", func(t *testing.T) {
+		transactor, err := keep.keepTransactor(
+			350000,
+			&transactionFees{gasPrice: big.NewInt(150)},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		legacyTransactor, ok := transactor.(*legacyKeepTransactor)
+		if !ok {
+			t.Fatalf("unexpected transactor type: [%T]", transactor)
+		}
+
+		if legacyTransactor.contract != sharedContract {
+			t.Errorf("shared keep contract should be used")
+		}
+
+		expectedOptions := ethutil.TransactionOptions{
+			GasLimit: 350000,
+			GasPrice: big.NewInt(150),
+		}
+		if !reflect.DeepEqual(expectedOptions, legacyTransactor.options) {
+			t.Errorf(
+				"unexpected transaction options\n"+
+					"expected: [%+v]\n"+
+					"actual:   [%+v]",
+				expectedOptions,
+				legacyTransactor.options,
+			)
+		}
+	})
+
+	t.Run("dynamic fees", func(t *testing.T) {
+		fees := &transactionFees{
+			gasFeeCap: big.NewInt(200),
+			gasTipCap: big.NewInt(2),
+		}
+
+		transactor, err := keep.keepTransactor(350000, fees)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		dynamicFeeTransactor, ok := transactor.(*dynamicFeeKeepTransactor)
+		if !ok {
+			t.Fatalf("unexpected transactor type: [%T]", transactor)
+		}
+
+		if dynamicFeeTransactor.submitter != submitter {
+			t.Errorf("dynamic fee submitter of the chain should be used")
+		}
+		if dynamicFeeTransactor.miningWaiter != miningWaiter {
+			t.Errorf("shared mining waiter should be used")
+		}
+		if dynamicFeeTransactor.gasLimit != 350000 {
+			t.Errorf(
+				"unexpected gas limit\n"+
+					"expected: [%v]\n"+
+					"actual:   [%v]",
+				350000,
+				dynamicFeeTransactor.gasLimit,
+			)
+		}
+		if dynamicFeeTransactor.fees != fees {
+			t.Errorf(
+				"unexpected transaction fees\n"+
+					"expected: [%+v]\n"+
+					"actual:   [%+v]",
+				fees,
+				dynamicFeeTransactor.fees,
+			)
+		}
+	})
+}
+
+func TestKeepTransactor_DynamicFeeSubmitterNotConfigured(t *testing.T) {
+	keep := &bondedEcdsaKeepHandle{}
+
+	_, err := keep.keepTransactor(
+		350000,
+		&transactionFees{
+			gasFeeCap: big.NewInt(200),
+			gasTipCap: big.NewInt(2),
+		},
+	)
+	if err == nil {
+		t.Errorf("expected error")
+	}
+}
+
+func TestSubmittedTransactionType(t *testing.T) {
+	var tests = map[string]struct {
+		baseFee           *big.Int
+		fees              *transactionFees
+		expectedType      uint8
+		expectedGasPrice  *big.Int
+		expectedGasFeeCap *big.Int
+		expectedGasTipCap *big.Int
+	}{
+		"legacy fees": {
+			baseFee:           nil,
+			fees:              &transactionFees{gasPrice: big.NewInt(150)},
+			expectedType:      types.LegacyTxType,
+			expectedGasPrice:  big.NewInt(150),
+			expectedGasFeeCap: big.NewInt(150),
+			expectedGasTipCap: big.NewInt(150),
+		},
+		"dynamic fees": {
+			baseFee: big.NewInt(100),
+			fees: &transactionFees{
+				gasFeeCap: big.NewInt(200),
+				gasTipCap: big.NewInt(2),
+			},
+			expectedType:      types.DynamicFeeTxType,
+			expectedGasPrice:  big.NewInt(200),
+			expectedGasFeeCap: big.NewInt(200),
+			expectedGasTipCap: big.NewInt(2),
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			privateKey, err := crypto.GenerateKey()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			options, err := bind.NewKeyedTransactorWithChainID(
+				privateKey,
+				big.NewInt(1),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			options.Nonce = big.NewInt(5)
+			options.GasLimit = 350000
+			test.fees.apply(options)
+
+			backend := &contractTransactorStub{baseFee: test.baseFee}
+
+			transactor, err := abi.NewBondedECDSAKeepTransactor(
+				common.HexToAddress("0x1"),
+				backend,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := transactor.SubmitPublicKey(
+				options,
+				[]byte{0x1},
+			); err != nil {
+				t.Fatal(err)
+			}
+
+			transaction := backend.sentTransaction
+
+			if transaction.Type() != test.expectedType {
+				t.Errorf(
+					"unexpected transaction type\n"+
+						"expected: [%v]\n"+
+						"actual:   [%v]",
+					test.expectedType,
+					transaction.Type(),
+				)
+			}
+			if transaction.GasPrice().Cmp(test.expectedGasPrice) != 0 {
+				t.Errorf(
+					"unexpected gas price\n"+
+						"expected: [%v]\n"+
+						"actual:   [%v]",
+					test.expectedGasPrice,
+					transaction.GasPrice(),
+				)
+			}
+			if transaction.GasFeeCap().Cmp(test.expectedGasFeeCap) != 0 {
+				t.Errorf(
+					"unexpected gas fee cap\n"+
+						"expected: [%v]\n"+
+						"actual:   [%v]",
+					test.expectedGasFeeCap,
+					transaction.GasFeeCap(),
+				)
+			}
+			if transaction.GasTipCap().Cmp(test.expectedGasTipCap) != 0 {
+				t.Errorf(
+					"unexpected gas tip cap\n"+
+						"expected: [%v]\n"+
+						"actual:   [%v]",
+					test.expectedGasTipCap,
+					transaction.GasTipCap(),
+				)
+			}
+		})
+	}
+}
+
+func TestResubmissionGasTipCap(t *testing.T) {
+	// Fee cap increased by 20%.
+	gasTipCap := resubmissionGasTipCap(
+		big.NewInt(10),
+		big.NewInt(200),
+		big.NewInt(240),
+	)
+
+	expectedGasTipCap := big.NewInt(12)
+	if gasTipCap.Cmp(expectedGasTipCap) != 0 {
+		t.Errorf(
+			"unexpected gas tip cap\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedGasTipCap,
+			gasTipCap,
+		)
+	}
+}
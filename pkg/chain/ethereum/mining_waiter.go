@@ -0,0 +1,83 @@
+//+build !celo
+
+package ethereum
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/keep-network/keep-common/pkg/chain/ethlike"
+
+	"github.com/keep-network/keep-ecdsa/pkg/chain/gen/ethereum/contract"
+)
+
+// resubmissionGasPriceIncrease is the percentage the mining waiter increases
+// the gas price by with each resubmission of a transaction.
+const resubmissionGasPriceIncrease = 20
+
+// submissionMiningWaiter determines how transactions submitted to keep
+// contracts are waited for to be mined. A transaction which is not mined
+// within the check interval is resubmitted with a higher gas price, at most
+// maxResubmissions times and never above maxGasPrice.
+type submissionMiningWaiter struct {
+	checkInterval    time.Duration
+	maxResubmissions int
+	maxGasPrice      *big.Int
+
+	newMiningWaiter func(
+		checkInterval time.Duration,
+		maxGasPrice *big.Int,
+	) *ethlike.MiningWaiter
+}
+
+// resubmissionGasPriceCeiling returns the gas price a transaction submitted
+// with the given gas price reaches after the maximum number of resubmissions,
+// capped at the maximum gas price. The mining waiter stops resubmitting the
+// transaction once its gas price reaches this ceiling.
+func (smw *submissionMiningWaiter) resubmissionGasPriceCeiling(
+	gasPrice *big.Int,
+) *big.Int {
+	ceiling := new(big.Int).Set(gasPrice)
+	for i := 0; i < smw.maxResubmissions; i++ {
+		ceiling.Mul(ceiling, big.NewInt(100+resubmissionGasPriceIncrease))
+		ceiling.Div(ceiling, big.NewInt(100))
+	}
+
+	if smw.maxGasPrice != nil && ceiling.Cmp(smw.maxGasPrice) > 0 {
+		return new(big.Int).Set(smw.maxGasPrice)
+	}
+
+	return ceiling
+}
+
+// submissionContract returns the keep contract binding a legacy transaction
+// with the given gas price should be submitted with. If the submission mining
+// waiter is configured, the binding uses a mining waiter bounding the
+// resubmissions of the transaction; otherwise, the shared keep contract
+// binding is returned.
+func (bekh *bondedEcdsaKeepHandle) submissionContract(
+	gasPrice *big.Int,
+) (*contract.BondedECDSAKeep, error) {
+	if bekh.submissionMiningWaiter == nil {
+		return bekh.contract, nil
+	}
+
+	return bekh.newContract(bekh.resubmissionMiningWaiter(gasPrice))
+}
+
+// resubmissionMiningWaiter returns the mining waiter bounding the
+// resubmissions of a transaction submitted with the given gas price. If the
+// submission mining waiter is not configured, the mining waiter shared by
+// all contracts is returned.
+func (bekh *bondedEcdsaKeepHandle) resubmissionMiningWaiter(
+	gasPrice *big.Int,
+) *ethlike.MiningWaiter {
+	if bekh.submissionMiningWaiter == nil {
+		return bekh.miningWaiter
+	}
+
+	return bekh.submissionMiningWaiter.newMiningWaiter(
+		bekh.submissionMiningWaiter.checkInterval,
+		bekh.submissionMiningWaiter.resubmissionGasPriceCeiling(gasPrice),
+	)
+}
@@ -0,0 +1,128 @@
+//+build !celo
+
+package ethereum
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-common/pkg/chain/ethlike"
+	"github.com/keep-network/keep-ecdsa/pkg/chain/gen/ethereum/contract"
+)
+
+func TestSubmissionContract_PassesConfigurationToMiningWaiter(t *testing.T) {
+	var (
+		actualCheckInterval time.Duration
+		actualMaxGasPrice   *big.Int
+	)
+
+	checkInterval := 3 * time.Minute
+
+	keep := &bondedEcdsaKeepHandle{
+		submissionMiningWaiter: &submissionMiningWaiter{
+			checkInterval:    checkInterval,
+			maxResubmissions: 2,
+			maxGasPrice:      big.NewInt(1000),
+			newMiningWaiter: func(
+				checkInterval time.Duration,
+				maxGasPrice *big.Int,
+			) *ethlike.MiningWaiter {
+				actualCheckInterval = checkInterval
+				actualMaxGasPrice = maxGasPrice
+				return nil
+			},
+		},
+		newContract: func(
+			miningWaiter *ethlike.MiningWaiter,
+		) (*contract.BondedECDSAKeep, error) {
+			return &contract.BondedECDSAKeep{}, nil
+		},
+	}
+
+	_, err := keep.submissionContract(big.NewInt(100))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if actualCheckInterval != checkInterval {
+		t.Errorf(
+			"unexpected check interval\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			checkInterval,
+			actualCheckInterval,
+		)
+	}
+
+	// 100 wei increased by 20% twice.
+	expectedMaxGasPrice := big.NewInt(144)
+	if actualMaxGasPrice.Cmp(expectedMaxGasPrice) != 0 {
+		t.Errorf(
+			"unexpected max gas price\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedMaxGasPrice,
+			actualMaxGasPrice,
+		)
+	}
+}
+
+func TestSubmissionContract_NotConfigured(t *testing.T) {
+	sharedContract := &contract.BondedECDSAKeep{}
+
+	keep := &bondedEcdsaKeepHandle{contract: sharedContract}
+
+	submissionContract, err := keep.submissionContract(big.NewInt(100))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if submissionContract != sharedContract {
+		t.Errorf("shared keep contract should be used")
+	}
+}
+
+func TestResubmissionGasPriceCeiling(t *testing.T) {
+	var tests = map[string]struct {
+		maxResubmissions int
+		maxGasPrice      *big.Int
+		expectedCeiling  *big.Int
+	}{
+		"no resubmissions": {
+			maxResubmissions: 0,
+			maxGasPrice:      big.NewInt(1000),
+			expectedCeiling:  big.NewInt(100),
+		},
+		"ceiling below max gas price": {
+			maxResubmissions: 3,
+			maxGasPrice:      big.NewInt(1000),
+			expectedCeiling:  big.NewInt(172),
+		},
+		"ceiling capped at max gas price": {
+			maxResubmissions: 10,
+			maxGasPrice:      big.NewInt(300),
+			expectedCeiling:  big.NewInt(300),
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			waiter := &submissionMiningWaiter{
+				maxResubmissions: test.maxResubmissions,
+				maxGasPrice:      test.maxGasPrice,
+			}
+
+			ceiling := waiter.resubmissionGasPriceCeiling(big.NewInt(100))
+			if ceiling.Cmp(test.expectedCeiling) != 0 {
+				t.Errorf(
+					"unexpected gas price ceiling\n"+
+						"expected: [%v]\n"+
+						"actual:   [%v]",
+					test.expectedCeiling,
+					ceiling,
+				)
+			}
+		})
+	}
+}
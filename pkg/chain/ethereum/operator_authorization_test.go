@@ -0,0 +1,65 @@
+//+build !celo
+
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestWatchDeauthorization(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	type check struct {
+		authorized bool
+		err        error
+	}
+
+	checks := []check{
+		{authorized: false},
+		{authorized: true},
+		{authorized: true},
+		{err: fmt.Errorf("connection refused")},
+		{authorized: false},
+		{authorized: false},
+		{authorized: true},
+		{authorized: false},
+	}
+
+	checkIndex := 0
+	isAuthorized := func() (bool, error) {
+		if checkIndex >= len(checks) {
+			cancelCtx()
+			return false, nil
+		}
+
+		check := checks[checkIndex]
+		checkIndex++
+
+		return check.authorized, check.err
+	}
+
+	deauthorizations := 0
+	watchDeauthorization(
+		ctx,
+		isAuthorized,
+		time.Millisecond,
+		func() {
+			deauthorizations++
+		},
+	)
+
+	expectedDeauthorizations := 2
+	if expectedDeauthorizations != deauthorizations {
+		t.Errorf(
+			"unexpected number of deauthorizations\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedDeauthorizations,
+			deauthorizations,
+		)
+	}
+}
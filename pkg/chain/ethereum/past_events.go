@@ -0,0 +1,136 @@
+//+build !celo
+
+package ethereum
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// blockRange is a range of blocks, inclusive on both ends.
+type blockRange struct {
+	start uint64
+	end   uint64
+}
+
+func (br blockRange) String() string {
+	return fmt.Sprintf("%d-%d", br.start, br.end)
+}
+
+// splitBlockRange splits the range between the given start and end blocks,
+// both inclusive, into consecutive ranges having at most chunkSize blocks.
+func splitBlockRange(startBlock, endBlock, chunkSize uint64) []blockRange {
+	ranges := make([]blockRange, 0)
+
+	for start := startBlock; start <= endBlock; start += chunkSize {
+		end := start + chunkSize - 1
+		if end > endBlock || end < start {
+			end = endBlock
+		}
+
+		ranges = append(ranges, blockRange{start, end})
+
+		if end == endBlock {
+			break
+		}
+	}
+
+	return ranges
+}
+
+// fetchInChunks calls fetch for consecutive block ranges having at most
+// chunkSize blocks and covering all blocks between the given start and end
+// blocks, both inclusive. At most concurrency fetches are executed at the
+// same time. If fetching of any range fails, an error listing all failed
+// ranges is returned.
+func fetchInChunks(
+	startBlock uint64,
+	endBlock uint64,
+	chunkSize uint64,
+	concurrency int,
+	fetch func(startBlock, endBlock uint64) error,
+) error {
+	if chunkSize == 0 {
+		return fmt.Errorf("chunk size must be greater than zero")
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	ranges := splitBlockRange(startBlock, endBlock, chunkSize)
+	errs := make([]error, len(ranges))
+
+	semaphore := make(chan struct{}, concurrency)
+	wg := &sync.WaitGroup{}
+	wg.Add(len(ranges))
+
+	for i, blocks := range ranges {
+		semaphore <- struct{}{}
+
+		go func(i int, blocks blockRange) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+
+			errs[i] = fetch(blocks.start, blocks.end)
+		}(i, blocks)
+	}
+
+	wg.Wait()
+
+	failures := make([]string, 0)
+	for i, err := range errs {
+		if err != nil {
+			failures = append(
+				failures,
+				fmt.Sprintf("blocks [%v]: [%v]", ranges[i], err),
+			)
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf(
+			"failed to fetch [%d] of [%d] block ranges: %s",
+			len(failures),
+			len(ranges),
+			strings.Join(failures, "; "),
+		)
+	}
+
+	return nil
+}
+
+// fetchPastEvents calls fetch for blocks starting from the given start block
+// up to the latest block. If past events chunking is enabled, blocks are
+// split into ranges of the configured size fetched separately; otherwise,
+// fetch is called once with the end block set to nil, meaning the latest
+// block. fetch may be called concurrently.
+func (bekh *bondedEcdsaKeepHandle) fetchPastEvents(
+	startBlock uint64,
+	fetch func(startBlock uint64, endBlock *uint64) error,
+) error {
+	if bekh.pastEventsChunkSize == 0 {
+		return fetch(startBlock, nil)
+	}
+
+	currentBlock, err := bekh.blockCounter.CurrentBlock()
+	if err != nil {
+		return fmt.Errorf("failed to get current block: [%v]", err)
+	}
+
+	if startBlock > currentBlock {
+		return nil
+	}
+
+	return fetchInChunks(
+		startBlock,
+		currentBlock,
+		bekh.pastEventsChunkSize,
+		bekh.pastEventsConcurrency,
+		func(startBlock, endBlock uint64) error {
+			return fetch(startBlock, &endBlock)
+		},
+	)
+}
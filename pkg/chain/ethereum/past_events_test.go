@@ -0,0 +1,232 @@
+//+build !celo
+
+package ethereum
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// logsBackendStub serves events emitted in every block and rejects requests
+// for more than maxRange blocks, like Ethereum nodes limiting the block range
+// of eth_getLogs calls.
+type logsBackendStub struct {
+	maxRange uint64
+
+	mutex sync.Mutex
+	calls int
+}
+
+func (lbs *logsBackendStub) fetch(startBlock, endBlock uint64) ([]uint64, error) {
+	lbs.mutex.Lock()
+	lbs.calls++
+	lbs.mutex.Unlock()
+
+	if endBlock-startBlock+1 > lbs.maxRange {
+		return nil, fmt.Errorf(
+			"block range [%d-%d] exceeds the limit",
+			startBlock,
+			endBlock,
+		)
+	}
+
+	events := make([]uint64, 0)
+	for block := startBlock; block <= endBlock; block++ {
+		events = append(events, block)
+	}
+
+	return events, nil
+}
+
+func TestFetchInChunks(t *testing.T) {
+	var tests = map[string]struct {
+		chunkSize     uint64
+		concurrency   int
+		expectedCalls int
+	}{
+		"sequential fetching": {
+			chunkSize:     10,
+			concurrency:   1,
+			expectedCalls: 6,
+		},
+		"concurrent fetching": {
+			chunkSize:     10,
+			concurrency:   3,
+			expectedCalls: 6,
+		},
+		"chunk size smaller than the limit": {
+			chunkSize:     4,
+			concurrency:   2,
+			expectedCalls: 13,
+		},
+	}
+
+	startBlock := uint64(100)
+	endBlock := uint64(150)
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			backend := &logsBackendStub{maxRange: 10}
+
+			eventsMutex := &sync.Mutex{}
+			events := make([]uint64, 0)
+
+			err := fetchInChunks(
+				startBlock,
+				endBlock,
+				test.chunkSize,
+				test.concurrency,
+				func(startBlock, endBlock uint64) error {
+					chunkEvents, err := backend.fetch(startBlock, endBlock)
+					if err != nil {
+						return err
+					}
+
+					eventsMutex.Lock()
+					events = append(events, chunkEvents...)
+					eventsMutex.Unlock()
+
+					return nil
+				},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sort.Slice(events, func(i, j int) bool {
+				return events[i] < events[j]
+			})
+
+			expectedEvents := make([]uint64, 0)
+			for block := startBlock; block <= endBlock; block++ {
+				expectedEvents = append(expectedEvents, block)
+			}
+
+			if !reflect.DeepEqual(expectedEvents, events) {
+				t.Errorf(
+					"unexpected events\n"+
+						"expected: [%v]\n"+
+						"actual:   [%v]",
+					expectedEvents,
+					events,
+				)
+			}
+
+			if backend.calls != test.expectedCalls {
+				t.Errorf(
+					"unexpected number of calls\n"+
+						"expected: [%v]\n"+
+						"actual:   [%v]",
+					test.expectedCalls,
+					backend.calls,
+				)
+			}
+		})
+	}
+}
+
+func TestFetchInChunks_PartialFailure(t *testing.T) {
+	err := fetchInChunks(
+		0,
+		29,
+		10,
+		2,
+		func(startBlock, endBlock uint64) error {
+			if startBlock == 10 {
+				return fmt.Errorf("node unavailable")
+			}
+			return nil
+		},
+	)
+	if err == nil {
+		t.Fatal("expected error for failed block range")
+	}
+
+	expectedError := "failed to fetch [1] of [3] block ranges: " +
+		"blocks [10-19]: [node unavailable]"
+	if err.Error() != expectedError {
+		t.Errorf(
+			"unexpected error\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedError,
+			err,
+		)
+	}
+}
+
+func TestFetchInChunks_RangeTooLarge(t *testing.T) {
+	backend := &logsBackendStub{maxRange: 10}
+
+	err := fetchInChunks(
+		0,
+		29,
+		30,
+		1,
+		func(startBlock, endBlock uint64) error {
+			_, err := backend.fetch(startBlock, endBlock)
+			return err
+		},
+	)
+	if err == nil || !strings.Contains(err.Error(), "exceeds the limit") {
+		t.Errorf("expected error for block range exceeding the limit")
+	}
+}
+
+func TestSplitBlockRange(t *testing.T) {
+	var tests = map[string]struct {
+		startBlock     uint64
+		endBlock       uint64
+		chunkSize      uint64
+		expectedRanges []blockRange
+	}{
+		"range divisible by chunk size": {
+			startBlock:     0,
+			endBlock:       9,
+			chunkSize:      5,
+			expectedRanges: []blockRange{{0, 4}, {5, 9}},
+		},
+		"range not divisible by chunk size": {
+			startBlock:     3,
+			endBlock:       10,
+			chunkSize:      5,
+			expectedRanges: []blockRange{{3, 7}, {8, 10}},
+		},
+		"single block": {
+			startBlock:     7,
+			endBlock:       7,
+			chunkSize:      5,
+			expectedRanges: []blockRange{{7, 7}},
+		},
+		"chunk size larger than range": {
+			startBlock:     1,
+			endBlock:       3,
+			chunkSize:      100,
+			expectedRanges: []blockRange{{1, 3}},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			ranges := splitBlockRange(
+				test.startBlock,
+				test.endBlock,
+				test.chunkSize,
+			)
+
+			if !reflect.DeepEqual(test.expectedRanges, ranges) {
+				t.Errorf(
+					"unexpected ranges\n"+
+						"expected: [%v]\n"+
+						"actual:   [%v]",
+					test.expectedRanges,
+					ranges,
+				)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+//+build !celo
+
+package ethereum
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+
+	"github.com/keep-network/keep-ecdsa/pkg/chain"
+)
+
+// withReadRetry executes the read-only chain call fn until it succeeds, the
+// maximum number of attempts is reached, or fn returns an error which is not
+// going to change when retried. The delay between consecutive attempts starts
+// at the given initial backoff and doubles after each attempt. It returns the
+// context error if the context is done while waiting for the next attempt.
+func withReadRetry(
+	ctx context.Context,
+	maxAttempts int,
+	initialBackoff time.Duration,
+	fn func() error,
+) error {
+	backoff := initialBackoff
+
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+
+		if attempt >= maxAttempts || isPermanentReadError(err) {
+			return err
+		}
+
+		logger.Warningf(
+			"read-only call failed on attempt [%v]; retrying in [%v]: [%v]",
+			attempt,
+			backoff,
+			err,
+		)
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		backoff *= 2
+	}
+}
+
+// isPermanentReadError determines whether the error returned by a read-only
+// chain call is not going to change when the call is retried.
+func isPermanentReadError(err error) bool {
+	return errors.Is(err, chain.ErrKeepNotFound) ||
+		errors.Is(err, bind.ErrNoCode) ||
+		errors.Is(err, context.Canceled) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		strings.Contains(err.Error(), "execution reverted")
+}
@@ -0,0 +1,161 @@
+//+build !celo
+
+package ethereum
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-ecdsa/pkg/chain"
+)
+
+func TestWithReadRetry_SucceedsAfterTransientFailures(t *testing.T) {
+	attempts := 0
+	getMembers := func() ([]string, error) {
+		attempts++
+		if attempts <= 2 {
+			return nil, fmt.Errorf("connection reset by peer")
+		}
+		return []string{"member-1", "member-2"}, nil
+	}
+
+	var members []string
+	err := withReadRetry(
+		context.Background(),
+		5,
+		time.Millisecond,
+		func() (err error) {
+			members, err = getMembers()
+			return
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedMembers := 2
+	if len(members) != expectedMembers {
+		t.Errorf(
+			"unexpected number of members\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedMembers,
+			len(members),
+		)
+	}
+
+	expectedAttempts := 3
+	if expectedAttempts != attempts {
+		t.Errorf(
+			"unexpected number of attempts\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedAttempts,
+			attempts,
+		)
+	}
+}
+
+func TestWithReadRetry_GivesUpAfterMaxAttempts(t *testing.T) {
+	attempts := 0
+	err := withReadRetry(
+		context.Background(),
+		3,
+		time.Millisecond,
+		func() error {
+			attempts++
+			return fmt.Errorf("connection reset by peer")
+		},
+	)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	expectedAttempts := 3
+	if expectedAttempts != attempts {
+		t.Errorf(
+			"unexpected number of attempts\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedAttempts,
+			attempts,
+		)
+	}
+}
+
+func TestWithReadRetry_DoesNotRetryPermanentErrors(t *testing.T) {
+	var tests = map[string]struct {
+		err error
+	}{
+		"keep not found": {
+			err: fmt.Errorf("no keep with address: [%w]", chain.ErrKeepNotFound),
+		},
+		"reverted call": {
+			err: fmt.Errorf("execution reverted"),
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			attempts := 0
+			err := withReadRetry(
+				context.Background(),
+				5,
+				time.Millisecond,
+				func() error {
+					attempts++
+					return test.err
+				},
+			)
+			if err != test.err {
+				t.Errorf(
+					"unexpected error\n"+
+						"expected: [%v]\n"+
+						"actual:   [%v]",
+					test.err,
+					err,
+				)
+			}
+
+			expectedAttempts := 1
+			if expectedAttempts != attempts {
+				t.Errorf(
+					"unexpected number of attempts\n"+
+						"expected: [%v]\n"+
+						"actual:   [%v]",
+					expectedAttempts,
+					attempts,
+				)
+			}
+		})
+	}
+}
+
+func TestWithReadRetry_ContextDoneDuringBackoff(t *testing.T) {
+	ctx, cancelCtx := context.WithTimeout(
+		context.Background(),
+		10*time.Millisecond,
+	)
+	defer cancelCtx()
+
+	err := withReadRetry(
+		ctx,
+		5,
+		time.Minute,
+		func() error {
+			return fmt.Errorf("connection reset by peer")
+		},
+	)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf(
+			"unexpected error\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			context.DeadlineExceeded,
+			err,
+		)
+	}
+}
@@ -0,0 +1,61 @@
+//+build !celo
+
+package ethereum
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/keep-network/keep-ecdsa/pkg/ecdsa"
+)
+
+// RecoverAddress recovers the address of the account which produced the given
+// signature over the given digest. Only recovery IDs 0 and 1 are supported;
+// IDs 2 and 3 are practically never produced for the secp256k1 curve.
+func RecoverAddress(
+	digest [32]byte,
+	signature *ecdsa.Signature,
+) (common.Address, error) {
+	if signature == nil || signature.R == nil || signature.S == nil {
+		return common.Address{}, fmt.Errorf("incomplete signature")
+	}
+
+	if signature.RecoveryID < 0 || signature.RecoveryID > 1 {
+		return common.Address{}, fmt.Errorf(
+			"recovery id [%v] out of range [0, 1]",
+			signature.RecoveryID,
+		)
+	}
+
+	if signature.R.BitLen() > 256 || signature.S.BitLen() > 256 {
+		return common.Address{}, fmt.Errorf(
+			"signature values longer than 32 bytes",
+		)
+	}
+
+	serializedSignature := make([]byte, 0, crypto.SignatureLength)
+	serializedSignature = append(
+		serializedSignature,
+		common.LeftPadBytes(signature.R.Bytes(), 32)...,
+	)
+	serializedSignature = append(
+		serializedSignature,
+		common.LeftPadBytes(signature.S.Bytes(), 32)...,
+	)
+	serializedSignature = append(
+		serializedSignature,
+		byte(signature.RecoveryID),
+	)
+
+	publicKey, err := crypto.SigToPub(digest[:], serializedSignature)
+	if err != nil {
+		return common.Address{}, fmt.Errorf(
+			"could not recover public key: [%v]",
+			err,
+		)
+	}
+
+	return crypto.PubkeyToAddress(*publicKey), nil
+}
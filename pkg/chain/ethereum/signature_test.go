@@ -0,0 +1,67 @@
+//+build !celo
+
+package ethereum
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/keep-network/keep-ecdsa/pkg/ecdsa"
+)
+
+func TestRecoverAddress(t *testing.T) {
+	privateKey, err := crypto.HexToECDSA(
+		"289c2857d4598e37fb9647507e47a309d6133539bf21a8b9cb6df88fd5232032",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedAddress := common.HexToAddress(
+		"0x970E8128AB834E8EAC17Ab8E3812F010678CF791",
+	)
+
+	digest := [32]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	serializedSignature, err := crypto.Sign(digest[:], privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signature := &ecdsa.Signature{
+		R:          new(big.Int).SetBytes(serializedSignature[:32]),
+		S:          new(big.Int).SetBytes(serializedSignature[32:64]),
+		RecoveryID: int(serializedSignature[64]),
+	}
+
+	address, err := RecoverAddress(digest, signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if address != expectedAddress {
+		t.Errorf(
+			"unexpected address\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedAddress.Hex(),
+			address.Hex(),
+		)
+	}
+}
+
+func TestRecoverAddress_InvalidRecoveryID(t *testing.T) {
+	signature := &ecdsa.Signature{
+		R:          big.NewInt(1),
+		S:          big.NewInt(1),
+		RecoveryID: 2,
+	}
+
+	_, err := RecoverAddress([32]byte{1}, signature)
+	if err == nil {
+		t.Errorf("expected error for recovery id out of range")
+	}
+}
@@ -0,0 +1,163 @@
+//+build !celo
+
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/keep-network/keep-common/pkg/metrics"
+)
+
+// Names of the operations whose transaction submissions are measured by
+// TransactionMetrics.
+const (
+	SubmitKeepPublicKeyOperation       = "submit_keep_public_key"
+	SubmitSignatureOperation           = "submit_signature"
+	RegisterAsMemberCandidateOperation = "register_as_member_candidate"
+)
+
+// TransactionOperations lists all operations measured by TransactionMetrics.
+var TransactionOperations = []string{
+	SubmitKeepPublicKeyOperation,
+	SubmitSignatureOperation,
+	RegisterAsMemberCandidateOperation,
+}
+
+// TransactionStats is a snapshot of the transaction metrics collected for
+// a single operation.
+type TransactionStats struct {
+	// Submitted is the number of submission attempts, failed ones included.
+	Submitted uint64
+	// Failed is the number of submission attempts which returned an error.
+	Failed uint64
+	// TotalLatency is the total time spent on all submission attempts.
+	TotalLatency time.Duration
+}
+
+// AverageLatency returns the average time spent on a submission attempt.
+func (ts TransactionStats) AverageLatency() time.Duration {
+	if ts.Submitted == 0 {
+		return 0
+	}
+
+	return ts.TotalLatency / time.Duration(ts.Submitted)
+}
+
+// TransactionMetrics collects counts, failures, and latencies of transactions
+// submitted to the chain. A nil TransactionMetrics is a valid no-op collector.
+type TransactionMetrics struct {
+	mutex      sync.Mutex
+	operations map[string]*TransactionStats
+}
+
+// TransactionMetricsSource is implemented by chain handles collecting metrics
+// of transactions submitted by the client.
+type TransactionMetricsSource interface {
+	// TransactionMetrics returns the collector of metrics of transactions
+	// submitted by the client.
+	TransactionMetrics() *TransactionMetrics
+}
+
+// TransactionMetrics returns the collector of metrics of transactions
+// submitted by the client. It returns nil if transaction metrics are not
+// collected.
+func (ec *ethereumChain) TransactionMetrics() *TransactionMetrics {
+	return ec.transactionMetrics
+}
+
+// NewTransactionMetrics creates a new, empty transaction metrics collector.
+func NewTransactionMetrics() *TransactionMetrics {
+	return &TransactionMetrics{
+		operations: make(map[string]*TransactionStats),
+	}
+}
+
+// record records a submission attempt of the given operation started at the
+// given time and completed with the given error.
+func (tm *TransactionMetrics) record(
+	operation string,
+	startTime time.Time,
+	err error,
+) {
+	if tm == nil {
+		return
+	}
+
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+
+	stats, ok := tm.operations[operation]
+	if !ok {
+		stats = &TransactionStats{}
+		tm.operations[operation] = stats
+	}
+
+	stats.Submitted++
+	stats.TotalLatency += time.Since(startTime)
+	if err != nil {
+		stats.Failed++
+	}
+}
+
+// Stats returns a snapshot of the metrics collected for the given operation.
+func (tm *TransactionMetrics) Stats(operation string) TransactionStats {
+	if tm == nil {
+		return TransactionStats{}
+	}
+
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+
+	stats, ok := tm.operations[operation]
+	if !ok {
+		return TransactionStats{}
+	}
+
+	return *stats
+}
+
+// Observe exposes the collected metrics in the given registry, under
+// `eth_<operation>_transactions_submitted`,
+// `eth_<operation>_transactions_failed`, and
+// `eth_<operation>_transactions_latency_seconds` gauges, refreshed with
+// the given tick. The latency gauge holds the average latency of a submission.
+func (tm *TransactionMetrics) Observe(
+	ctx context.Context,
+	registry *metrics.Registry,
+	tick time.Duration,
+) {
+	if tm == nil {
+		return
+	}
+
+	for _, operation := range TransactionOperations {
+		operation := operation
+
+		inputs := map[string]metrics.ObserverInput{
+			"submitted": func() float64 {
+				return float64(tm.Stats(operation).Submitted)
+			},
+			"failed": func() float64 {
+				return float64(tm.Stats(operation).Failed)
+			},
+			"latency_seconds": func() float64 {
+				return tm.Stats(operation).AverageLatency().Seconds()
+			},
+		}
+
+		for suffix, input := range inputs {
+			name := fmt.Sprintf("eth_%s_transactions_%s", operation, suffix)
+
+			observer, err := registry.NewGaugeObserver(name, input)
+			if err != nil {
+				logger.Warningf("could not create gauge observer [%v]", name)
+				continue
+			}
+
+			observer.Observe(ctx, tick)
+		}
+	}
+}
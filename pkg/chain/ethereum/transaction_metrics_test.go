@@ -0,0 +1,88 @@
+//+build !celo
+
+package ethereum
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestTransactionMetrics_Record(t *testing.T) {
+	transactionMetrics := NewTransactionMetrics()
+
+	startTime := time.Now().Add(-2 * time.Second)
+	transactionMetrics.record(SubmitSignatureOperation, startTime, nil)
+	transactionMetrics.record(
+		SubmitSignatureOperation,
+		startTime,
+		fmt.Errorf("nonce too low"),
+	)
+
+	stats := transactionMetrics.Stats(SubmitSignatureOperation)
+
+	expectedSubmitted := uint64(2)
+	if stats.Submitted != expectedSubmitted {
+		t.Errorf(
+			"unexpected number of submitted transactions\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedSubmitted,
+			stats.Submitted,
+		)
+	}
+
+	expectedFailed := uint64(1)
+	if stats.Failed != expectedFailed {
+		t.Errorf(
+			"unexpected number of failed transactions\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedFailed,
+			stats.Failed,
+		)
+	}
+
+	minLatency := 2 * time.Second
+	if stats.AverageLatency() < minLatency {
+		t.Errorf(
+			"unexpected average latency\n"+
+				"expected: [>= %v]\n"+
+				"actual:   [%v]",
+			minLatency,
+			stats.AverageLatency(),
+		)
+	}
+
+	otherStats := transactionMetrics.Stats(SubmitKeepPublicKeyOperation)
+	if otherStats != (TransactionStats{}) {
+		t.Errorf(
+			"unexpected stats of other operation\n"+
+				"expected: [%+v]\n"+
+				"actual:   [%+v]",
+			TransactionStats{},
+			otherStats,
+		)
+	}
+}
+
+func TestTransactionMetrics_Nil(t *testing.T) {
+	var transactionMetrics *TransactionMetrics
+
+	transactionMetrics.record(
+		RegisterAsMemberCandidateOperation,
+		time.Now(),
+		nil,
+	)
+
+	stats := transactionMetrics.Stats(RegisterAsMemberCandidateOperation)
+	if stats != (TransactionStats{}) {
+		t.Errorf(
+			"unexpected stats\n"+
+				"expected: [%+v]\n"+
+				"actual:   [%+v]",
+			TransactionStats{},
+			stats,
+		)
+	}
+}
@@ -1,9 +1,13 @@
 package chain
 
+import "math/big"
+
 // BondedECDSAKeepCreatedEvent is an event emitted on a new keep creation.
 type BondedECDSAKeepCreatedEvent struct {
 	Keep                 BondedECDSAKeepHandle
 	MemberIDs            []ID // keep member ids
+	OwnerID              ID   // keep owner id
+	ApplicationID        ID   // id of the application the keep is created for
 	HonestThreshold      uint64
 	BlockNumber          uint64
 	ThisOperatorIsMember bool
@@ -42,6 +46,15 @@ type KeepTerminatedEvent struct {
 	BlockNumber uint64
 }
 
+// BondSeizedEvent is an event emitted when a bond of a keep member has been
+// seized.
+type BondSeizedEvent struct {
+	KeepID      ID
+	Operator    ID
+	Amount      *big.Int
+	BlockNumber uint64
+}
+
 // SignatureSubmittedEvent is an event emitted when a keep submits a signature.
 type SignatureSubmittedEvent struct {
 	Digest      [32]byte
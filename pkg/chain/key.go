@@ -2,6 +2,7 @@ package chain
 
 import (
 	cecdsa "crypto/ecdsa"
+	"fmt"
 
 	"github.com/keep-network/keep-ecdsa/pkg/utils/byteutils"
 )
@@ -28,3 +29,32 @@ func SerializePublicKey(publicKey *cecdsa.PublicKey) ([64]byte, error) {
 
 	return serialized, nil
 }
+
+// ValidatePublicKey checks whether the public key can be submitted to a keep.
+// It returns an error if the key is all-zero, which the keep contract would
+// reject.
+func ValidatePublicKey(publicKey [64]byte) error {
+	if publicKey == [64]byte{} {
+		return fmt.Errorf("public key is all-zero")
+	}
+
+	return nil
+}
+
+// ValidatePublicKeySubmitter checks whether the operator can submit a public
+// key to the keep with the given members. It returns an error if the operator
+// is not a member of the keep, in which case the keep contract would reject
+// the submission.
+func ValidatePublicKeySubmitter(operatorID ID, memberIDs []ID) error {
+	for _, memberID := range memberIDs {
+		if memberID.String() == operatorID.String() {
+			return nil
+		}
+	}
+
+	return fmt.Errorf(
+		"operator [%v] is not a member of the keep; members: %v",
+		operatorID,
+		memberIDs,
+	)
+}
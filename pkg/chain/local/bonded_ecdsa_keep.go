@@ -1,11 +1,14 @@
 package local
 
 import (
+	"bytes"
 	"fmt"
 	"math/big"
+	"sort"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/keep-network/keep-common/pkg/subscription"
 	"github.com/keep-network/keep-ecdsa/pkg/chain"
 	"github.com/keep-network/keep-ecdsa/pkg/ecdsa"
@@ -21,21 +24,35 @@ const (
 )
 
 type localKeep struct {
-	chain  *localChain
-	keepID common.Address
-	owner  common.Address
-
-	publicKey    [64]byte
-	members      []common.Address
-	status       keepStatus
-	latestDigest [32]byte
+	chain       *localChain
+	keepID      common.Address
+	owner       common.Address
+	application common.Address
+
+	publicKey       [64]byte
+	members         []common.Address
+	honestThreshold uint64
+	status          keepStatus
+	latestDigest    [32]byte
+	openedTimestamp time.Time
+
+	// awaitingSignature is set when a signature is requested for the
+	// latest digest and cleared once the signature is submitted.
+	awaitingSignature bool
 
 	signatureRequestedHandlers map[int]func(event *chain.SignatureRequestedEvent)
+	signatureRequestedEvents   []*chain.SignatureRequestedEvent
+
+	publicKeyPublishedHandlers            map[int]func(event *chain.PublicKeyPublishedEvent)
+	conflictingPublicKeySubmittedHandlers map[int]func(event *chain.ConflictingPublicKeySubmittedEvent)
 
 	keepClosedHandlers     map[int]func(event *chain.KeepClosedEvent)
 	keepTerminatedHandlers map[int]func(event *chain.KeepTerminatedEvent)
+	bondSeizedHandlers     map[int]func(event *chain.BondSeizedEvent)
 
 	signatureSubmittedEvents []*chain.SignatureSubmittedEvent
+	keepClosedEvents         []*chain.KeepClosedEvent
+	keepTerminatedEvents     []*chain.KeepTerminatedEvent
 }
 
 func (lc *localChain) GetKeepWithID(
@@ -64,18 +81,115 @@ func (lc *localChain) GetKeepAtIndex(
 	return lc.GetKeepWithID(localChainID(lc.keepAddresses[index]))
 }
 
+func (lc *localChain) GetKeepsForOperator(
+	operatorID chain.ID,
+) ([]chain.ID, error) {
+	operatorAddress, err := fromChainID(operatorID)
+	if err != nil {
+		return nil, err
+	}
+
+	lc.localChainMutex.Lock()
+	defer lc.localChainMutex.Unlock()
+
+	keepIDs := make([]chain.ID, 0)
+	for _, keepAddress := range lc.keepAddresses {
+		for _, member := range lc.keeps[keepAddress].members {
+			if member == operatorAddress {
+				keepIDs = append(keepIDs, localChainID(keepAddress))
+				break
+			}
+		}
+	}
+
+	return keepIDs, nil
+}
+
+func (lc *localChain) GetApplicationKeeps(
+	applicationID chain.ID,
+) ([]chain.ID, error) {
+	applicationAddress, err := fromChainID(applicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	lc.localChainMutex.Lock()
+	defer lc.localChainMutex.Unlock()
+
+	keepIDs := make([]chain.ID, 0)
+	for _, keepAddress := range lc.keepAddresses {
+		if lc.keeps[keepAddress].application == applicationAddress {
+			keepIDs = append(keepIDs, localChainID(keepAddress))
+		}
+	}
+
+	return keepIDs, nil
+}
+
+func (lc *localChain) GetMembersBatch(
+	keepIDs []chain.ID,
+) (map[chain.ID][]chain.ID, error) {
+	return chain.GetMembersBatch(
+		keepIDs,
+		chain.DefaultBatchConcurrency,
+		func(keepID chain.ID) ([]chain.ID, error) {
+			keepAddress, err := fromChainID(keepID)
+			if err != nil {
+				return nil, err
+			}
+
+			lc.localChainMutex.Lock()
+			keep, ok := lc.keeps[keepAddress]
+			lc.localChainMutex.Unlock()
+
+			if !ok {
+				return nil, fmt.Errorf(
+					"failed to find keep with address: [%s]: [%w]",
+					keepAddress.String(),
+					chain.ErrKeepNotFound,
+				)
+			}
+
+			return keep.GetMembers()
+		},
+	)
+}
+
 func (lk *localKeep) ID() chain.ID {
 	return localChainID(lk.keepID)
 }
 
 // OnSignatureRequested is a callback that is invoked on-chain
-// when a keep's signature is requested.
+// when a keep's signature is requested. If a non-zero number of past blocks
+// is set in the options, signature requests from those blocks are replayed
+// to the callback.
 func (lk *localKeep) OnSignatureRequested(
 	handler func(event *chain.SignatureRequestedEvent),
+	opts ...chain.SubscribeOpts,
 ) (subscription.EventSubscription, error) {
 	lk.chain.localChainMutex.Lock()
 	defer lk.chain.localChainMutex.Unlock()
 
+	if pastBlocks := chain.PastBlocks(opts); pastBlocks > 0 {
+		currentBlock, err := lk.chain.blockCounter.CurrentBlock()
+		if err != nil {
+			return nil, err
+		}
+
+		pastEvents := make([]*chain.SignatureRequestedEvent, 0)
+		for _, event := range lk.signatureRequestedEvents {
+			if event.BlockNumber+pastBlocks >= currentBlock {
+				pastEvents = append(pastEvents, event)
+			}
+		}
+
+		go func() {
+			for _, event := range pastEvents {
+				handler(event)
+			}
+		}()
+	}
+
 	handlerID := generateHandlerID()
 
 	lk.signatureRequestedHandlers[handlerID] = handler
@@ -88,16 +202,44 @@ func (lk *localKeep) OnSignatureRequested(
 	}), nil
 }
 
+// OnConflictingPublicKeySubmitted is a callback that is invoked when
+// a conflicting public key is submitted for the keep.
 func (lk *localKeep) OnConflictingPublicKeySubmitted(
 	handler func(event *chain.ConflictingPublicKeySubmittedEvent),
 ) (subscription.EventSubscription, error) {
-	panic("implement")
+	lk.chain.localChainMutex.Lock()
+	defer lk.chain.localChainMutex.Unlock()
+
+	handlerID := generateHandlerID()
+
+	lk.conflictingPublicKeySubmittedHandlers[handlerID] = handler
+
+	return subscription.NewEventSubscription(func() {
+		lk.chain.localChainMutex.Lock()
+		defer lk.chain.localChainMutex.Unlock()
+
+		delete(lk.conflictingPublicKeySubmittedHandlers, handlerID)
+	}), nil
 }
 
+// OnPublicKeyPublished is a callback that is invoked when the public key
+// is published for the keep.
 func (lk *localKeep) OnPublicKeyPublished(
 	handler func(event *chain.PublicKeyPublishedEvent),
 ) (subscription.EventSubscription, error) {
-	panic("implement")
+	lk.chain.localChainMutex.Lock()
+	defer lk.chain.localChainMutex.Unlock()
+
+	handlerID := generateHandlerID()
+
+	lk.publicKeyPublishedHandlers[handlerID] = handler
+
+	return subscription.NewEventSubscription(func() {
+		lk.chain.localChainMutex.Lock()
+		defer lk.chain.localChainMutex.Unlock()
+
+		delete(lk.publicKeyPublishedHandlers, handlerID)
+	}), nil
 }
 
 // SubmitKeepPublicKey checks if public key has been already submitted for given
@@ -106,6 +248,16 @@ func (lk *localKeep) SubmitKeepPublicKey(publicKey [64]byte) error {
 	lk.chain.localChainMutex.Lock()
 	defer lk.chain.localChainMutex.Unlock()
 
+	// Keep members are not validated as the local keep public key submission
+	// stands for the submission of all the members.
+	if err := chain.ValidatePublicKey(publicKey); err != nil {
+		return fmt.Errorf(
+			"invalid public key for keep [%s]: [%v]",
+			lk.ID().String(),
+			err,
+		)
+	}
+
 	if lk.publicKey != [64]byte{} {
 		return fmt.Errorf(
 			"public key already submitted for keep [%s]",
@@ -115,6 +267,25 @@ func (lk *localKeep) SubmitKeepPublicKey(publicKey [64]byte) error {
 
 	lk.publicKey = publicKey
 
+	currentBlock, err := lk.chain.blockCounter.CurrentBlock()
+	if err != nil {
+		return err
+	}
+
+	publicKeyPublishedEvent := &chain.PublicKeyPublishedEvent{
+		PublicKey:   publicKey[:],
+		BlockNumber: currentBlock,
+	}
+
+	for _, handler := range lk.publicKeyPublishedHandlers {
+		go func(
+			handler func(event *chain.PublicKeyPublishedEvent),
+			publicKeyPublishedEvent *chain.PublicKeyPublishedEvent,
+		) {
+			handler(publicKeyPublishedEvent)
+		}(handler, publicKeyPublishedEvent)
+	}
+
 	return nil
 }
 
@@ -124,14 +295,23 @@ func (lk *localKeep) SubmitSignature(signature *ecdsa.Signature) error {
 	lk.chain.localChainMutex.Lock()
 	defer lk.chain.localChainMutex.Unlock()
 
+	lk.chain.logger.logSubmitSignatureCall()
+
 	// force the right workflow sequence
-	if lk.latestDigest == [32]byte{} {
+	if !lk.awaitingSignature {
 		return fmt.Errorf(
 			"keep [%s] is not awaiting for a signature",
 			lk.ID().String(),
 		)
 	}
 
+	if lk.latestDigest == [32]byte{} {
+		return fmt.Errorf(
+			"no signature has been requested from keep [%s]",
+			lk.ID().String(),
+		)
+	}
+
 	rBytes, err := byteutils.BytesTo32Byte(signature.R.Bytes())
 	if err != nil {
 		return err
@@ -142,6 +322,13 @@ func (lk *localKeep) SubmitSignature(signature *ecdsa.Signature) error {
 		return err
 	}
 
+	if lk.chain.verifySignatures {
+		err := lk.verifySignature(rBytes, sBytes, signature.RecoveryID)
+		if err != nil {
+			return err
+		}
+	}
+
 	lk.signatureSubmittedEvents = append(
 		lk.signatureSubmittedEvents,
 		&chain.SignatureSubmittedEvent{
@@ -152,6 +339,46 @@ func (lk *localKeep) SubmitSignature(signature *ecdsa.Signature) error {
 		},
 	)
 
+	lk.awaitingSignature = false
+
+	return nil
+}
+
+// verifySignature checks if the signature over the latest digest recovers
+// to the keep's public key.
+func (lk *localKeep) verifySignature(
+	r [32]byte,
+	s [32]byte,
+	recoveryID int,
+) error {
+	if recoveryID < 0 || recoveryID > 3 {
+		return fmt.Errorf("invalid recovery id [%v]", recoveryID)
+	}
+
+	serializedSignature := append(append(r[:], s[:]...), byte(recoveryID))
+
+	recoveredPublicKey, err := crypto.Ecrecover(
+		lk.latestDigest[:],
+		serializedSignature,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"could not recover public key from signature for keep [%s]: [%v]",
+			lk.ID().String(),
+			err,
+		)
+	}
+
+	// recovered public key is serialized in the uncompressed form with
+	// the 0x04 prefix followed by X and Y coordinates
+	if !bytes.Equal(recoveredPublicKey[1:], lk.publicKey[:]) {
+		return fmt.Errorf(
+			"signature for digest [%x] does not match public key of keep [%s]",
+			lk.latestDigest,
+			lk.ID().String(),
+		)
+	}
+
 	return nil
 }
 
@@ -197,7 +424,7 @@ func (lk *localKeep) IsAwaitingSignature(digest [32]byte) (bool, error) {
 	lk.chain.localChainMutex.Lock()
 	defer lk.chain.localChainMutex.Unlock()
 
-	return lk.latestDigest != [32]byte{}, nil
+	return lk.awaitingSignature && lk.latestDigest == digest, nil
 }
 
 // IsActive checks for current state of a keep on-chain.
@@ -208,12 +435,29 @@ func (lk *localKeep) IsActive() (bool, error) {
 	return lk.status == active, nil
 }
 
+// LatestDigest returns the latest digest requested to be signed.
 func (lk *localKeep) LatestDigest() ([32]byte, error) {
-	panic("implement")
+	lk.chain.localChainMutex.Lock()
+	defer lk.chain.localChainMutex.Unlock()
+
+	return lk.latestDigest, nil
 }
 
+// SignatureRequestedBlock returns block number from the moment when a
+// signature was requested for the given digest from a keep.
+// If a signature was not requested for the given digest, returns 0.
 func (lk *localKeep) SignatureRequestedBlock(digest [32]byte) (uint64, error) {
-	panic("implement")
+	lk.chain.localChainMutex.Lock()
+	defer lk.chain.localChainMutex.Unlock()
+
+	for i := len(lk.signatureRequestedEvents) - 1; i >= 0; i-- {
+		event := lk.signatureRequestedEvents[i]
+		if event.Digest == digest {
+			return event.BlockNumber, nil
+		}
+	}
+
+	return 0, nil
 }
 
 func (lk *localKeep) GetPublicKey() ([]uint8, error) {
@@ -276,11 +520,17 @@ func (lk *localKeep) GetOwner() (chain.ID, error) {
 }
 
 func (lk *localKeep) GetHonestThreshold() (uint64, error) {
-	panic("implement")
+	lk.chain.localChainMutex.Lock()
+	defer lk.chain.localChainMutex.Unlock()
+
+	return lk.honestThreshold, nil
 }
 
 func (lk *localKeep) GetOpenedTimestamp() (time.Time, error) {
-	panic("implement")
+	lk.chain.localChainMutex.Lock()
+	defer lk.chain.localChainMutex.Unlock()
+
+	return lk.openedTimestamp, nil
 }
 
 func (lk *localKeep) PastSignatureSubmittedEvents(
@@ -292,6 +542,46 @@ func (lk *localKeep) PastSignatureSubmittedEvents(
 	return lk.signatureSubmittedEvents, nil
 }
 
+func (lk *localKeep) PastKeepClosedEvents(
+	startBlock uint64,
+) ([]*chain.KeepClosedEvent, error) {
+	lk.chain.localChainMutex.Lock()
+	defer lk.chain.localChainMutex.Unlock()
+
+	result := make([]*chain.KeepClosedEvent, 0)
+	for _, event := range lk.keepClosedEvents {
+		if event.BlockNumber >= startBlock {
+			result = append(result, event)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].BlockNumber < result[j].BlockNumber
+	})
+
+	return result, nil
+}
+
+func (lk *localKeep) PastKeepTerminatedEvents(
+	startBlock uint64,
+) ([]*chain.KeepTerminatedEvent, error) {
+	lk.chain.localChainMutex.Lock()
+	defer lk.chain.localChainMutex.Unlock()
+
+	result := make([]*chain.KeepTerminatedEvent, 0)
+	for _, event := range lk.keepTerminatedEvents {
+		if event.BlockNumber >= startBlock {
+			result = append(result, event)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].BlockNumber < result[j].BlockNumber
+	})
+
+	return result, nil
+}
+
 func (lc *localChain) RequestSignature(keepAddress common.Address, digest [32]byte) error {
 	lc.localChainMutex.Lock()
 	defer lc.localChainMutex.Unlock()
@@ -299,8 +589,9 @@ func (lc *localChain) RequestSignature(keepAddress common.Address, digest [32]by
 	keep, ok := lc.keeps[keepAddress]
 	if !ok {
 		return fmt.Errorf(
-			"failed to find keep with address: [%s]",
+			"failed to find keep with address: [%s]: [%w]",
 			keepAddress.String(),
+			chain.ErrKeepNotFound,
 		)
 	}
 
@@ -313,6 +604,20 @@ func (lc *localChain) RequestSignature(keepAddress common.Address, digest [32]by
 	}
 
 	keep.latestDigest = digest
+	keep.awaitingSignature = true
+
+	currentBlock, err := lc.blockCounter.CurrentBlock()
+	if err != nil {
+		return err
+	}
+
+	keep.signatureRequestedEvents = append(
+		keep.signatureRequestedEvents,
+		&chain.SignatureRequestedEvent{
+			Digest:      digest,
+			BlockNumber: currentBlock,
+		},
+	)
 
 	signatureRequestedEvent := &chain.SignatureRequestedEvent{
 		Digest: digest,
@@ -327,3 +632,45 @@ func (lc *localChain) RequestSignature(keepAddress common.Address, digest [32]by
 
 	return nil
 }
+
+// SubmitConflictingPublicKey simulates a keep member submitting a public key
+// which does not match the keys submitted so far by other members of the keep.
+func (lc *localChain) SubmitConflictingPublicKey(
+	keepAddress common.Address,
+	submittingMember common.Address,
+	publicKey [64]byte,
+) error {
+	lc.localChainMutex.Lock()
+	defer lc.localChainMutex.Unlock()
+
+	keep, ok := lc.keeps[keepAddress]
+	if !ok {
+		return fmt.Errorf(
+			"failed to find keep with address: [%s]: [%w]",
+			keepAddress.String(),
+			chain.ErrKeepNotFound,
+		)
+	}
+
+	currentBlock, err := lc.blockCounter.CurrentBlock()
+	if err != nil {
+		return err
+	}
+
+	conflictingPublicKeySubmittedEvent := &chain.ConflictingPublicKeySubmittedEvent{
+		SubmittingMember:     localChainID(submittingMember),
+		ConflictingPublicKey: publicKey[:],
+		BlockNumber:          currentBlock,
+	}
+
+	for _, handler := range keep.conflictingPublicKeySubmittedHandlers {
+		go func(
+			handler func(event *chain.ConflictingPublicKeySubmittedEvent),
+			conflictingPublicKeySubmittedEvent *chain.ConflictingPublicKeySubmittedEvent,
+		) {
+			handler(conflictingPublicKeySubmittedEvent)
+		}(handler, conflictingPublicKeySubmittedEvent)
+	}
+
+	return nil
+}
@@ -10,12 +10,18 @@ import (
 func (c *localChain) createKeep(
 	keepAddress common.Address,
 ) error {
-	return c.createKeepWithMembers(keepAddress, keepAddress, []common.Address{})
+	return c.createKeepWithMembers(
+		keepAddress,
+		keepAddress,
+		common.BigToAddress(tbtcApplicationID),
+		[]common.Address{},
+	)
 }
 
 func (c *localChain) createKeepWithMembers(
 	keepAddress common.Address,
 	ownerAddress common.Address,
+	applicationAddress common.Address,
 	members []common.Address,
 ) error {
 	c.localChainMutex.Lock()
@@ -28,17 +34,12 @@ func (c *localChain) createKeepWithMembers(
 		)
 	}
 
-	localKeep := &localKeep{
-		chain:                      c,
-		keepID:                     keepAddress,
-		owner:                      ownerAddress,
-		publicKey:                  [64]byte{},
-		members:                    members,
-		signatureRequestedHandlers: make(map[int]func(event *chain.SignatureRequestedEvent)),
-		keepClosedHandlers:         make(map[int]func(event *chain.KeepClosedEvent)),
-		keepTerminatedHandlers:     make(map[int]func(event *chain.KeepTerminatedEvent)),
-		signatureSubmittedEvents:   make([]*chain.SignatureSubmittedEvent, 0),
-	}
+	localKeep := c.newLocalKeep(
+		keepAddress,
+		ownerAddress,
+		applicationAddress,
+		members,
+	)
 
 	c.keeps[keepAddress] = localKeep
 	c.keepAddresses = append(c.keepAddresses, keepAddress)
@@ -48,6 +49,8 @@ func (c *localChain) createKeepWithMembers(
 
 	keepCreatedEvent := &chain.BondedECDSAKeepCreatedEvent{
 		Keep:                 localKeep,
+		OwnerID:              localChainID(ownerAddress),
+		ApplicationID:        localChainID(applicationAddress),
 		ThisOperatorIsMember: operatorIndex > -1,
 	}
 
@@ -62,3 +65,32 @@ func (c *localChain) createKeepWithMembers(
 
 	return nil
 }
+
+// newLocalKeep creates a keep with no public key and no events. It should be
+// called with the chain mutex held, as it reads the chain clock.
+func (c *localChain) newLocalKeep(
+	keepAddress common.Address,
+	ownerAddress common.Address,
+	applicationAddress common.Address,
+	members []common.Address,
+) *localKeep {
+	return &localKeep{
+		chain:                      c,
+		keepID:                     keepAddress,
+		owner:                      ownerAddress,
+		application:                applicationAddress,
+		publicKey:                  [64]byte{},
+		members:                    members,
+		honestThreshold:            uint64(len(members)),
+		openedTimestamp:            c.clock.Now(),
+		signatureRequestedHandlers: make(map[int]func(event *chain.SignatureRequestedEvent)),
+		publicKeyPublishedHandlers: make(map[int]func(event *chain.PublicKeyPublishedEvent)),
+		conflictingPublicKeySubmittedHandlers: make(
+			map[int]func(event *chain.ConflictingPublicKeySubmittedEvent),
+		),
+		keepClosedHandlers:       make(map[int]func(event *chain.KeepClosedEvent)),
+		keepTerminatedHandlers:   make(map[int]func(event *chain.KeepTerminatedEvent)),
+		bondSeizedHandlers:       make(map[int]func(event *chain.BondSeizedEvent)),
+		signatureSubmittedEvents: make([]*chain.SignatureSubmittedEvent, 0),
+	}
+}
@@ -3,9 +3,8 @@ package local
 import (
 	"bytes"
 	"context"
-	"fmt"
+	"errors"
 	"math/rand"
-	"reflect"
 	"testing"
 	"time"
 
@@ -22,15 +21,14 @@ func TestRequestSignatureNonexistentKeep(t *testing.T) {
 	localChain := initializeLocalChain(ctx)
 	keepAddress := common.Address([20]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1})
 	digest := [32]byte{1}
-	expectedError := fmt.Errorf("failed to find keep with address: [0x0000000000000000000000000000000000000001]")
 
 	err := localChain.RequestSignature(keepAddress, digest)
 
-	if !reflect.DeepEqual(err, expectedError) {
+	if !errors.Is(err, chain.ErrKeepNotFound) {
 		t.Fatalf(
 			"unexpected error\nexpected: [%v]\nactual:   [%v]",
-			expectedError,
-			err.Error(),
+			chain.ErrKeepNotFound,
+			err,
 		)
 	}
 }
@@ -101,3 +99,75 @@ func TestRequestSignature(t *testing.T) {
 		t.Fatal(ctx.Err())
 	}
 }
+
+func TestSubmitKeepPublicKey_ZeroKey(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	localChain := initializeLocalChain(ctx)
+	keepAddress := common.Address([20]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1})
+
+	keep := localChain.OpenKeep(keepAddress, emptyAddress, []common.Address{})
+
+	err := keep.SubmitKeepPublicKey([64]byte{})
+	if err == nil {
+		t.Fatal("expected error for all-zero public key")
+	}
+
+	// The rejected key must not prevent the valid key from being submitted.
+	err = keep.SubmitKeepPublicKey([64]byte{11, 12, 13, 14, 15, 16})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestValidatePublicKeySubmitter(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	localChain := initializeLocalChain(ctx)
+
+	var tests = map[string]struct {
+		members       []common.Address
+		expectedError bool
+	}{
+		"operator is a member": {
+			members: append(
+				[]common.Address{localChain.OperatorAddress()},
+				RandomSigningGroup(2)...,
+			),
+			expectedError: false,
+		},
+		"operator is not a member": {
+			members:       RandomSigningGroup(3),
+			expectedError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			keep := localChain.OpenKeep(
+				generateAddress(),
+				emptyAddress,
+				test.members,
+			)
+
+			members, err := keep.GetMembers()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = chain.ValidatePublicKeySubmitter(
+				localChain.OperatorID(),
+				members,
+			)
+			if test.expectedError != (err != nil) {
+				t.Errorf(
+					"unexpected error\nexpected error: %v\nactual:   %v",
+					test.expectedError,
+					err,
+				)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package local
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is a source of the current time for the local chain. The local chain
+// uses the real clock by default; tests exercising time-based logic can
+// replace it with a ManualClock via Chain.SetClock.
+type Clock interface {
+	Now() time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+// ManualClock is a Clock which moves forward only when explicitly advanced.
+type ManualClock struct {
+	mutex sync.RWMutex
+	now   time.Time
+}
+
+// NewManualClock creates a ManualClock set to the given time.
+func NewManualClock(now time.Time) *ManualClock {
+	return &ManualClock{now: now}
+}
+
+// Now returns the current time of the clock.
+func (mc *ManualClock) Now() time.Time {
+	mc.mutex.RLock()
+	defer mc.mutex.RUnlock()
+
+	return mc.now
+}
+
+// Advance moves the clock forward by the given duration.
+func (mc *ManualClock) Advance(duration time.Duration) {
+	mc.mutex.Lock()
+	defer mc.mutex.Unlock()
+
+	mc.now = mc.now.Add(duration)
+}
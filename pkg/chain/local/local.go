@@ -31,10 +31,36 @@ type Chain interface {
 		ownerAddress common.Address,
 		members []common.Address,
 	) chain.BondedECDSAKeepHandle
+	OpenKeepForApplication(
+		keepAddress common.Address,
+		ownerAddress common.Address,
+		applicationAddress common.Address,
+		members []common.Address,
+	) chain.BondedECDSAKeepHandle
 	CloseKeep(keepAddress common.Address) error
 	TerminateKeep(keepAddress common.Address) error
 	RequestSignature(keepAddress common.Address, digest [32]byte) error
+	SubmitConflictingPublicKey(
+		keepAddress common.Address,
+		submittingMember common.Address,
+		publicKey [64]byte,
+	) error
 	AuthorizeOperator(operatorAddress common.Address)
+	DeauthorizeOperator(operatorAddress common.Address)
+	SetBondAmount(
+		keepAddress common.Address,
+		operatorAddress common.Address,
+		holderAddress common.Address,
+		amount *big.Int,
+	)
+	SeizeBond(
+		keepAddress common.Address,
+		operatorAddress common.Address,
+		amount *big.Int,
+	) error
+	SetClock(clock Clock)
+	SetBlockTime(blockTime time.Duration)
+	SetSignatureVerification(enabled bool)
 }
 
 // localChain is an implementation of ethereum blockchain interface.
@@ -47,6 +73,17 @@ type localChain struct {
 	blockCounter     corechain.BlockCounter
 	blocksTimestamps sync.Map
 
+	clock Clock
+	// blockTime is the fixed interval between blocks used to derive block
+	// timestamps. If zero, blocks are timestamped with the clock time they
+	// have been observed at.
+	blockTime time.Duration
+	// blockTimeAnchor and blockTimeAnchorTime pin the fixed block time
+	// model to the clock.
+	blockTimeAnchor     uint64
+	blockTimeAnchorTime time.Time
+	lastBlockTimestamp  uint64
+
 	keepAddresses []common.Address
 	keeps         map[common.Address]*localKeep
 
@@ -56,6 +93,24 @@ type localChain struct {
 	signer      corechain.Signing
 
 	authorizations map[common.Address]bool
+
+	operatorDeauthorizedHandlers map[int]func(operator chain.ID)
+
+	bonds map[bondKey]*big.Int
+
+	// verifySignatures enables checking that signatures submitted to keeps
+	// recover to the keep public key. It is disabled by default so tests can
+	// submit arbitrary signatures.
+	verifySignatures bool
+
+	logger *ChainLogger
+}
+
+// bondKey identifies a bond the same way the keep bonding contract does.
+type bondKey struct {
+	keepAddress     common.Address
+	operatorAddress common.Address
+	holderAddress   common.Address
 }
 
 // Connect performs initialization for the local chain, wrapped in the provided
@@ -80,10 +135,15 @@ func Connect(ctx context.Context) Chain {
 		operatorKey:         operatorKey,
 		signer:              signer,
 		authorizations:      make(map[common.Address]bool),
+		bonds:               make(map[bondKey]*big.Int),
+		clock:               realClock{},
+		logger:              &ChainLogger{},
+
+		operatorDeauthorizedHandlers: make(map[int]func(operator chain.ID)),
 	}
 
 	// block 0 must be stored manually as it is not delivered by the block counter
-	localChain.blocksTimestamps.Store(uint64(0), uint64(time.Now().Unix()))
+	localChain.recordBlockTimestamp(0)
 
 	go localChain.observeBlocksTimestamps(ctx)
 
@@ -100,13 +160,28 @@ func (lc *localChain) observeBlocksTimestamps(ctx context.Context) {
 	for {
 		select {
 		case blockNumber := <-blockChan:
-			lc.blocksTimestamps.Store(blockNumber, uint64(time.Now().Unix()))
+			lc.recordBlockTimestamp(blockNumber)
 		case <-ctx.Done():
 			return
 		}
 	}
 }
 
+// recordBlockTimestamp stores the current clock time as the timestamp of the
+// given block. Timestamps never go backwards, even if the clock does.
+func (lc *localChain) recordBlockTimestamp(blockNumber uint64) {
+	lc.localChainMutex.Lock()
+	defer lc.localChainMutex.Unlock()
+
+	timestamp := uint64(lc.clock.Now().Unix())
+	if timestamp < lc.lastBlockTimestamp {
+		timestamp = lc.lastBlockTimestamp
+	}
+	lc.lastBlockTimestamp = timestamp
+
+	lc.blocksTimestamps.Store(blockNumber, timestamp)
+}
+
 func (lc *localChain) OperatorAddress() common.Address {
 	return common.BytesToAddress(lc.signer.PublicKey())
 }
@@ -119,12 +194,34 @@ func (lc *localChain) Signing() corechain.Signing {
 	return commonLocal.NewSigner(lc.operatorKey)
 }
 
+// OpenKeep opens a keep for the tBTC application.
 func (lc *localChain) OpenKeep(
 	keepAddress common.Address,
 	ownerAddress common.Address,
 	members []common.Address,
 ) chain.BondedECDSAKeepHandle {
-	err := lc.createKeepWithMembers(keepAddress, ownerAddress, members)
+	return lc.OpenKeepForApplication(
+		keepAddress,
+		ownerAddress,
+		common.BigToAddress(tbtcApplicationID),
+		members,
+	)
+}
+
+// OpenKeepForApplication opens a keep for the application with the given
+// address.
+func (lc *localChain) OpenKeepForApplication(
+	keepAddress common.Address,
+	ownerAddress common.Address,
+	applicationAddress common.Address,
+	members []common.Address,
+) chain.BondedECDSAKeepHandle {
+	err := lc.createKeepWithMembers(
+		keepAddress,
+		ownerAddress,
+		applicationAddress,
+		members,
+	)
 	if err != nil {
 		panic(err)
 	}
@@ -149,6 +246,89 @@ func (lc *localChain) AuthorizeOperator(operator common.Address) {
 	lc.authorizations[operator] = true
 }
 
+// DeauthorizeOperator revokes the authorization of the operator and notifies
+// handlers installed with OnOperatorDeauthorized. Nothing happens if the
+// operator is not authorized.
+func (lc *localChain) DeauthorizeOperator(operator common.Address) {
+	lc.localChainMutex.Lock()
+	defer lc.localChainMutex.Unlock()
+
+	if !lc.authorizations[operator] {
+		return
+	}
+
+	delete(lc.authorizations, operator)
+
+	for _, handler := range lc.operatorDeauthorizedHandlers {
+		go func(handler func(operator chain.ID)) {
+			handler(localChainID(operator))
+		}(handler)
+	}
+}
+
+func (lc *localChain) SetBondAmount(
+	keepAddress common.Address,
+	operatorAddress common.Address,
+	holderAddress common.Address,
+	amount *big.Int,
+) {
+	lc.localChainMutex.Lock()
+	defer lc.localChainMutex.Unlock()
+
+	lc.bonds[bondKey{keepAddress, operatorAddress, holderAddress}] = amount
+}
+
+func (lc *localChain) SeizeBond(
+	keepAddress common.Address,
+	operatorAddress common.Address,
+	amount *big.Int,
+) error {
+	return lc.seizeBond(keepAddress, operatorAddress, amount)
+}
+
+// SetClock replaces the clock used by the local chain to timestamp keeps and
+// blocks.
+func (lc *localChain) SetClock(clock Clock) {
+	lc.localChainMutex.Lock()
+	defer lc.localChainMutex.Unlock()
+
+	lc.clock = clock
+	lc.anchorBlockTime()
+}
+
+// SetBlockTime switches the local chain to a fixed block time model where
+// block timestamps are derived from the block number. Blocks are assumed to
+// be mined every blockTime, with the current block mined at the current
+// clock time. Setting a zero block time restores timestamping blocks when
+// they are observed.
+func (lc *localChain) SetBlockTime(blockTime time.Duration) {
+	lc.localChainMutex.Lock()
+	defer lc.localChainMutex.Unlock()
+
+	lc.blockTime = blockTime
+	lc.anchorBlockTime()
+}
+
+// SetSignatureVerification enables or disables checking that signatures
+// submitted to keeps were produced over the requested digest with the keep's
+// private key.
+func (lc *localChain) SetSignatureVerification(enabled bool) {
+	lc.localChainMutex.Lock()
+	defer lc.localChainMutex.Unlock()
+
+	lc.verifySignatures = enabled
+}
+
+func (lc *localChain) anchorBlockTime() {
+	currentBlock, err := lc.blockCounter.CurrentBlock()
+	if err != nil {
+		currentBlock = 0
+	}
+
+	lc.blockTimeAnchor = currentBlock
+	lc.blockTimeAnchorTime = lc.clock.Now()
+}
+
 func (lc *localChain) StakeMonitor() (corechain.StakeMonitor, error) {
 	return nil, nil // not implemented.
 }
@@ -173,6 +353,26 @@ func (lc *localChain) OnBondedECDSAKeepCreated(
 	})
 }
 
+// OnOperatorDeauthorized installs a callback that is invoked when the
+// authorization of an operator is revoked with DeauthorizeOperator.
+func (lc *localChain) OnOperatorDeauthorized(
+	handler func(operator chain.ID),
+) subscription.EventSubscription {
+	lc.localChainMutex.Lock()
+	defer lc.localChainMutex.Unlock()
+
+	handlerID := generateHandlerID()
+
+	lc.operatorDeauthorizedHandlers[handlerID] = handler
+
+	return subscription.NewEventSubscription(func() {
+		lc.localChainMutex.Lock()
+		defer lc.localChainMutex.Unlock()
+
+		delete(lc.operatorDeauthorizedHandlers, handlerID)
+	})
+}
+
 func (lc *localChain) BlockCounter() corechain.BlockCounter {
 	return lc.blockCounter
 }
@@ -196,7 +396,105 @@ func (lc *localChain) GetKeepCount() (*big.Int, error) {
 	return big.NewInt(int64(len(lc.keeps))), nil
 }
 
+func (lc *localChain) GetBondAmount(
+	keepID chain.ID,
+	operatorID chain.ID,
+	holderID chain.ID,
+) (*big.Int, error) {
+	keepAddress, err := fromChainID(keepID)
+	if err != nil {
+		return nil, err
+	}
+
+	operatorAddress, err := fromChainID(operatorID)
+	if err != nil {
+		return nil, err
+	}
+
+	holderAddress, err := fromChainID(holderID)
+	if err != nil {
+		return nil, err
+	}
+
+	lc.localChainMutex.Lock()
+	defer lc.localChainMutex.Unlock()
+
+	amount, ok := lc.bonds[bondKey{keepAddress, operatorAddress, holderAddress}]
+	if !ok {
+		return big.NewInt(0), nil
+	}
+
+	return new(big.Int).Set(amount), nil
+}
+
+func (lc *localChain) GetTotalBondedAmount(
+	operatorID chain.ID,
+) (*big.Int, error) {
+	operatorAddress, err := fromChainID(operatorID)
+	if err != nil {
+		return nil, err
+	}
+
+	lc.localChainMutex.Lock()
+	defer lc.localChainMutex.Unlock()
+
+	total := big.NewInt(0)
+	for key, amount := range lc.bonds {
+		if key.operatorAddress == operatorAddress {
+			total.Add(total, amount)
+		}
+	}
+
+	return total, nil
+}
+
+func (lc *localChain) OnBondSeized(
+	keepID chain.ID,
+	handler func(event *chain.BondSeizedEvent),
+) (subscription.EventSubscription, error) {
+	keepAddress, err := fromChainID(keepID)
+	if err != nil {
+		return nil, err
+	}
+
+	lc.localChainMutex.Lock()
+	defer lc.localChainMutex.Unlock()
+
+	keep, ok := lc.keeps[keepAddress]
+	if !ok {
+		return nil, fmt.Errorf(
+			"failed to find keep with address: [%s]: [%w]",
+			keepAddress.String(),
+			chain.ErrKeepNotFound,
+		)
+	}
+
+	handlerID := generateHandlerID()
+
+	keep.bondSeizedHandlers[handlerID] = handler
+
+	return subscription.NewEventSubscription(func() {
+		lc.localChainMutex.Lock()
+		defer lc.localChainMutex.Unlock()
+
+		delete(keep.bondSeizedHandlers, handlerID)
+	}), nil
+}
+
 func (lc *localChain) BlockTimestamp(blockNumber *big.Int) (uint64, error) {
+	lc.localChainMutex.Lock()
+	blockTime := lc.blockTime
+	anchor := lc.blockTimeAnchor
+	anchorTime := lc.blockTimeAnchorTime
+	lc.localChainMutex.Unlock()
+
+	if blockTime > 0 {
+		blocksSinceAnchor := int64(blockNumber.Uint64()) - int64(anchor)
+		return uint64(
+			anchorTime.Add(time.Duration(blocksSinceAnchor) * blockTime).Unix(),
+		), nil
+	}
+
 	blockTimestamp, ok := lc.blocksTimestamps.Load(blockNumber.Uint64())
 	if !ok {
 		return 0, fmt.Errorf("no timestamp for block [%v]", blockNumber)
@@ -239,8 +537,9 @@ func (lc *localChain) closeKeep(keepAddress common.Address) error {
 	keep, ok := lc.keeps[keepAddress]
 	if !ok {
 		return fmt.Errorf(
-			"failed to find keep with address: [%s]",
+			"failed to find keep with address: [%s]: [%w]",
 			keepAddress.String(),
+			chain.ErrKeepNotFound,
 		)
 	}
 
@@ -250,7 +549,17 @@ func (lc *localChain) closeKeep(keepAddress common.Address) error {
 
 	keep.status = closed
 
-	keepClosedEvent := &chain.KeepClosedEvent{}
+	currentBlock, err := lc.blockCounter.CurrentBlock()
+	if err != nil {
+		return err
+	}
+
+	keep.keepClosedEvents = append(
+		keep.keepClosedEvents,
+		&chain.KeepClosedEvent{BlockNumber: currentBlock},
+	)
+
+	keepClosedEvent := &chain.KeepClosedEvent{BlockNumber: currentBlock}
 
 	for _, handler := range keep.keepClosedHandlers {
 		go func(
@@ -264,6 +573,56 @@ func (lc *localChain) closeKeep(keepAddress common.Address) error {
 	return nil
 }
 
+func (lc *localChain) seizeBond(
+	keepAddress common.Address,
+	operatorAddress common.Address,
+	amount *big.Int,
+) error {
+	lc.localChainMutex.Lock()
+	defer lc.localChainMutex.Unlock()
+
+	keep, ok := lc.keeps[keepAddress]
+	if !ok {
+		return fmt.Errorf(
+			"failed to find keep with address: [%s]: [%w]",
+			keepAddress.String(),
+			chain.ErrKeepNotFound,
+		)
+	}
+
+	key := bondKey{keepAddress, operatorAddress, keepAddress}
+	if bondAmount, ok := lc.bonds[key]; ok {
+		remainingAmount := new(big.Int).Sub(bondAmount, amount)
+		if remainingAmount.Sign() < 0 {
+			remainingAmount = big.NewInt(0)
+		}
+		lc.bonds[key] = remainingAmount
+	}
+
+	currentBlock, err := lc.blockCounter.CurrentBlock()
+	if err != nil {
+		return err
+	}
+
+	bondSeizedEvent := &chain.BondSeizedEvent{
+		KeepID:      localChainID(keepAddress),
+		Operator:    localChainID(operatorAddress),
+		Amount:      amount,
+		BlockNumber: currentBlock,
+	}
+
+	for _, handler := range keep.bondSeizedHandlers {
+		go func(
+			handler func(event *chain.BondSeizedEvent),
+			bondSeizedEvent *chain.BondSeizedEvent,
+		) {
+			handler(bondSeizedEvent)
+		}(handler, bondSeizedEvent)
+	}
+
+	return nil
+}
+
 func (lc *localChain) terminateKeep(keepAddress common.Address) error {
 	lc.localChainMutex.Lock()
 	defer lc.localChainMutex.Unlock()
@@ -271,8 +630,9 @@ func (lc *localChain) terminateKeep(keepAddress common.Address) error {
 	keep, ok := lc.keeps[keepAddress]
 	if !ok {
 		return fmt.Errorf(
-			"failed to find keep with address: [%s]",
+			"failed to find keep with address: [%s]: [%w]",
 			keepAddress.String(),
+			chain.ErrKeepNotFound,
 		)
 	}
 
@@ -282,7 +642,17 @@ func (lc *localChain) terminateKeep(keepAddress common.Address) error {
 
 	keep.status = terminated
 
-	keepTerminatedEvent := &chain.KeepTerminatedEvent{}
+	currentBlock, err := lc.blockCounter.CurrentBlock()
+	if err != nil {
+		return err
+	}
+
+	keep.keepTerminatedEvents = append(
+		keep.keepTerminatedEvents,
+		&chain.KeepTerminatedEvent{BlockNumber: currentBlock},
+	)
+
+	keepTerminatedEvent := &chain.KeepTerminatedEvent{BlockNumber: currentBlock}
 
 	for _, handler := range keep.keepTerminatedHandlers {
 		go func(
@@ -14,6 +14,7 @@ import (
 	"github.com/keep-network/keep-ecdsa/pkg/utils/byteutils"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/keep-network/keep-ecdsa/pkg/chain"
 )
 
@@ -32,88 +33,1015 @@ func TestOnBondedECDSAKeepCreated(t *testing.T) {
 	)
 	defer subscription.Unsubscribe()
 
+	ownerAddress := common.HexToAddress("0xa5FA806723A7c7c8523F33c39686f20b52612877")
+
+	keep := localChain.OpenKeep(keepAddress, ownerAddress, []common.Address{})
+	expectedEvent := &chain.BondedECDSAKeepCreatedEvent{
+		Keep:          keep,
+		OwnerID:       localChainID(ownerAddress),
+		ApplicationID: localChainID(common.BigToAddress(tbtcApplicationID)),
+	}
+
+	select {
+	case event := <-eventFired:
+		if !reflect.DeepEqual(event, expectedEvent) {
+			t.Fatalf(
+				"unexpected keep creation event\nexpected: [%v]\nactual:   [%v]",
+				expectedEvent,
+				event,
+			)
+		}
+	case <-ctx.Done():
+		t.Fatal(ctx.Err())
+	}
+}
+
+func TestOnBondedECDSAKeepCreated_Application(t *testing.T) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancelCtx()
+
+	localChain := initializeLocalChain(ctx)
+	eventFired := make(chan *chain.BondedECDSAKeepCreatedEvent)
+	keepAddress := common.Address([20]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1})
+	applicationAddress := common.HexToAddress("0x2b70907b5c44897030ea1369591ddcd23c5d85d6")
+
+	subscription := localChain.OnBondedECDSAKeepCreated(
+		func(event *chain.BondedECDSAKeepCreatedEvent) {
+			eventFired <- event
+		},
+	)
+	defer subscription.Unsubscribe()
+
+	localChain.OpenKeepForApplication(
+		keepAddress,
+		emptyAddress,
+		applicationAddress,
+		[]common.Address{},
+	)
+
+	select {
+	case event := <-eventFired:
+		expectedApplicationID := localChainID(applicationAddress)
+		if event.ApplicationID != expectedApplicationID {
+			t.Errorf(
+				"unexpected application id\nexpected: [%v]\nactual:   [%v]",
+				expectedApplicationID,
+				event.ApplicationID,
+			)
+		}
+	case <-ctx.Done():
+		t.Fatal(ctx.Err())
+	}
+}
+
+func TestGetKeepsForOperator(t *testing.T) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancelCtx()
+
+	localChain := initializeLocalChain(ctx)
+
+	operator := common.HexToAddress("0x65ea55c1f10491038425725dc00dffeab2a1e28a")
+	otherMembers := RandomSigningGroup(2)
+
+	keepAddress1 := common.Address([20]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1})
+	keepAddress2 := common.Address([20]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2})
+	keepAddress3 := common.Address([20]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 3})
+
+	localChain.OpenKeep(
+		keepAddress1,
+		emptyAddress,
+		append([]common.Address{operator}, otherMembers...),
+	)
+	localChain.OpenKeep(keepAddress2, emptyAddress, otherMembers)
+	localChain.OpenKeep(
+		keepAddress3,
+		emptyAddress,
+		[]common.Address{otherMembers[0], operator},
+	)
+
+	keepIDs, err := localChain.GetKeepsForOperator(localChainID(operator))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedKeepIDs := []chain.ID{
+		localChainID(keepAddress1),
+		localChainID(keepAddress3),
+	}
+	if !reflect.DeepEqual(expectedKeepIDs, keepIDs) {
+		t.Errorf(
+			"unexpected keeps\nexpected: [%v]\nactual:   [%v]",
+			expectedKeepIDs,
+			keepIDs,
+		)
+	}
+}
+
+func TestGetApplicationKeeps(t *testing.T) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancelCtx()
+
+	localChain := initializeLocalChain(ctx)
+
+	application1 := common.HexToAddress("0x8d5D9eC7B5e7dEc1B4D3e2D2E02F0eDa5E6Df6f1")
+	application2 := common.HexToAddress("0x2b4f1e29c5f0dA6D7e1C3b2c6E1F1b1A3eF9e2c7")
+	members := RandomSigningGroup(3)
+
+	keepAddress1 := common.Address([20]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1})
+	keepAddress2 := common.Address([20]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2})
+	keepAddress3 := common.Address([20]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 3})
+
+	localChain.OpenKeepForApplication(keepAddress1, emptyAddress, application1, members)
+	localChain.OpenKeepForApplication(keepAddress2, emptyAddress, application2, members)
+	localChain.OpenKeepForApplication(keepAddress3, emptyAddress, application1, members)
+
+	var tests = map[string]struct {
+		application     common.Address
+		expectedKeepIDs []chain.ID
+	}{
+		"first application": {
+			application: application1,
+			expectedKeepIDs: []chain.ID{
+				localChainID(keepAddress1),
+				localChainID(keepAddress3),
+			},
+		},
+		"second application": {
+			application: application2,
+			expectedKeepIDs: []chain.ID{
+				localChainID(keepAddress2),
+			},
+		},
+		"application without keeps": {
+			application:     common.HexToAddress("0x65ea55c1f10491038425725dc00dffeab2a1e28a"),
+			expectedKeepIDs: []chain.ID{},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			keepIDs, err := localChain.GetApplicationKeeps(
+				localChainID(test.application),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(test.expectedKeepIDs, keepIDs) {
+				t.Errorf(
+					"unexpected keeps\nexpected: [%v]\nactual:   [%v]",
+					test.expectedKeepIDs,
+					keepIDs,
+				)
+			}
+		})
+	}
+}
+
+func TestGetMembersBatch(t *testing.T) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancelCtx()
+
+	localChain := initializeLocalChain(ctx)
+
+	keepAddress1 := common.Address([20]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1})
+	keepAddress2 := common.Address([20]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2})
+	missingKeepAddress := common.Address([20]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 3})
+
+	members1 := RandomSigningGroup(3)
+	members2 := RandomSigningGroup(2)
+
+	localChain.OpenKeep(keepAddress1, emptyAddress, members1)
+	localChain.OpenKeep(keepAddress2, emptyAddress, members2)
+
+	members, err := localChain.GetMembersBatch([]chain.ID{
+		localChainID(keepAddress1),
+		localChainID(missingKeepAddress),
+		localChainID(keepAddress2),
+	})
+
+	batchErr, ok := err.(*chain.BatchError)
+	if !ok {
+		t.Fatalf("unexpected error type: [%T]", err)
+	}
+
+	if len(batchErr.Errors) != 1 {
+		t.Errorf(
+			"unexpected number of errors\nexpected: [%v]\nactual:   [%v]",
+			1,
+			len(batchErr.Errors),
+		)
+	}
+
+	expectedMembers := map[chain.ID][]chain.ID{
+		localChainID(keepAddress1): toIDSlice(members1),
+		localChainID(keepAddress2): toIDSlice(members2),
+	}
+	if !reflect.DeepEqual(expectedMembers, members) {
+		t.Errorf(
+			"unexpected members\nexpected: [%v]\nactual:   [%v]",
+			expectedMembers,
+			members,
+		)
+	}
+}
+
+func TestGetBondAmount(t *testing.T) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancelCtx()
+
+	localChain := initializeLocalChain(ctx)
+
+	keepAddress := common.Address([20]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1})
+	members := RandomSigningGroup(2)
+	bondedOperator := members[0]
+	unbondedOperator := members[1]
+
+	localChain.OpenKeep(keepAddress, emptyAddress, members)
+
+	expectedBondAmount := big.NewInt(10000)
+	localChain.SetBondAmount(
+		keepAddress,
+		bondedOperator,
+		keepAddress,
+		expectedBondAmount,
+	)
+
+	var tests = map[string]struct {
+		operator           common.Address
+		expectedBondAmount *big.Int
+	}{
+		"bonded operator": {
+			operator:           bondedOperator,
+			expectedBondAmount: expectedBondAmount,
+		},
+		"operator with no bond": {
+			operator:           unbondedOperator,
+			expectedBondAmount: big.NewInt(0),
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			bondAmount, err := localChain.GetBondAmount(
+				localChainID(keepAddress),
+				localChainID(test.operator),
+				localChainID(keepAddress),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if test.expectedBondAmount.Cmp(bondAmount) != 0 {
+				t.Errorf(
+					"unexpected bond amount\nexpected: [%v]\nactual:   [%v]",
+					test.expectedBondAmount,
+					bondAmount,
+				)
+			}
+
+			totalBondedAmount, err := localChain.GetTotalBondedAmount(
+				localChainID(test.operator),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if test.expectedBondAmount.Cmp(totalBondedAmount) != 0 {
+				t.Errorf(
+					"unexpected total bonded amount\nexpected: [%v]\nactual:   [%v]",
+					test.expectedBondAmount,
+					totalBondedAmount,
+				)
+			}
+		})
+	}
+}
+
+func TestOnBondSeized(t *testing.T) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancelCtx()
+
+	localChain := initializeLocalChain(ctx)
+	eventFired := make(chan *chain.BondSeizedEvent)
+	keepAddress := common.Address([20]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1})
+	members := RandomSigningGroup(2)
+
+	localChain.OpenKeep(keepAddress, emptyAddress, members)
+	localChain.SetBondAmount(keepAddress, members[0], keepAddress, big.NewInt(100))
+
+	subscription, err := localChain.OnBondSeized(
+		localChainID(keepAddress),
+		func(event *chain.BondSeizedEvent) {
+			eventFired <- event
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer subscription.Unsubscribe()
+
+	seizedAmount := big.NewInt(40)
+	err = localChain.SeizeBond(keepAddress, members[0], seizedAmount)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case event := <-eventFired:
+		if seizedAmount.Cmp(event.Amount) != 0 {
+			t.Errorf(
+				"unexpected seized amount\nexpected: [%v]\nactual:   [%v]",
+				seizedAmount,
+				event.Amount,
+			)
+		}
+		if event.KeepID != localChainID(keepAddress) {
+			t.Errorf(
+				"unexpected keep\nexpected: [%v]\nactual:   [%v]",
+				localChainID(keepAddress),
+				event.KeepID,
+			)
+		}
+		if event.Operator != localChainID(members[0]) {
+			t.Errorf(
+				"unexpected operator\nexpected: [%v]\nactual:   [%v]",
+				localChainID(members[0]),
+				event.Operator,
+			)
+		}
+	case <-ctx.Done():
+		t.Fatal(ctx.Err())
+	}
+
+	expectedBondAmount := big.NewInt(60)
+	bondAmount, err := localChain.GetBondAmount(
+		localChainID(keepAddress),
+		localChainID(members[0]),
+		localChainID(keepAddress),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expectedBondAmount.Cmp(bondAmount) != 0 {
+		t.Errorf(
+			"unexpected bond amount\nexpected: [%v]\nactual:   [%v]",
+			expectedBondAmount,
+			bondAmount,
+		)
+	}
+}
+
+func TestOnOperatorDeauthorized(t *testing.T) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancelCtx()
+
+	localChain := initializeLocalChain(ctx)
+	eventFired := make(chan chain.ID, 2)
+	operator := common.HexToAddress("0x65ea55c1f10491038425725dc00dffeab2a1e28a")
+
+	subscription := localChain.OnOperatorDeauthorized(
+		func(operator chain.ID) {
+			eventFired <- operator
+		},
+	)
+	defer subscription.Unsubscribe()
+
+	localChain.AuthorizeOperator(operator)
+	localChain.DeauthorizeOperator(operator)
+	// The operator is no longer authorized so this should not be notified.
+	localChain.DeauthorizeOperator(operator)
+
+	select {
+	case event := <-eventFired:
+		if event != localChainID(operator) {
+			t.Errorf(
+				"unexpected operator\nexpected: [%v]\nactual:   [%v]",
+				localChainID(operator),
+				event,
+			)
+		}
+	case <-ctx.Done():
+		t.Fatal(ctx.Err())
+	}
+
+	select {
+	case event := <-eventFired:
+		t.Fatalf("unexpected deauthorization event for operator [%v]", event)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	authorized, err := localChain.IsOperatorAuthorized(localChainID(operator))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if authorized {
+		t.Errorf("operator should not be authorized")
+	}
+}
+
+func TestOnSignatureRequested(t *testing.T) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancelCtx()
+
+	localChain := initializeLocalChain(ctx)
+	eventFired := make(chan *chain.SignatureRequestedEvent)
+	keepAddress := common.Address([20]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1})
+	digest := [32]byte{1}
+
+	keep := localChain.OpenKeep(keepAddress, emptyAddress, []common.Address{})
+
+	var keepPubkey [64]byte
+	rand.Read(keepPubkey[:])
+
+	err := keep.SubmitKeepPublicKey(keepPubkey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	subscription, err := keep.OnSignatureRequested(
+		func(event *chain.SignatureRequestedEvent) {
+			eventFired <- event
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer subscription.Unsubscribe()
+
+	err = localChain.RequestSignature(keepAddress, digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedEvent := &chain.SignatureRequestedEvent{
+		Digest: digest,
+	}
+
+	select {
+	case event := <-eventFired:
+		if !reflect.DeepEqual(event, expectedEvent) {
+			t.Fatalf(
+				"unexpected signature requested event\nexpected: [%v]\nactual:   [%v]",
+				expectedEvent,
+				event,
+			)
+		}
+	case <-ctx.Done():
+		t.Fatal(ctx.Err())
+	}
+}
+
+func TestOnSignatureRequested_PastBlocks(t *testing.T) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancelCtx()
+
+	localChain := initializeLocalChain(ctx)
+	eventFired := make(chan *chain.SignatureRequestedEvent)
+	keepAddress := common.Address([20]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1})
+	digest := [32]byte{1}
+
+	keep := localChain.OpenKeep(keepAddress, emptyAddress, []common.Address{})
+
+	var keepPubkey [64]byte
+	rand.Read(keepPubkey[:])
+
+	err := keep.SubmitKeepPublicKey(keepPubkey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// request the signature before the subscription is installed
+	err = localChain.RequestSignature(keepAddress, digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	subscription, err := keep.OnSignatureRequested(
+		func(event *chain.SignatureRequestedEvent) {
+			eventFired <- event
+		},
+		chain.SubscribeOpts{PastBlocks: 100},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer subscription.Unsubscribe()
+
+	select {
+	case event := <-eventFired:
+		if event.Digest != digest {
+			t.Fatalf(
+				"unexpected signature requested event digest\nexpected: [%v]\nactual:   [%v]",
+				digest,
+				event.Digest,
+			)
+		}
+	case <-ctx.Done():
+		t.Fatal(ctx.Err())
+	}
+}
+
+func TestOnSignatureRequested_NoPastBlocks(t *testing.T) {
+	ctx, cancelCtx := context.WithTimeout(
+		context.Background(),
+		100*time.Millisecond,
+	)
+	defer cancelCtx()
+
+	localChain := initializeLocalChain(ctx)
+	eventFired := make(chan *chain.SignatureRequestedEvent)
+	keepAddress := common.Address([20]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1})
+	digest := [32]byte{1}
+
+	keep := localChain.OpenKeep(keepAddress, emptyAddress, []common.Address{})
+
+	var keepPubkey [64]byte
+	rand.Read(keepPubkey[:])
+
+	err := keep.SubmitKeepPublicKey(keepPubkey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = localChain.RequestSignature(keepAddress, digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	subscription, err := keep.OnSignatureRequested(
+		func(event *chain.SignatureRequestedEvent) {
+			eventFired <- event
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer subscription.Unsubscribe()
+
+	select {
+	case event := <-eventFired:
+		t.Fatalf("unexpected signature requested event: [%v]", event)
+	case <-ctx.Done():
+	}
+}
+
+func TestSubmitKeepPublicKey(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	localChain := initializeLocalChain(ctx)
+	keepAddress := common.HexToAddress("0x41048F9B90290A2e96D07f537F3A7E97620E9e47")
+	keepPublicKey := [64]byte{11, 12, 13, 14, 15, 16}
+	expectedDuplicationError := fmt.Errorf(
+		"public key already submitted for keep [%s]",
+		keepAddress.String(),
+	)
+
+	keep := localChain.OpenKeep(keepAddress, emptyAddress, []common.Address{})
+
+	err := keep.SubmitKeepPublicKey(keepPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	onChainPubKey, err := keep.GetPublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(keepPublicKey[:]) != hex.EncodeToString(onChainPubKey) {
+		t.Errorf(
+			"unexpected result\nexpected: [%+v]\nactual:   [%+v]",
+			hex.EncodeToString(keepPublicKey[:]),
+			hex.EncodeToString(onChainPubKey),
+		)
+	}
+
+	err = keep.SubmitKeepPublicKey(keepPublicKey)
+	if !reflect.DeepEqual(expectedDuplicationError, err) {
+		t.Errorf(
+			"unexpected error\nexpected: [%+v]\nactual:   [%+v]",
+			expectedDuplicationError,
+			err,
+		)
+	}
+}
+
+func TestOnPublicKeyPublished(t *testing.T) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancelCtx()
+
+	localChain := initializeLocalChain(ctx)
+	eventFired := make(chan *chain.PublicKeyPublishedEvent, 2)
+	keepAddress := common.HexToAddress("0x41048F9B90290A2e96D07f537F3A7E97620E9e47")
+	keepPublicKey := [64]byte{11, 12, 13, 14, 15, 16}
+
+	keep := localChain.OpenKeep(keepAddress, emptyAddress, []common.Address{})
+
+	subscription, err := keep.OnPublicKeyPublished(
+		func(event *chain.PublicKeyPublishedEvent) {
+			eventFired <- event
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer subscription.Unsubscribe()
+
+	err = keep.SubmitKeepPublicKey(keepPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// duplicated submission fails and must not fire the handler
+	_ = keep.SubmitKeepPublicKey(keepPublicKey)
+
+	select {
+	case event := <-eventFired:
+		if !reflect.DeepEqual(keepPublicKey[:], event.PublicKey) {
+			t.Fatalf(
+				"unexpected published public key\nexpected: [%x]\nactual:   [%x]",
+				keepPublicKey,
+				event.PublicKey,
+			)
+		}
+	case <-ctx.Done():
+		t.Fatal(ctx.Err())
+	}
+
+	select {
+	case event := <-eventFired:
+		t.Fatalf("unexpected public key published event: [%v]", event)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestOnPublicKeyPublished_Unsubscribed(t *testing.T) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancelCtx()
+
+	localChain := initializeLocalChain(ctx)
+	eventFired := make(chan *chain.PublicKeyPublishedEvent)
+	keepAddress := common.HexToAddress("0x41048F9B90290A2e96D07f537F3A7E97620E9e47")
+	keepPublicKey := [64]byte{11, 12, 13, 14, 15, 16}
+
 	keep := localChain.OpenKeep(keepAddress, emptyAddress, []common.Address{})
-	expectedEvent := &chain.BondedECDSAKeepCreatedEvent{
-		Keep: keep,
+
+	subscription, err := keep.OnPublicKeyPublished(
+		func(event *chain.PublicKeyPublishedEvent) {
+			eventFired <- event
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	subscription.Unsubscribe()
+
+	err = keep.SubmitKeepPublicKey(keepPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case event := <-eventFired:
+		t.Fatalf("unexpected public key published event: [%v]", event)
+	case <-ctx.Done():
+	}
+}
+
+func TestOnConflictingPublicKeySubmitted(t *testing.T) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancelCtx()
+
+	localChain := initializeLocalChain(ctx)
+	eventFired := make(chan *chain.ConflictingPublicKeySubmittedEvent)
+	keepAddress := common.HexToAddress("0x41048F9B90290A2e96D07f537F3A7E97620E9e47")
+	submittingMember := common.HexToAddress("0x1")
+	conflictingPublicKey := [64]byte{21, 22, 23}
+
+	keep := localChain.OpenKeep(
+		keepAddress,
+		emptyAddress,
+		[]common.Address{submittingMember},
+	)
+
+	subscription, err := keep.OnConflictingPublicKeySubmitted(
+		func(event *chain.ConflictingPublicKeySubmittedEvent) {
+			eventFired <- event
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer subscription.Unsubscribe()
+
+	err = localChain.SubmitConflictingPublicKey(
+		keepAddress,
+		submittingMember,
+		conflictingPublicKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case event := <-eventFired:
+		if event.SubmittingMember.String() != localChainID(submittingMember).String() {
+			t.Errorf(
+				"unexpected submitting member\nexpected: [%v]\nactual:   [%v]",
+				localChainID(submittingMember),
+				event.SubmittingMember,
+			)
+		}
+		if !reflect.DeepEqual(conflictingPublicKey[:], event.ConflictingPublicKey) {
+			t.Errorf(
+				"unexpected conflicting public key\nexpected: [%x]\nactual:   [%x]",
+				conflictingPublicKey,
+				event.ConflictingPublicKey,
+			)
+		}
+	case <-ctx.Done():
+		t.Fatal(ctx.Err())
+	}
+}
+
+func TestSubmitSignature(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	localChain := initializeLocalChain(ctx)
+
+	keepAddress := common.HexToAddress("0x41048F9B90290A2e96D07f537F3A7E97620E9e47")
+	keepPublicKey := [64]byte{11, 12, 13, 14, 15, 16}
+
+	keep := localChain.OpenKeep(keepAddress, emptyAddress, []common.Address{})
+
+	err := keep.SubmitKeepPublicKey(keepPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	digest := [32]byte{17, 18}
+
+	err = localChain.RequestSignature(keepAddress, digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signature := &ecdsa.Signature{
+		R:          big.NewInt(10),
+		S:          big.NewInt(11),
+		RecoveryID: 1,
+	}
+
+	err = keep.SubmitSignature(signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := keep.PastSignatureSubmittedEvents(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(events) != 1 {
+		t.Errorf("there should be one signature submitted event")
+	}
+
+	expectedRBytes, _ := byteutils.BytesTo32Byte(signature.R.Bytes())
+	expectedSBytes, _ := byteutils.BytesTo32Byte(signature.S.Bytes())
+	expectedEvent := &chain.SignatureSubmittedEvent{
+		Digest:      digest,
+		R:           expectedRBytes,
+		S:           expectedSBytes,
+		RecoveryID:  1,
+		BlockNumber: 0,
+	}
+
+	lastEvent := events[len(events)-1]
+
+	if !reflect.DeepEqual(expectedEvent, lastEvent) {
+		t.Fatalf(
+			"unexpected signature submitted event\nexpected: [%+v]\nactual:   [%+v]",
+			expectedEvent,
+			lastEvent,
+		)
+	}
+}
+
+func TestSubmitSignature_NotRequested(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	localChain := initializeLocalChain(ctx)
+
+	keepAddress := common.HexToAddress("0x41048F9B90290A2e96D07f537F3A7E97620E9e47")
+	keepPublicKey := [64]byte{11, 12, 13, 14, 15, 16}
+
+	keep := localChain.OpenKeep(keepAddress, emptyAddress, []common.Address{})
+
+	err := keep.SubmitKeepPublicKey(keepPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signature := &ecdsa.Signature{
+		R:          big.NewInt(10),
+		S:          big.NewInt(11),
+		RecoveryID: 1,
+	}
+
+	err = keep.SubmitSignature(signature)
+	if err == nil {
+		t.Fatal("expected error for signature which has not been requested")
+	}
+
+	events, err := keep.PastSignatureSubmittedEvents(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(events) != 0 {
+		t.Errorf("there should be no signature submitted events")
+	}
+}
+
+func TestSubmitSignature_Verification(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	localChain := initializeLocalChain(ctx)
+	localChain.SetSignatureVerification(true)
+
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keepAddress := common.HexToAddress("0x41048F9B90290A2e96D07f537F3A7E97620E9e47")
+	var keepPublicKey [64]byte
+	copy(keepPublicKey[:], crypto.FromECDSAPub(&privateKey.PublicKey)[1:])
+
+	keep := localChain.OpenKeep(keepAddress, emptyAddress, []common.Address{})
+
+	err = keep.SubmitKeepPublicKey(keepPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	digest := [32]byte{17, 18}
+
+	err = localChain.RequestSignature(keepAddress, digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = keep.SubmitSignature(&ecdsa.Signature{
+		R:          big.NewInt(10),
+		S:          big.NewInt(11),
+		RecoveryID: 1,
+	})
+	if err == nil {
+		t.Fatal("expected error for signature not matching keep public key")
+	}
+
+	serializedSignature, err := crypto.Sign(digest[:], privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = keep.SubmitSignature(&ecdsa.Signature{
+		R:          new(big.Int).SetBytes(serializedSignature[:32]),
+		S:          new(big.Int).SetBytes(serializedSignature[32:64]),
+		RecoveryID: int(serializedSignature[64]),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := keep.PastSignatureSubmittedEvents(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(events) != 1 {
+		t.Errorf("there should be one signature submitted event")
+	}
+}
+
+func TestIsAwaitingSignature(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	localChain := initializeLocalChain(ctx)
+
+	keepAddress := common.HexToAddress("0x41048F9B90290A2e96D07f537F3A7E97620E9e47")
+	keepPublicKey := [64]byte{11, 12, 13, 14, 15, 16}
+
+	keep := localChain.OpenKeep(keepAddress, emptyAddress, []common.Address{})
+
+	err := keep.SubmitKeepPublicKey(keepPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	digest := [32]byte{17, 18}
+
+	err = localChain.RequestSignature(keepAddress, digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	isAwaitingSignature, err := keep.IsAwaitingSignature(digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isAwaitingSignature {
+		t.Error("keep should be awaiting for a signature for requested digest")
+	}
+
+	anotherDigest := [32]byte{18, 17}
+	isAwaitingSignature, err = keep.IsAwaitingSignature(anotherDigest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if isAwaitingSignature {
+		t.Error("keep should not be awaiting for a signature for a not requested digest")
 	}
 
-	select {
-	case event := <-eventFired:
-		if !reflect.DeepEqual(event, expectedEvent) {
-			t.Fatalf(
-				"unexpected keep creation event\nexpected: [%v]\nactual:   [%v]",
-				expectedEvent,
-				event,
-			)
-		}
-	case <-ctx.Done():
-		t.Fatal(ctx.Err())
+	signature := &ecdsa.Signature{
+		R:          big.NewInt(10),
+		S:          big.NewInt(11),
+		RecoveryID: 1,
+	}
+
+	err = keep.SubmitSignature(signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	isAwaitingSignature, err = keep.IsAwaitingSignature(digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if isAwaitingSignature {
+		t.Error("keep should not be awaiting for already provided signature")
 	}
 }
 
-func TestOnSignatureRequested(t *testing.T) {
-	ctx, cancelCtx := context.WithTimeout(context.Background(), 1*time.Second)
+func TestLatestDigest(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
 	defer cancelCtx()
 
 	localChain := initializeLocalChain(ctx)
-	eventFired := make(chan *chain.SignatureRequestedEvent)
-	keepAddress := common.Address([20]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1})
-	digest := [32]byte{1}
 
-	keep := localChain.OpenKeep(keepAddress, emptyAddress, []common.Address{})
+	keepAddress := common.HexToAddress("0x41048F9B90290A2e96D07f537F3A7E97620E9e47")
+	keepPublicKey := [64]byte{11, 12, 13, 14, 15, 16}
 
-	var keepPubkey [64]byte
-	rand.Read(keepPubkey[:])
+	keep := localChain.OpenKeep(keepAddress, emptyAddress, []common.Address{})
 
-	err := keep.SubmitKeepPublicKey(keepPubkey)
+	err := keep.SubmitKeepPublicKey(keepPublicKey)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	subscription, err := keep.OnSignatureRequested(
-		func(event *chain.SignatureRequestedEvent) {
-			eventFired <- event
-		},
-	)
+	latestDigest, err := keep.LatestDigest()
 	if err != nil {
 		t.Fatal(err)
 	}
-	defer subscription.Unsubscribe()
+	if latestDigest != [32]byte{} {
+		t.Errorf("unexpected latest digest before signature request: [%x]", latestDigest)
+	}
+
+	digest := [32]byte{17, 18}
 
 	err = localChain.RequestSignature(keepAddress, digest)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	expectedEvent := &chain.SignatureRequestedEvent{
-		Digest: digest,
+	latestDigest, err = keep.LatestDigest()
+	if err != nil {
+		t.Fatal(err)
 	}
-
-	select {
-	case event := <-eventFired:
-		if !reflect.DeepEqual(event, expectedEvent) {
-			t.Fatalf(
-				"unexpected signature requested event\nexpected: [%v]\nactual:   [%v]",
-				expectedEvent,
-				event,
-			)
-		}
-	case <-ctx.Done():
-		t.Fatal(ctx.Err())
+	if latestDigest != digest {
+		t.Errorf(
+			"unexpected latest digest\nexpected: [%x]\nactual:   [%x]",
+			digest,
+			latestDigest,
+		)
 	}
 }
 
-func TestSubmitKeepPublicKey(t *testing.T) {
+func TestSignatureRequestedBlock(t *testing.T) {
 	ctx, cancelCtx := context.WithCancel(context.Background())
 	defer cancelCtx()
 
 	localChain := initializeLocalChain(ctx)
+
 	keepAddress := common.HexToAddress("0x41048F9B90290A2e96D07f537F3A7E97620E9e47")
 	keepPublicKey := [64]byte{11, 12, 13, 14, 15, 16}
-	expectedDuplicationError := fmt.Errorf(
-		"public key already submitted for keep [%s]",
-		keepAddress.String(),
-	)
 
 	keep := localChain.OpenKeep(keepAddress, emptyAddress, []common.Address{})
 
@@ -122,140 +1050,371 @@ func TestSubmitKeepPublicKey(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	onChainPubKey, err := keep.GetPublicKey()
+	digest := [32]byte{17, 18}
+
+	expectedBlock, err := localChain.blockCounter.CurrentBlock()
 	if err != nil {
 		t.Fatal(err)
 	}
-	if hex.EncodeToString(keepPublicKey[:]) != hex.EncodeToString(onChainPubKey) {
+
+	err = localChain.RequestSignature(keepAddress, digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	requestedBlock, err := keep.SignatureRequestedBlock(digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requestedBlock != expectedBlock {
 		t.Errorf(
-			"unexpected result\nexpected: [%+v]\nactual:   [%+v]",
-			hex.EncodeToString(keepPublicKey[:]),
-			hex.EncodeToString(onChainPubKey),
+			"unexpected signature requested block\nexpected: [%v]\nactual:   [%v]",
+			expectedBlock,
+			requestedBlock,
 		)
 	}
 
-	err = keep.SubmitKeepPublicKey(keepPublicKey)
-	if !reflect.DeepEqual(expectedDuplicationError, err) {
+	anotherDigest := [32]byte{18, 17}
+	requestedBlock, err = keep.SignatureRequestedBlock(anotherDigest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requestedBlock != 0 {
 		t.Errorf(
-			"unexpected error\nexpected: [%+v]\nactual:   [%+v]",
-			expectedDuplicationError,
-			err,
+			"unexpected signature requested block for not requested digest\n"+
+				"expected: [0]\nactual:   [%v]",
+			requestedBlock,
 		)
 	}
 }
 
-func TestSubmitSignature(t *testing.T) {
+func TestGetHonestThreshold(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	localChain := initializeLocalChain(ctx)
+
+	keepAddress := common.HexToAddress("0x41048F9B90290A2e96D07f537F3A7E97620E9e47")
+	members := []common.Address{
+		common.HexToAddress("0x1"),
+		common.HexToAddress("0x2"),
+		common.HexToAddress("0x3"),
+	}
+
+	keep := localChain.OpenKeep(keepAddress, emptyAddress, members)
+
+	honestThreshold, err := keep.GetHonestThreshold()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedHonestThreshold := uint64(len(members))
+	if honestThreshold != expectedHonestThreshold {
+		t.Errorf(
+			"unexpected honest threshold\nexpected: [%v]\nactual:   [%v]",
+			expectedHonestThreshold,
+			honestThreshold,
+		)
+	}
+}
+
+func TestGetOpenedTimestamp(t *testing.T) {
 	ctx, cancelCtx := context.WithCancel(context.Background())
 	defer cancelCtx()
 
 	localChain := initializeLocalChain(ctx)
 
 	keepAddress := common.HexToAddress("0x41048F9B90290A2e96D07f537F3A7E97620E9e47")
-	keepPublicKey := [64]byte{11, 12, 13, 14, 15, 16}
 
+	beforeOpen := time.Now()
 	keep := localChain.OpenKeep(keepAddress, emptyAddress, []common.Address{})
+	afterOpen := time.Now()
 
-	err := keep.SubmitKeepPublicKey(keepPublicKey)
+	openedTimestamp, err := keep.GetOpenedTimestamp()
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	digest := [32]byte{17, 18}
+	if openedTimestamp.Before(beforeOpen) || openedTimestamp.After(afterOpen) {
+		t.Errorf(
+			"unexpected opened timestamp\nexpected between: [%v] and [%v]\nactual:   [%v]",
+			beforeOpen,
+			afterOpen,
+			openedTimestamp,
+		)
+	}
+}
 
-	err = localChain.RequestSignature(keepAddress, digest)
+func TestGetOpenedTimestamp_ManualClock(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	localChain := initializeLocalChain(ctx)
+
+	clock := NewManualClock(time.Unix(1615172517, 0))
+	localChain.SetClock(clock)
+
+	keepAddress1 := common.HexToAddress("0x41048F9B90290A2e96D07f537F3A7E97620E9e47")
+	keepAddress2 := common.HexToAddress("0x7f9bB3E3F1A9AC1F0e8dDC69fA4d93Bcc2a9B4B1")
+
+	keep1 := localChain.OpenKeep(keepAddress1, emptyAddress, []common.Address{})
+
+	clock.Advance(3 * time.Hour)
+
+	keep2 := localChain.OpenKeep(keepAddress2, emptyAddress, []common.Address{})
+
+	var tests = map[string]struct {
+		keep              chain.BondedECDSAKeepHandle
+		expectedTimestamp time.Time
+	}{
+		"keep opened before advancing the clock": {
+			keep:              keep1,
+			expectedTimestamp: time.Unix(1615172517, 0),
+		},
+		"keep opened after advancing the clock": {
+			keep:              keep2,
+			expectedTimestamp: time.Unix(1615172517, 0).Add(3 * time.Hour),
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			openedTimestamp, err := test.keep.GetOpenedTimestamp()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !openedTimestamp.Equal(test.expectedTimestamp) {
+				t.Errorf(
+					"unexpected opened timestamp\nexpected: [%v]\nactual:   [%v]",
+					test.expectedTimestamp,
+					openedTimestamp,
+				)
+			}
+		})
+	}
+}
+
+func TestKeepAge_ManualClock(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	localChain := initializeLocalChain(ctx)
+
+	clock := NewManualClock(time.Unix(1615172517, 0))
+	localChain.SetClock(clock)
+
+	keepAddress := common.HexToAddress("0x41048F9B90290A2e96D07f537F3A7E97620E9e47")
+	keep := localChain.OpenKeep(keepAddress, emptyAddress, []common.Address{})
+
+	clock.Advance(49 * time.Hour)
+
+	openedTimestamp, err := keep.GetOpenedTimestamp()
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	signature := &ecdsa.Signature{
-		R:          big.NewInt(10),
-		S:          big.NewInt(11),
-		RecoveryID: 1,
+	expectedAge := 49 * time.Hour
+	age := clock.Now().Sub(openedTimestamp)
+	if expectedAge != age {
+		t.Errorf(
+			"unexpected keep age\nexpected: [%v]\nactual:   [%v]",
+			expectedAge,
+			age,
+		)
 	}
+}
 
-	err = keep.SubmitSignature(signature)
+func TestIsActive(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	localChain := initializeLocalChain(ctx)
+
+	var tests = map[string]struct {
+		keepAddress      common.Address
+		changeStatus     func(keepAddress common.Address) error
+		expectedIsActive bool
+	}{
+		"opened keep": {
+			keepAddress:      common.HexToAddress("0x41048F9B90290A2e96D07f537F3A7E97620E9e47"),
+			changeStatus:     func(keepAddress common.Address) error { return nil },
+			expectedIsActive: true,
+		},
+		"closed keep": {
+			keepAddress:      common.HexToAddress("0x7f9bB3E3F1A9AC1F0e8dDC69fA4d93Bcc2a9B4B1"),
+			changeStatus:     localChain.CloseKeep,
+			expectedIsActive: false,
+		},
+		"terminated keep": {
+			keepAddress:      common.HexToAddress("0x65ea55c1f10491038425725dc00dffeab2a1e28a"),
+			changeStatus:     localChain.TerminateKeep,
+			expectedIsActive: false,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			keep := localChain.OpenKeep(
+				test.keepAddress,
+				emptyAddress,
+				[]common.Address{},
+			)
+
+			err := test.changeStatus(test.keepAddress)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			isActive, err := keep.IsActive()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if test.expectedIsActive != isActive {
+				t.Errorf(
+					"unexpected keep activity\nexpected: [%v]\nactual:   [%v]",
+					test.expectedIsActive,
+					isActive,
+				)
+			}
+		})
+	}
+}
+
+func TestPastKeepClosedEvents(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	localChain := initializeLocalChain(ctx)
+
+	keepAddress := common.HexToAddress("0x41048F9B90290A2e96D07f537F3A7E97620E9e47")
+	keep := localChain.OpenKeep(keepAddress, emptyAddress, []common.Address{})
+
+	err := localChain.CloseKeep(keepAddress)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	events, err := keep.PastSignatureSubmittedEvents(0)
+	events, err := keep.PastKeepClosedEvents(0)
 	if err != nil {
 		t.Fatal(err)
 	}
 
 	if len(events) != 1 {
-		t.Errorf("there should be one signature submitted event")
+		t.Fatalf(
+			"unexpected number of keep closed events\nexpected: [%v]\nactual:   [%v]",
+			1,
+			len(events),
+		)
 	}
 
-	expectedRBytes, _ := byteutils.BytesTo32Byte(signature.R.Bytes())
-	expectedSBytes, _ := byteutils.BytesTo32Byte(signature.S.Bytes())
-	expectedEvent := &chain.SignatureSubmittedEvent{
-		Digest:      digest,
-		R:           expectedRBytes,
-		S:           expectedSBytes,
-		RecoveryID:  1,
-		BlockNumber: 0,
+	// Simulate events emitted in different blocks, out of order.
+	localChain.keeps[keepAddress].keepClosedEvents = []*chain.KeepClosedEvent{
+		{BlockNumber: 15},
+		{BlockNumber: 5},
+		{BlockNumber: 10},
 	}
 
-	lastEvent := events[len(events)-1]
+	events, err = keep.PastKeepClosedEvents(10)
+	if err != nil {
+		t.Fatal(err)
+	}
 
-	if !reflect.DeepEqual(expectedEvent, lastEvent) {
-		t.Fatalf(
-			"unexpected signature submitted event\nexpected: [%+v]\nactual:   [%+v]",
-			expectedEvent,
-			lastEvent,
+	expectedEvents := []*chain.KeepClosedEvent{
+		{BlockNumber: 10},
+		{BlockNumber: 15},
+	}
+	if !reflect.DeepEqual(expectedEvents, events) {
+		t.Errorf(
+			"unexpected keep closed events\nexpected: [%+v]\nactual:   [%+v]",
+			expectedEvents,
+			events,
 		)
 	}
 }
 
-func TestIsAwaitingSignature(t *testing.T) {
+func TestPastKeepTerminatedEvents(t *testing.T) {
 	ctx, cancelCtx := context.WithCancel(context.Background())
 	defer cancelCtx()
 
 	localChain := initializeLocalChain(ctx)
 
 	keepAddress := common.HexToAddress("0x41048F9B90290A2e96D07f537F3A7E97620E9e47")
-	keepPublicKey := [64]byte{11, 12, 13, 14, 15, 16}
-
 	keep := localChain.OpenKeep(keepAddress, emptyAddress, []common.Address{})
 
-	err := keep.SubmitKeepPublicKey(keepPublicKey)
+	err := localChain.TerminateKeep(keepAddress)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	digest := [32]byte{17, 18}
-
-	err = localChain.RequestSignature(keepAddress, digest)
+	events, err := keep.PastKeepTerminatedEvents(0)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	isAwaitingSignature, err := keep.IsAwaitingSignature(digest)
-	if !isAwaitingSignature {
-		t.Error("keep should be awaiting for a signature for requested digest")
+	if len(events) != 1 {
+		t.Fatalf(
+			"unexpected number of keep terminated events\nexpected: [%v]\nactual:   [%v]",
+			1,
+			len(events),
+		)
 	}
 
-	anotherDigest := [32]byte{18, 17}
-	isAwaitingSignature, err = keep.IsAwaitingSignature(anotherDigest)
-	if !isAwaitingSignature {
-		t.Error("keep should not be awaiting for a signature for a not requested digest")
+	// Simulate events emitted in different blocks, out of order.
+	localChain.keeps[keepAddress].keepTerminatedEvents = []*chain.KeepTerminatedEvent{
+		{BlockNumber: 15},
+		{BlockNumber: 5},
+		{BlockNumber: 10},
 	}
 
-	signature := &ecdsa.Signature{
-		R:          big.NewInt(10),
-		S:          big.NewInt(11),
-		RecoveryID: 1,
+	events, err = keep.PastKeepTerminatedEvents(10)
+	if err != nil {
+		t.Fatal(err)
 	}
 
-	err = keep.SubmitSignature(signature)
+	expectedEvents := []*chain.KeepTerminatedEvent{
+		{BlockNumber: 10},
+		{BlockNumber: 15},
+	}
+	if !reflect.DeepEqual(expectedEvents, events) {
+		t.Errorf(
+			"unexpected keep terminated events\nexpected: [%+v]\nactual:   [%+v]",
+			expectedEvents,
+			events,
+		)
+	}
+}
+
+func TestBlockTimestamp_BlockTime(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	localChain := initializeLocalChain(ctx)
+
+	start := time.Unix(1615172517, 0)
+	localChain.SetClock(NewManualClock(start))
+	localChain.SetBlockTime(15 * time.Second)
+
+	currentBlock, err := localChain.BlockCounter().CurrentBlock()
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	isAwaitingSignature, err = keep.IsAwaitingSignature(digest)
-	if !isAwaitingSignature {
-		t.Error("keep should be awaiting for already provided signature")
+	blockTimestamp, err := localChain.BlockTimestamp(
+		new(big.Int).SetUint64(currentBlock + 4),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedBlockTimestamp := uint64(start.Add(60 * time.Second).Unix())
+	if blockTimestamp != expectedBlockTimestamp {
+		t.Errorf(
+			"unexpected block timestamp\nexpected: [%v]\nactual:   [%v]",
+			expectedBlockTimestamp,
+			blockTimestamp,
+		)
 	}
 }
 
@@ -0,0 +1,220 @@
+package local
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/keep-network/keep-ecdsa/pkg/chain"
+)
+
+// ChainSnapshot is a copy of the TBTCLocalChain state captured by
+// TBTCLocalChain.Snapshot. It can be restored any number of times with
+// TBTCLocalChain.Restore. Registered event handlers are not part of the
+// snapshot; subscriptions made before restoring remain active.
+type ChainSnapshot struct {
+	keepAddresses  []common.Address
+	keeps          map[common.Address]*keepSnapshot
+	authorizations map[common.Address]bool
+	bonds          map[bondKey]*big.Int
+
+	deposits                  map[string]*localDeposit
+	alwaysFailingTransactions map[string]bool
+	logger                    ChainLogger
+}
+
+type keepSnapshot struct {
+	owner             common.Address
+	application       common.Address
+	publicKey         [64]byte
+	members           []common.Address
+	honestThreshold   uint64
+	status            keepStatus
+	latestDigest      [32]byte
+	openedTimestamp   time.Time
+	awaitingSignature bool
+
+	signatureRequestedEvents []*chain.SignatureRequestedEvent
+	signatureSubmittedEvents []*chain.SignatureSubmittedEvent
+}
+
+// Snapshot captures the current state of keeps, bonds, deposits and logger
+// counters of the chain.
+func (tlc *TBTCLocalChain) Snapshot() ChainSnapshot {
+	tlc.tbtcLocalChainMutex.Lock()
+	defer tlc.tbtcLocalChainMutex.Unlock()
+
+	tlc.localChainMutex.Lock()
+	defer tlc.localChainMutex.Unlock()
+
+	snapshot := ChainSnapshot{
+		keepAddresses:             append([]common.Address{}, tlc.keepAddresses...),
+		keeps:                     make(map[common.Address]*keepSnapshot),
+		authorizations:            make(map[common.Address]bool),
+		bonds:                     make(map[bondKey]*big.Int),
+		deposits:                  make(map[string]*localDeposit),
+		alwaysFailingTransactions: make(map[string]bool),
+		logger:                    *tlc.logger,
+	}
+
+	for address, keep := range tlc.keeps {
+		snapshot.keeps[address] = &keepSnapshot{
+			owner:             keep.owner,
+			application:       keep.application,
+			publicKey:         keep.publicKey,
+			members:           append([]common.Address{}, keep.members...),
+			honestThreshold:   keep.honestThreshold,
+			status:            keep.status,
+			latestDigest:      keep.latestDigest,
+			openedTimestamp:   keep.openedTimestamp,
+			awaitingSignature: keep.awaitingSignature,
+			signatureRequestedEvents: append(
+				[]*chain.SignatureRequestedEvent{},
+				keep.signatureRequestedEvents...,
+			),
+			signatureSubmittedEvents: append(
+				[]*chain.SignatureSubmittedEvent{},
+				keep.signatureSubmittedEvents...,
+			),
+		}
+	}
+
+	for operator, authorized := range tlc.authorizations {
+		snapshot.authorizations[operator] = authorized
+	}
+
+	for key, amount := range tlc.bonds {
+		snapshot.bonds[key] = new(big.Int).Set(amount)
+	}
+
+	for address, deposit := range tlc.deposits {
+		snapshot.deposits[address] = deposit.copy()
+	}
+
+	for method, failing := range tlc.alwaysFailingTransactions {
+		snapshot.alwaysFailingTransactions[method] = failing
+	}
+
+	return snapshot
+}
+
+// Restore brings keeps, bonds, deposits and logger counters of the chain back
+// to the state captured in the snapshot. Keeps opened after the snapshot was
+// taken are removed. Keep handles obtained before restoring remain valid.
+func (tlc *TBTCLocalChain) Restore(snapshot ChainSnapshot) {
+	tlc.tbtcLocalChainMutex.Lock()
+	defer tlc.tbtcLocalChainMutex.Unlock()
+
+	tlc.localChainMutex.Lock()
+	defer tlc.localChainMutex.Unlock()
+
+	for address := range tlc.keeps {
+		if _, ok := snapshot.keeps[address]; !ok {
+			delete(tlc.keeps, address)
+		}
+	}
+
+	for address, keepState := range snapshot.keeps {
+		keep, ok := tlc.keeps[address]
+		if !ok {
+			// Keeps are never removed from the chain, so this happens only
+			// if the snapshot comes from a different chain instance.
+			keep = tlc.newLocalKeep(
+				address,
+				keepState.owner,
+				keepState.application,
+				nil,
+			)
+			tlc.keeps[address] = keep
+		}
+
+		keep.owner = keepState.owner
+		keep.application = keepState.application
+		keep.publicKey = keepState.publicKey
+		keep.members = append([]common.Address{}, keepState.members...)
+		keep.honestThreshold = keepState.honestThreshold
+		keep.status = keepState.status
+		keep.latestDigest = keepState.latestDigest
+		keep.openedTimestamp = keepState.openedTimestamp
+		keep.awaitingSignature = keepState.awaitingSignature
+		keep.signatureRequestedEvents = append(
+			[]*chain.SignatureRequestedEvent{},
+			keepState.signatureRequestedEvents...,
+		)
+		keep.signatureSubmittedEvents = append(
+			[]*chain.SignatureSubmittedEvent{},
+			keepState.signatureSubmittedEvents...,
+		)
+	}
+
+	tlc.keepAddresses = append([]common.Address{}, snapshot.keepAddresses...)
+
+	tlc.authorizations = make(map[common.Address]bool)
+	for operator, authorized := range snapshot.authorizations {
+		tlc.authorizations[operator] = authorized
+	}
+
+	tlc.bonds = make(map[bondKey]*big.Int)
+	for key, amount := range snapshot.bonds {
+		tlc.bonds[key] = new(big.Int).Set(amount)
+	}
+
+	tlc.deposits = make(map[string]*localDeposit)
+	for address, deposit := range snapshot.deposits {
+		tlc.deposits[address] = deposit.copy()
+	}
+
+	tlc.alwaysFailingTransactions = make(map[string]bool)
+	for method, failing := range snapshot.alwaysFailingTransactions {
+		tlc.alwaysFailingTransactions[method] = failing
+	}
+
+	// Update the logger in place so references obtained with Logger() observe
+	// the restored counters.
+	*tlc.logger = snapshot.logger
+}
+
+func (ld *localDeposit) copy() *localDeposit {
+	deposit := &localDeposit{
+		keepAddress:      ld.keepAddress,
+		state:            ld.state,
+		createdAtBlock:   ld.createdAtBlock,
+		redemptionDigest: ld.redemptionDigest,
+		redemptionRequestedEvents: append(
+			[]*chain.DepositRedemptionRequestedEvent{},
+			ld.redemptionRequestedEvents...,
+		),
+	}
+
+	if ld.pubkey != nil {
+		deposit.pubkey = append([]byte{}, ld.pubkey...)
+	}
+
+	if ld.fundingInfo != nil {
+		fundingInfo := *ld.fundingInfo
+		if fundingInfo.FundedAt != nil {
+			fundingInfo.FundedAt = new(big.Int).Set(fundingInfo.FundedAt)
+		}
+		deposit.fundingInfo = &fundingInfo
+	}
+
+	if ld.utxoValue != nil {
+		deposit.utxoValue = new(big.Int).Set(ld.utxoValue)
+	}
+
+	if ld.redemptionFee != nil {
+		deposit.redemptionFee = new(big.Int).Set(ld.redemptionFee)
+	}
+
+	if ld.redemptionSignature != nil {
+		redemptionSignature := *ld.redemptionSignature
+		deposit.redemptionSignature = &redemptionSignature
+	}
+
+	if ld.redemptionProof != nil {
+		redemptionProof := *ld.redemptionProof
+		deposit.redemptionProof = &redemptionProof
+	}
+
+	return deposit
+}
@@ -7,7 +7,9 @@ import (
 	"fmt"
 	"math/big"
 	"math/rand"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 
@@ -17,6 +19,7 @@ import (
 
 const (
 	defaultInitialRedemptionFee = 10
+	defaultSigningGroupSize     = 3
 	defaultUtxoValueHex         = "8096980000000000" // 10000000
 	defaultFundedAt             = 1615172517
 	previousTransactionHashHex  = "c27c3bfa8293ac6b303b9f7455ae23b7c24b8814915a6511976027064efc4d51"
@@ -27,9 +30,10 @@ const (
 var tbtcApplicationID = common.Big1
 
 type localDeposit struct {
-	keepAddress string
-	pubkey      []byte
-	state       chain.DepositState
+	keepAddress    string
+	pubkey         []byte
+	state          chain.DepositState
+	createdAtBlock uint64
 
 	fundingInfo *chain.FundingInfo
 
@@ -57,6 +61,9 @@ type ChainLogger struct {
 	retrieveSignerPubkeyCalls       int
 	provideRedemptionSignatureCalls int
 	increaseRedemptionFeeCalls      int
+	provideRedemptionProofCalls     int
+	provideBTCFundingProofCalls     int
+	submitSignatureCalls            int
 	keepAddressCalls                int
 }
 
@@ -96,22 +103,62 @@ func (cl *ChainLogger) KeepAddressCalls() int {
 	return cl.keepAddressCalls
 }
 
+func (cl *ChainLogger) logProvideRedemptionProofCall() {
+	cl.provideRedemptionProofCalls++
+}
+
+// ProvideRedemptionProofCalls returns the number of times we've tried to provide the redemption proof
+func (cl *ChainLogger) ProvideRedemptionProofCalls() int {
+	return cl.provideRedemptionProofCalls
+}
+
+func (cl *ChainLogger) logProvideBTCFundingProofCall() {
+	cl.provideBTCFundingProofCalls++
+}
+
+// ProvideBTCFundingProofCalls returns the number of times we've tried to provide the funding proof
+func (cl *ChainLogger) ProvideBTCFundingProofCalls() int {
+	return cl.provideBTCFundingProofCalls
+}
+
+func (cl *ChainLogger) logSubmitSignatureCall() {
+	cl.submitSignatureCalls++
+}
+
+// SubmitSignatureCalls returns the number of times we've tried to submit a keep signature
+func (cl *ChainLogger) SubmitSignatureCalls() int {
+	return cl.submitSignatureCalls
+}
+
+// Reset zeroes all the counters so the logger can be reused between tests
+func (cl *ChainLogger) Reset() {
+	*cl = ChainLogger{}
+}
+
 // TBTCLocalChain represents variables and state relative to the TBTC chain
 type TBTCLocalChain struct {
 	*localChain
 
 	tbtcLocalChainMutex sync.Mutex
 
-	logger *ChainLogger
-
 	alwaysFailingTransactions map[string]bool
 
+	// redemptionProofTimeout is the on-chain redemption proof timeout, zero
+	// if it has not been set.
+	redemptionProofTimeout time.Duration
+
 	deposits                              map[string]*localDeposit
 	depositCreatedHandlers                map[int]func(depositAddress string)
 	depositRegisteredPubkeyHandlers       map[int]func(depositAddress string)
+	depositFundedHandlers                 map[int]func(depositAddress string)
 	depositRedemptionRequestedHandlers    map[int]func(depositAddress string)
 	depositGotRedemptionSignatureHandlers map[int]func(depositAddress string)
 	depositRedeemedHandlers               map[int]func(depositAddress string)
+
+	// lastDepositHandlerID is the ID of the most recently installed deposit
+	// event handler. Deposit handler IDs are sequential so handlers can be
+	// notified in the order they were installed.
+	lastDepositHandlerID int
 }
 
 func (lc *localChain) TBTCApplicationHandle() (chain.TBTCHandle, error) {
@@ -122,12 +169,12 @@ func (lc *localChain) TBTCApplicationHandle() (chain.TBTCHandle, error) {
 func NewTBTCLocalChain(ctx context.Context) *TBTCLocalChain {
 	return &TBTCLocalChain{
 		localChain: Connect(ctx).(*localChain),
-		logger:     &ChainLogger{},
 
 		alwaysFailingTransactions:             make(map[string]bool),
 		deposits:                              make(map[string]*localDeposit),
 		depositCreatedHandlers:                make(map[int]func(depositAddress string)),
 		depositRegisteredPubkeyHandlers:       make(map[int]func(depositAddress string)),
+		depositFundedHandlers:                 make(map[int]func(depositAddress string)),
 		depositRedemptionRequestedHandlers:    make(map[int]func(depositAddress string)),
 		depositGotRedemptionSignatureHandlers: make(map[int]func(depositAddress string)),
 		depositRedeemedHandlers:               make(map[int]func(depositAddress string)),
@@ -169,6 +216,64 @@ func (tlc *TBTCLocalChain) UpdateStatusForApplication() error {
 	panic("implement")
 }
 
+// nextDepositHandlerID returns the ID for a newly installed deposit event
+// handler. It should be called with the tBTC chain mutex held.
+func (tlc *TBTCLocalChain) nextDepositHandlerID() int {
+	tlc.lastDepositHandlerID++
+	return tlc.lastDepositHandlerID
+}
+
+// UnsubscribeAll removes all deposit event handlers installed on the chain,
+// so no handler is invoked on subsequent deposit events. Handlers are removed
+// in place so those already being notified are not invoked either.
+func (tlc *TBTCLocalChain) UnsubscribeAll() {
+	tlc.tbtcLocalChainMutex.Lock()
+	defer tlc.tbtcLocalChainMutex.Unlock()
+
+	for _, handlers := range []map[int]func(depositAddress string){
+		tlc.depositCreatedHandlers,
+		tlc.depositRegisteredPubkeyHandlers,
+		tlc.depositFundedHandlers,
+		tlc.depositRedemptionRequestedHandlers,
+		tlc.depositGotRedemptionSignatureHandlers,
+		tlc.depositRedeemedHandlers,
+	} {
+		for handlerID := range handlers {
+			delete(handlers, handlerID)
+		}
+	}
+}
+
+// notifyDepositHandlers invokes the given deposit event handlers with the
+// deposit address. Each handler is invoked in a separate goroutine and the
+// goroutines are started in the order handlers were installed. A handler
+// unsubscribed before its goroutine runs is not invoked. It should be called
+// with the tBTC chain mutex held.
+func (tlc *TBTCLocalChain) notifyDepositHandlers(
+	handlers map[int]func(depositAddress string),
+	depositAddress string,
+) {
+	handlerIDs := make([]int, 0, len(handlers))
+	for handlerID := range handlers {
+		handlerIDs = append(handlerIDs, handlerID)
+	}
+	sort.Ints(handlerIDs)
+
+	for _, handlerID := range handlerIDs {
+		go func(handlerID int) {
+			tlc.tbtcLocalChainMutex.Lock()
+			handler, ok := handlers[handlerID]
+			tlc.tbtcLocalChainMutex.Unlock()
+
+			if !ok {
+				return
+			}
+
+			handler(depositAddress)
+		}(handlerID)
+	}
+}
+
 // CreateDeposit creates a new deposit by mutating the local TBTC chain
 func (tlc *TBTCLocalChain) CreateDeposit(
 	depositAddress string,
@@ -177,23 +282,37 @@ func (tlc *TBTCLocalChain) CreateDeposit(
 	tlc.tbtcLocalChainMutex.Lock()
 	defer tlc.tbtcLocalChainMutex.Unlock()
 
+	currentBlock, err := tlc.BlockCounter().CurrentBlock()
+	if err != nil {
+		panic(err)
+	}
+
 	keepAddress := generateAddress()
 	tlc.OpenKeep(keepAddress, common.HexToAddress(depositAddress), signers)
 
 	tlc.deposits[depositAddress] = &localDeposit{
-		keepAddress: keepAddress.Hex(),
-		state:       chain.AwaitingSignerSetup,
+		keepAddress:    keepAddress.Hex(),
+		state:          chain.AwaitingSignerSetup,
+		createdAtBlock: currentBlock,
 		fundingInfo: &chain.FundingInfo{
 			FundedAt: big.NewInt(0),
 		},
 		redemptionRequestedEvents: make([]*chain.DepositRedemptionRequestedEvent, 0),
 	}
 
-	for _, handler := range tlc.depositCreatedHandlers {
-		go func(handler func(depositAddress string), depositAddress string) {
-			handler(depositAddress)
-		}(handler, depositAddress)
-	}
+	tlc.notifyDepositHandlers(tlc.depositCreatedHandlers, depositAddress)
+}
+
+// CreateDepositWithRandomSigningGroup creates a new deposit by mutating the
+// local TBTC chain. The deposit keep is opened with a random signing group
+// of the default size, which does not include this operator.
+func (tlc *TBTCLocalChain) CreateDepositWithRandomSigningGroup(
+	depositAddress string,
+) {
+	tlc.CreateDeposit(
+		depositAddress,
+		RandomSigningGroup(defaultSigningGroupSize),
+	)
 }
 
 // OnDepositCreated installs a callback that is invoked when a
@@ -204,7 +323,7 @@ func (tlc *TBTCLocalChain) OnDepositCreated(
 	tlc.tbtcLocalChainMutex.Lock()
 	defer tlc.tbtcLocalChainMutex.Unlock()
 
-	handlerID := generateHandlerID()
+	handlerID := tlc.nextDepositHandlerID()
 
 	tlc.depositCreatedHandlers[handlerID] = handler
 
@@ -224,7 +343,7 @@ func (tlc *TBTCLocalChain) OnDepositRegisteredPubkey(
 	tlc.tbtcLocalChainMutex.Lock()
 	defer tlc.tbtcLocalChainMutex.Unlock()
 
-	handlerID := generateHandlerID()
+	handlerID := tlc.nextDepositHandlerID()
 
 	tlc.depositRegisteredPubkeyHandlers[handlerID] = handler
 
@@ -237,11 +356,18 @@ func (tlc *TBTCLocalChain) OnDepositRegisteredPubkey(
 }
 
 // FundDeposit sets funding info for the deposit. It simulates result of providing
-// a funding proof for the deposit.
+// a funding proof for the deposit. A deposit awaiting the funding proof is
+// moved to the Active state.
 func (tlc *TBTCLocalChain) FundDeposit(depositAddress string) {
 	tlc.tbtcLocalChainMutex.Lock()
 	defer tlc.tbtcLocalChainMutex.Unlock()
 
+	tlc.fundDeposit(depositAddress)
+}
+
+// fundDeposit sets the default funding info for the deposit. It should be
+// called with the tBTC chain mutex held.
+func (tlc *TBTCLocalChain) fundDeposit(depositAddress string) {
 	utxoValueBytesSlice, err := hex.DecodeString(defaultUtxoValueHex)
 	if err != nil {
 		panic(err)
@@ -257,6 +383,54 @@ func (tlc *TBTCLocalChain) FundDeposit(depositAddress string) {
 	}
 
 	tlc.deposits[depositAddress].utxoValue = fromLittleEndianBytes(utxoValueBytes)
+
+	if tlc.deposits[depositAddress].state == chain.AwaitingBtcFundingProof {
+		tlc.deposits[depositAddress].state = chain.Active
+	}
+}
+
+// ProvideBTCFundingProof provides the funding proof for the deposit awaiting
+// it. The proof is not validated; the deposit is funded with the default
+// funding info and moved to the Active state.
+func (tlc *TBTCLocalChain) ProvideBTCFundingProof(
+	depositAddress string,
+	txVersion [4]uint8,
+	txInputVector []uint8,
+	txOutputVector []uint8,
+	txLocktime [4]uint8,
+	fundingOutputIndex uint8,
+	merkleProof []uint8,
+	txIndexInBlock *big.Int,
+	bitcoinHeaders []uint8,
+) error {
+	tlc.tbtcLocalChainMutex.Lock()
+	defer tlc.tbtcLocalChainMutex.Unlock()
+
+	tlc.logger.logProvideBTCFundingProofCall()
+
+	deposit, ok := tlc.deposits[depositAddress]
+	if !ok {
+		return fmt.Errorf(
+			"no deposit with address [%v]: [%w]",
+			depositAddress,
+			chain.ErrDepositNotFound,
+		)
+	}
+
+	if deposit.state != chain.AwaitingBtcFundingProof {
+		return fmt.Errorf(
+			"deposit [%v] is not awaiting btc funding proof; "+
+				"current state: [%v]",
+			depositAddress,
+			deposit.state,
+		)
+	}
+
+	tlc.fundDeposit(depositAddress)
+
+	tlc.notifyDepositHandlers(tlc.depositFundedHandlers, depositAddress)
+
+	return nil
 }
 
 // RedeemDeposit initiates the redemption process which involves trading the
@@ -267,7 +441,11 @@ func (tlc *TBTCLocalChain) RedeemDeposit(depositAddress string) error {
 
 	deposit, ok := tlc.deposits[depositAddress]
 	if !ok {
-		return fmt.Errorf("no deposit with address [%v]", depositAddress)
+		return fmt.Errorf(
+			"no deposit with address [%v]: [%w]",
+			depositAddress,
+			chain.ErrDepositNotFound,
+		)
 	}
 
 	if !bytes.Equal(
@@ -300,11 +478,7 @@ func (tlc *TBTCLocalChain) RedeemDeposit(depositAddress string) error {
 		return err
 	}
 
-	for _, handler := range tlc.depositRedemptionRequestedHandlers {
-		go func(handler func(depositAddress string), depositAddress string) {
-			handler(depositAddress)
-		}(handler, depositAddress)
-	}
+	tlc.notifyDepositHandlers(tlc.depositRedemptionRequestedHandlers, depositAddress)
 
 	currentBlock, err := tlc.BlockCounter().CurrentBlock()
 	if err != nil {
@@ -327,6 +501,26 @@ func (tlc *TBTCLocalChain) RedeemDeposit(depositAddress string) error {
 	return nil
 }
 
+// OnDepositFunded installs a callback that is invoked when a
+// local-chain notification of a deposit funding is seen.
+func (tlc *TBTCLocalChain) OnDepositFunded(
+	handler func(depositAddress string),
+) subscription.EventSubscription {
+	tlc.tbtcLocalChainMutex.Lock()
+	defer tlc.tbtcLocalChainMutex.Unlock()
+
+	handlerID := tlc.nextDepositHandlerID()
+
+	tlc.depositFundedHandlers[handlerID] = handler
+
+	return subscription.NewEventSubscription(func() {
+		tlc.tbtcLocalChainMutex.Lock()
+		defer tlc.tbtcLocalChainMutex.Unlock()
+
+		delete(tlc.depositFundedHandlers, handlerID)
+	})
+}
+
 // OnDepositRedemptionRequested installs a callback that is invoked when a
 // redemption is requested.
 func (tlc *TBTCLocalChain) OnDepositRedemptionRequested(
@@ -335,7 +529,7 @@ func (tlc *TBTCLocalChain) OnDepositRedemptionRequested(
 	tlc.tbtcLocalChainMutex.Lock()
 	defer tlc.tbtcLocalChainMutex.Unlock()
 
-	handlerID := generateHandlerID()
+	handlerID := tlc.nextDepositHandlerID()
 
 	tlc.depositRedemptionRequestedHandlers[handlerID] = handler
 
@@ -355,7 +549,7 @@ func (tlc *TBTCLocalChain) OnDepositGotRedemptionSignature(
 	tlc.tbtcLocalChainMutex.Lock()
 	defer tlc.tbtcLocalChainMutex.Unlock()
 
-	handlerID := generateHandlerID()
+	handlerID := tlc.nextDepositHandlerID()
 
 	tlc.depositGotRedemptionSignatureHandlers[handlerID] = handler
 
@@ -375,7 +569,7 @@ func (tlc *TBTCLocalChain) OnDepositRedeemed(
 	tlc.tbtcLocalChainMutex.Lock()
 	defer tlc.tbtcLocalChainMutex.Unlock()
 
-	handlerID := generateHandlerID()
+	handlerID := tlc.nextDepositHandlerID()
 
 	tlc.depositRedeemedHandlers[handlerID] = handler
 
@@ -387,7 +581,37 @@ func (tlc *TBTCLocalChain) OnDepositRedeemed(
 	})
 }
 
-// PastDepositRedemptionRequestedEvents the redemption requested events relevant to a particular deposit
+// PastDepositCreatedEvents returns the created events of all deposits which
+// occurred at or after the provided start block. Returned events are sorted
+// by the block number in the ascending order.
+func (tlc *TBTCLocalChain) PastDepositCreatedEvents(
+	startBlock uint64,
+) ([]*chain.DepositCreatedEvent, error) {
+	tlc.tbtcLocalChainMutex.Lock()
+	defer tlc.tbtcLocalChainMutex.Unlock()
+
+	result := make([]*chain.DepositCreatedEvent, 0)
+	for depositAddress, deposit := range tlc.deposits {
+		if deposit.createdAtBlock >= startBlock {
+			result = append(result, &chain.DepositCreatedEvent{
+				DepositAddress: depositAddress,
+				KeepAddress:    deposit.keepAddress,
+				BlockNumber:    deposit.createdAtBlock,
+			})
+		}
+	}
+
+	// Make sure events are sorted by block number in ascending order.
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].BlockNumber < result[j].BlockNumber
+	})
+
+	return result, nil
+}
+
+// PastDepositRedemptionRequestedEvents returns the redemption requested events
+// of a particular deposit which occurred at or after the provided start block.
+// Returned events are sorted by the block number in the ascending order.
 func (tlc *TBTCLocalChain) PastDepositRedemptionRequestedEvents(
 	startBlock uint64,
 	depositAddress string,
@@ -397,10 +621,26 @@ func (tlc *TBTCLocalChain) PastDepositRedemptionRequestedEvents(
 
 	deposit, ok := tlc.deposits[depositAddress]
 	if !ok {
-		return nil, fmt.Errorf("no deposit with address [%v]", depositAddress)
+		return nil, fmt.Errorf(
+			"no deposit with address [%v]: [%w]",
+			depositAddress,
+			chain.ErrDepositNotFound,
+		)
 	}
 
-	return deposit.redemptionRequestedEvents, nil
+	result := make([]*chain.DepositRedemptionRequestedEvent, 0)
+	for _, event := range deposit.redemptionRequestedEvents {
+		if event.BlockNumber >= startBlock {
+			result = append(result, event)
+		}
+	}
+
+	// Make sure events are sorted by block number in ascending order.
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].BlockNumber < result[j].BlockNumber
+	})
+
+	return result, nil
 }
 
 // Keep returns the keep for a particular deposit
@@ -410,9 +650,17 @@ func (tlc *TBTCLocalChain) Keep(depositAddress string) (chain.BondedECDSAKeepHan
 
 	tlc.logger.logKeepAddressCall()
 
+	if err := validateDepositAddress(depositAddress); err != nil {
+		return nil, err
+	}
+
 	deposit, ok := tlc.deposits[depositAddress]
 	if !ok {
-		return nil, fmt.Errorf("no deposit with address [%v]", depositAddress)
+		return nil, fmt.Errorf(
+			"no deposit with address [%v]: [%w]",
+			depositAddress,
+			chain.ErrDepositNotFound,
+		)
 	}
 
 	return tlc.GetKeepWithID(
@@ -420,6 +668,66 @@ func (tlc *TBTCLocalChain) Keep(depositAddress string) (chain.BondedECDSAKeepHan
 	)
 }
 
+// IsDepositKeepActive checks if the underlying keep for the provided deposit
+// is still active.
+func (tlc *TBTCLocalChain) IsDepositKeepActive(
+	depositAddress string,
+) (bool, error) {
+	keep, err := tlc.Keep(depositAddress)
+	if err != nil {
+		return false, err
+	}
+
+	return keep.IsActive()
+}
+
+// GetDepositsForOperator returns addresses of all deposits whose keeps
+// include the given operator as a member, in the order the deposits were
+// created.
+func (tlc *TBTCLocalChain) GetDepositsForOperator(
+	operator chain.ID,
+) ([]string, error) {
+	operatorAddress, err := fromChainID(operator)
+	if err != nil {
+		return nil, err
+	}
+
+	tlc.tbtcLocalChainMutex.Lock()
+	defer tlc.tbtcLocalChainMutex.Unlock()
+
+	// lock upstream mutex to access `keeps` map safely
+	tlc.localChainMutex.Lock()
+	defer tlc.localChainMutex.Unlock()
+
+	depositAddresses := make([]string, 0)
+	for depositAddress, deposit := range tlc.deposits {
+		keep, ok := tlc.keeps[common.HexToAddress(deposit.keepAddress)]
+		if !ok {
+			continue
+		}
+
+		for _, member := range keep.members {
+			if member == operatorAddress {
+				depositAddresses = append(depositAddresses, depositAddress)
+				break
+			}
+		}
+	}
+
+	sort.SliceStable(depositAddresses, func(i, j int) bool {
+		first := tlc.deposits[depositAddresses[i]]
+		second := tlc.deposits[depositAddresses[j]]
+
+		if first.createdAtBlock != second.createdAtBlock {
+			return first.createdAtBlock < second.createdAtBlock
+		}
+
+		return depositAddresses[i] < depositAddresses[j]
+	})
+
+	return depositAddresses, nil
+}
+
 // RetrieveSignerPubkey enriches the referenced deposit with the signer public
 // key and moves the state to AwaitingBtcFundingProof
 func (tlc *TBTCLocalChain) RetrieveSignerPubkey(depositAddress string) error {
@@ -428,9 +736,21 @@ func (tlc *TBTCLocalChain) RetrieveSignerPubkey(depositAddress string) error {
 
 	tlc.logger.logRetrieveSignerPubkeyCall()
 
+	if err := validateDepositAddress(depositAddress); err != nil {
+		return err
+	}
+
+	if _, exists := tlc.alwaysFailingTransactions["RetrieveSignerPubkey"]; exists {
+		return fmt.Errorf("always failing transaction")
+	}
+
 	deposit, ok := tlc.deposits[depositAddress]
 	if !ok {
-		return fmt.Errorf("no deposit with address [%v]", depositAddress)
+		return fmt.Errorf(
+			"no deposit with address [%v]: [%w]",
+			depositAddress,
+			chain.ErrDepositNotFound,
+		)
 	}
 
 	if len(deposit.pubkey) > 0 {
@@ -447,8 +767,9 @@ func (tlc *TBTCLocalChain) RetrieveSignerPubkey(depositAddress string) error {
 	keep, ok := tlc.keeps[common.HexToAddress(deposit.keepAddress)]
 	if !ok {
 		return fmt.Errorf(
-			"could not find keep for deposit [%v]",
+			"could not find keep for deposit [%v]: [%w]",
 			depositAddress,
+			chain.ErrKeepNotFound,
 		)
 	}
 
@@ -463,11 +784,7 @@ func (tlc *TBTCLocalChain) RetrieveSignerPubkey(depositAddress string) error {
 	deposit.pubkey = keep.publicKey[:]
 	deposit.state = chain.AwaitingBtcFundingProof
 
-	for _, handler := range tlc.depositRegisteredPubkeyHandlers {
-		go func(handler func(depositAddress string), depositAddress string) {
-			handler(depositAddress)
-		}(handler, depositAddress)
-	}
+	tlc.notifyDepositHandlers(tlc.depositRegisteredPubkeyHandlers, depositAddress)
 
 	return nil
 }
@@ -485,13 +802,21 @@ func (tlc *TBTCLocalChain) ProvideRedemptionSignature(
 
 	tlc.logger.logProvideRedemptionSignatureCall()
 
+	if err := validateDepositAddress(depositAddress); err != nil {
+		return err
+	}
+
 	if _, exists := tlc.alwaysFailingTransactions["ProvideRedemptionSignature"]; exists {
 		return fmt.Errorf("always failing transaction")
 	}
 
 	deposit, ok := tlc.deposits[depositAddress]
 	if !ok {
-		return fmt.Errorf("no deposit with address [%v]", depositAddress)
+		return fmt.Errorf(
+			"no deposit with address [%v]: [%w]",
+			depositAddress,
+			chain.ErrDepositNotFound,
+		)
 	}
 
 	if deposit.redemptionDigest == [32]byte{} {
@@ -512,11 +837,7 @@ func (tlc *TBTCLocalChain) ProvideRedemptionSignature(
 		S: s,
 	}
 
-	for _, handler := range tlc.depositGotRedemptionSignatureHandlers {
-		go func(handler func(depositAddress string), depositAddress string) {
-			handler(depositAddress)
-		}(handler, depositAddress)
-	}
+	tlc.notifyDepositHandlers(tlc.depositGotRedemptionSignatureHandlers, depositAddress)
 
 	return nil
 }
@@ -533,13 +854,21 @@ func (tlc *TBTCLocalChain) IncreaseRedemptionFee(
 
 	tlc.logger.logIncreaseRedemptionFeeCall()
 
+	if err := validateDepositAddress(depositAddress); err != nil {
+		return err
+	}
+
 	if _, exists := tlc.alwaysFailingTransactions["IncreaseRedemptionFee"]; exists {
 		return fmt.Errorf("always failing transaction")
 	}
 
 	deposit, ok := tlc.deposits[depositAddress]
 	if !ok {
-		return fmt.Errorf("no deposit with address [%v]", depositAddress)
+		return fmt.Errorf(
+			"no deposit with address [%v]: [%w]",
+			depositAddress,
+			chain.ErrDepositNotFound,
+		)
 	}
 
 	if deposit.redemptionSignature == nil {
@@ -561,6 +890,11 @@ func (tlc *TBTCLocalChain) IncreaseRedemptionFee(
 
 	newOutputValue := fromLittleEndianBytes(newOutputValueBytes)
 
+	// lower output value means higher fee paid from the deposit utxo
+	if newOutputValue.Cmp(previousOutputValue) >= 0 {
+		return fmt.Errorf("new output value does not increase the fee")
+	}
+
 	if new(big.Int).Sub(previousOutputValue, newOutputValue).Cmp(
 		big.NewInt(defaultInitialRedemptionFee),
 	) != 0 {
@@ -588,11 +922,7 @@ func (tlc *TBTCLocalChain) IncreaseRedemptionFee(
 		return err
 	}
 
-	for _, handler := range tlc.depositRedemptionRequestedHandlers {
-		go func(handler func(depositAddress string), depositAddress string) {
-			handler(depositAddress)
-		}(handler, depositAddress)
-	}
+	tlc.notifyDepositHandlers(tlc.depositRedemptionRequestedHandlers, depositAddress)
 
 	currentBlock, err := tlc.BlockCounter().CurrentBlock()
 	if err != nil {
@@ -629,9 +959,15 @@ func (tlc *TBTCLocalChain) ProvideRedemptionProof(
 	tlc.tbtcLocalChainMutex.Lock()
 	defer tlc.tbtcLocalChainMutex.Unlock()
 
+	tlc.logger.logProvideRedemptionProofCall()
+
 	deposit, ok := tlc.deposits[depositAddress]
 	if !ok {
-		return fmt.Errorf("no deposit with address [%v]", depositAddress)
+		return fmt.Errorf(
+			"no deposit with address [%v]: [%w]",
+			depositAddress,
+			chain.ErrDepositNotFound,
+		)
 	}
 
 	if deposit.redemptionProof != nil {
@@ -644,11 +980,7 @@ func (tlc *TBTCLocalChain) ProvideRedemptionProof(
 	deposit.state = chain.Redeemed
 	deposit.redemptionProof = &TxProof{}
 
-	for _, handler := range tlc.depositRedeemedHandlers {
-		go func(handler func(depositAddress string), depositAddress string) {
-			handler(depositAddress)
-		}(handler, depositAddress)
-	}
+	tlc.notifyDepositHandlers(tlc.depositRedeemedHandlers, depositAddress)
 
 	return nil
 }
@@ -662,7 +994,11 @@ func (tlc *TBTCLocalChain) CurrentState(
 
 	deposit, ok := tlc.deposits[depositAddress]
 	if !ok {
-		return 0, fmt.Errorf("no deposit with address [%v]", depositAddress)
+		return 0, fmt.Errorf(
+			"no deposit with address [%v]: [%w]",
+			depositAddress,
+			chain.ErrDepositNotFound,
+		)
 	}
 
 	return deposit.state, nil
@@ -677,7 +1013,11 @@ func (tlc *TBTCLocalChain) DepositPubkey(
 
 	deposit, ok := tlc.deposits[depositAddress]
 	if !ok {
-		return nil, fmt.Errorf("no deposit with address [%v]", depositAddress)
+		return nil, fmt.Errorf(
+			"no deposit with address [%v]: [%w]",
+			depositAddress,
+			chain.ErrDepositNotFound,
+		)
 	}
 
 	if len(deposit.pubkey) == 0 {
@@ -699,7 +1039,11 @@ func (tlc *TBTCLocalChain) DepositRedemptionSignature(
 
 	deposit, ok := tlc.deposits[depositAddress]
 	if !ok {
-		return nil, fmt.Errorf("no deposit with address [%v]", depositAddress)
+		return nil, fmt.Errorf(
+			"no deposit with address [%v]: [%w]",
+			depositAddress,
+			chain.ErrDepositNotFound,
+		)
 	}
 
 	if deposit.redemptionSignature == nil {
@@ -721,7 +1065,11 @@ func (tlc *TBTCLocalChain) DepositRedemptionProof(
 
 	deposit, ok := tlc.deposits[depositAddress]
 	if !ok {
-		return nil, fmt.Errorf("no deposit with address [%v]", depositAddress)
+		return nil, fmt.Errorf(
+			"no deposit with address [%v]: [%w]",
+			depositAddress,
+			chain.ErrDepositNotFound,
+		)
 	}
 
 	if deposit.redemptionProof == nil {
@@ -743,7 +1091,11 @@ func (tlc *TBTCLocalChain) DepositRedemptionFee(
 
 	deposit, ok := tlc.deposits[depositAddress]
 	if !ok {
-		return nil, fmt.Errorf("no deposit with address [%v]", depositAddress)
+		return nil, fmt.Errorf(
+			"no deposit with address [%v]: [%w]",
+			depositAddress,
+			chain.ErrDepositNotFound,
+		)
 	}
 
 	if deposit.redemptionFee == nil {
@@ -766,6 +1118,44 @@ func (tlc *TBTCLocalChain) SetAlwaysFailingTransactions(transactions ...string)
 	}
 }
 
+// ClearAlwaysFailingTransactions removes the supplied transactions from
+// collection of always failing transactions. If no transactions are supplied,
+// the whole collection is cleared.
+func (tlc *TBTCLocalChain) ClearAlwaysFailingTransactions(transactions ...string) {
+	tlc.tbtcLocalChainMutex.Lock()
+	defer tlc.tbtcLocalChainMutex.Unlock()
+
+	if len(transactions) == 0 {
+		tlc.alwaysFailingTransactions = make(map[string]bool)
+		return
+	}
+
+	for _, tx := range transactions {
+		delete(tlc.alwaysFailingTransactions, tx)
+	}
+}
+
+// SetRedemptionProofTimeout sets the on-chain redemption proof timeout.
+func (tlc *TBTCLocalChain) SetRedemptionProofTimeout(timeout time.Duration) {
+	tlc.tbtcLocalChainMutex.Lock()
+	defer tlc.tbtcLocalChainMutex.Unlock()
+
+	tlc.redemptionProofTimeout = timeout
+}
+
+// RedemptionProofTimeout returns the on-chain redemption proof timeout.
+// It returns an error if the timeout has not been set.
+func (tlc *TBTCLocalChain) RedemptionProofTimeout() (time.Duration, error) {
+	tlc.tbtcLocalChainMutex.Lock()
+	defer tlc.tbtcLocalChainMutex.Unlock()
+
+	if tlc.redemptionProofTimeout == 0 {
+		return 0, fmt.Errorf("redemption proof timeout not set")
+	}
+
+	return tlc.redemptionProofTimeout, nil
+}
+
 // FundingInfo retrieves the funding info for a particular deposit address
 func (tlc *TBTCLocalChain) FundingInfo(
 	depositAddress string,
@@ -774,7 +1164,11 @@ func (tlc *TBTCLocalChain) FundingInfo(
 	defer tlc.tbtcLocalChainMutex.Unlock()
 	deposit, ok := tlc.deposits[depositAddress]
 	if !ok {
-		return nil, fmt.Errorf("no deposit with address [%v]", depositAddress)
+		return nil, fmt.Errorf(
+			"no deposit with address [%v]: [%w]",
+			depositAddress,
+			chain.ErrDepositNotFound,
+		)
 	}
 
 	fundingInfo := deposit.fundingInfo
@@ -794,3 +1188,17 @@ func (tlc *TBTCLocalChain) Logger() *ChainLogger {
 func fromLittleEndianBytes(bytes [8]byte) *big.Int {
 	return new(big.Int).SetUint64(uint64(chain.UtxoValueBytesToUint32(bytes)))
 }
+
+// validateDepositAddress returns chain.ErrInvalidDepositAddress error if the
+// given deposit address is not a well-formed address.
+func validateDepositAddress(depositAddress string) error {
+	if !common.IsHexAddress(depositAddress) {
+		return fmt.Errorf(
+			"[%v] is not a deposit address: [%w]",
+			depositAddress,
+			chain.ErrInvalidDepositAddress,
+		)
+	}
+
+	return nil
+}
@@ -1,12 +1,18 @@
 package local
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
+	"errors"
 	"math/big"
 	"reflect"
 	"testing"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/keep-network/keep-ecdsa/pkg/chain"
+	"github.com/keep-network/keep-ecdsa/pkg/ecdsa"
 )
 
 const (
@@ -94,3 +100,1171 @@ func TestGetOwner(t *testing.T) {
 		)
 	}
 }
+
+func TestIsDepositKeepActive(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	tbtcChain := NewTBTCLocalChain(ctx)
+
+	tbtcChain.CreateDepositWithRandomSigningGroup(depositAddress)
+
+	isActive, err := tbtcChain.IsDepositKeepActive(depositAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isActive {
+		t.Errorf("keep of the deposit should be active")
+	}
+
+	keep, err := tbtcChain.Keep(depositAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = tbtcChain.CloseKeep(common.HexToAddress(keep.ID().String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	isActive, err = tbtcChain.IsDepositKeepActive(depositAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if isActive {
+		t.Errorf("keep of the deposit should not be active after closing")
+	}
+}
+
+func TestIsDepositKeepActive_UnknownDeposit(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	tbtcChain := NewTBTCLocalChain(ctx)
+
+	_, err := tbtcChain.IsDepositKeepActive(depositAddress)
+
+	if !errors.Is(err, chain.ErrDepositNotFound) {
+		t.Errorf(
+			"unexpected error\nexpected: %v\nactual:   %v",
+			chain.ErrDepositNotFound,
+			err,
+		)
+	}
+}
+
+func TestGetDepositsForOperator(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	tbtcChain := NewTBTCLocalChain(ctx)
+
+	memberDepositAddress := "0x4b18B7EEd1dBF6d84D4BC3F5Da4b4c2E8c2e2E6F"
+	otherDepositAddress := "0xb4F1cF6b8A5bC4bB0E7e2b6F3f2F8b3E8d6C5a4A"
+
+	tbtcChain.CreateDeposit(
+		memberDepositAddress,
+		append(RandomSigningGroup(2), tbtcChain.OperatorAddress()),
+	)
+	tbtcChain.CreateDeposit(otherDepositAddress, RandomSigningGroup(3))
+
+	depositAddresses, err := tbtcChain.GetDepositsForOperator(
+		tbtcChain.OperatorID(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedDepositAddresses := []string{memberDepositAddress}
+	if !reflect.DeepEqual(expectedDepositAddresses, depositAddresses) {
+		t.Errorf(
+			"unexpected deposits\nexpected: %v\nactual:   %v",
+			expectedDepositAddresses,
+			depositAddresses,
+		)
+	}
+}
+
+func TestAlwaysFailingTransactions(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	tbtcChain := NewTBTCLocalChain(ctx)
+
+	tbtcChain.CreateDeposit(depositAddress, RandomSigningGroup(3))
+	keep, err := tbtcChain.Keep(depositAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = keep.SubmitKeepPublicKey([64]byte{11, 12, 13, 14, 15, 16})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tbtcChain.SetAlwaysFailingTransactions("RetrieveSignerPubkey")
+
+	err = tbtcChain.RetrieveSignerPubkey(depositAddress)
+	if err == nil {
+		t.Fatal("expected always failing transaction error")
+	}
+
+	tbtcChain.ClearAlwaysFailingTransactions("RetrieveSignerPubkey")
+
+	err = tbtcChain.RetrieveSignerPubkey(depositAddress)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	expectedCalls := 2
+	actualCalls := tbtcChain.Logger().RetrieveSignerPubkeyCalls()
+	if expectedCalls != actualCalls {
+		t.Errorf(
+			"unexpected number of RetrieveSignerPubkey calls\n"+
+				"expected: %v\n"+
+				"actual:   %v",
+			expectedCalls,
+			actualCalls,
+		)
+	}
+
+	expectedState := chain.AwaitingBtcFundingProof
+	actualState, err := tbtcChain.CurrentState(depositAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expectedState != actualState {
+		t.Errorf(
+			"unexpected deposit state\nexpected: %v\nactual:   %v",
+			expectedState,
+			actualState,
+		)
+	}
+}
+
+func TestCurrentState(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	tbtcChain := NewTBTCLocalChain(ctx)
+
+	assertState := func(expectedState chain.DepositState) {
+		actualState, err := tbtcChain.CurrentState(depositAddress)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expectedState != actualState {
+			t.Errorf(
+				"unexpected deposit state\nexpected: %v\nactual:   %v",
+				expectedState,
+				actualState,
+			)
+		}
+	}
+
+	tbtcChain.CreateDepositWithRandomSigningGroup(depositAddress)
+	assertState(chain.AwaitingSignerSetup)
+
+	keep, err := tbtcChain.Keep(depositAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = keep.SubmitKeepPublicKey([64]byte{11, 12, 13, 14, 15, 16})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = tbtcChain.RetrieveSignerPubkey(depositAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertState(chain.AwaitingBtcFundingProof)
+
+	tbtcChain.FundDeposit(depositAddress)
+	assertState(chain.Active)
+
+	err = tbtcChain.RedeemDeposit(depositAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertState(chain.AwaitingWithdrawalSignature)
+
+	err = tbtcChain.ProvideRedemptionSignature(
+		depositAddress,
+		1,
+		[32]uint8{1},
+		[32]uint8{2},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertState(chain.AwaitingWithdrawalProof)
+
+	err = tbtcChain.IncreaseRedemptionFee(
+		depositAddress,
+		toLittleEndianBytes(9999990),
+		toLittleEndianBytes(9999980),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertState(chain.AwaitingWithdrawalSignature)
+
+	err = tbtcChain.ProvideRedemptionSignature(
+		depositAddress,
+		1,
+		[32]uint8{3},
+		[32]uint8{4},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertState(chain.AwaitingWithdrawalProof)
+
+	err = tbtcChain.ProvideRedemptionProof(
+		depositAddress,
+		[4]uint8{},
+		nil,
+		nil,
+		[4]uint8{},
+		nil,
+		nil,
+		nil,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertState(chain.Redeemed)
+}
+
+func TestCurrentState_UnknownDeposit(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	tbtcChain := NewTBTCLocalChain(ctx)
+
+	_, err := tbtcChain.CurrentState(depositAddress)
+	if !errors.Is(err, chain.ErrDepositNotFound) {
+		t.Errorf(
+			"unexpected error\nexpected: %v\nactual:   %v",
+			chain.ErrDepositNotFound,
+			err,
+		)
+	}
+}
+
+func TestCreateDeposit_OperatorInSigningGroup(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	tbtcChain := NewTBTCLocalChain(ctx)
+
+	signers := append(RandomSigningGroup(2), tbtcChain.OperatorAddress())
+
+	tbtcChain.CreateDeposit(depositAddress, signers)
+	keep, err := tbtcChain.Keep(depositAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	members, err := keep.GetMembers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(toIDSlice(signers), members) {
+		t.Errorf(
+			"unexpected keep members\nexpected: %v\nactual:   %v",
+			toIDSlice(signers),
+			members,
+		)
+	}
+
+	isMember, err := keep.IsThisOperatorMember()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isMember {
+		t.Error("operator should be a member of the signing group")
+	}
+}
+
+func TestCreateDeposit_OperatorNotInSigningGroup(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	tbtcChain := NewTBTCLocalChain(ctx)
+
+	tbtcChain.CreateDepositWithRandomSigningGroup(depositAddress)
+	keep, err := tbtcChain.Keep(depositAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	members, err := keep.GetMembers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(members) != defaultSigningGroupSize {
+		t.Errorf(
+			"unexpected number of keep members\nexpected: %v\nactual:   %v",
+			defaultSigningGroupSize,
+			len(members),
+		)
+	}
+
+	isMember, err := keep.IsThisOperatorMember()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if isMember {
+		t.Error("operator should not be a member of the signing group")
+	}
+}
+
+func TestCreateDeposit_NotifiesAllHandlers(t *testing.T) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancelCtx()
+
+	tbtcChain := NewTBTCLocalChain(ctx)
+
+	notifications := make(chan int, 4)
+	for i := 0; i < 3; i++ {
+		handlerIndex := i
+		subscription := tbtcChain.OnDepositCreated(func(depositAddress string) {
+			notifications <- handlerIndex
+		})
+		defer subscription.Unsubscribe()
+	}
+
+	unsubscribed := tbtcChain.OnDepositCreated(func(depositAddress string) {
+		notifications <- -1
+	})
+	unsubscribed.Unsubscribe()
+
+	tbtcChain.CreateDepositWithRandomSigningGroup(depositAddress)
+
+	notifiedHandlers := make(map[int]bool)
+	for len(notifiedHandlers) < 3 {
+		select {
+		case handlerIndex := <-notifications:
+			if notifiedHandlers[handlerIndex] {
+				t.Fatalf("handler [%v] notified more than once", handlerIndex)
+			}
+			notifiedHandlers[handlerIndex] = true
+		case <-ctx.Done():
+			t.Fatalf(
+				"only [%v] handlers notified: [%v]",
+				len(notifiedHandlers),
+				ctx.Err(),
+			)
+		}
+	}
+
+	if notifiedHandlers[-1] {
+		t.Errorf("unsubscribed handler should not be notified")
+	}
+
+	select {
+	case handlerIndex := <-notifications:
+		t.Errorf("unexpected notification of handler [%v]", handlerIndex)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestUnsubscribeAll(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	tbtcChain := NewTBTCLocalChain(ctx)
+
+	notifications := make(chan string, 10)
+	handler := func(eventName string) func(depositAddress string) {
+		return func(depositAddress string) {
+			notifications <- eventName
+		}
+	}
+
+	tbtcChain.OnDepositCreated(handler("created"))
+	tbtcChain.OnDepositCreated(handler("created"))
+	tbtcChain.OnDepositRegisteredPubkey(handler("registered pubkey"))
+	tbtcChain.OnDepositRedemptionRequested(handler("redemption requested"))
+	tbtcChain.OnDepositGotRedemptionSignature(handler("got signature"))
+	tbtcChain.OnDepositRedeemed(handler("redeemed"))
+
+	tbtcChain.UnsubscribeAll()
+
+	tbtcChain.CreateDepositWithRandomSigningGroup(depositAddress)
+
+	keep, err := tbtcChain.Keep(depositAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = keep.SubmitKeepPublicKey([64]byte{11, 12, 13, 14, 15, 16})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = tbtcChain.RetrieveSignerPubkey(depositAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tbtcChain.FundDeposit(depositAddress)
+
+	err = tbtcChain.RedeemDeposit(depositAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = tbtcChain.ProvideRedemptionSignature(
+		depositAddress,
+		1,
+		[32]uint8{1},
+		[32]uint8{2},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = tbtcChain.ProvideRedemptionProof(
+		depositAddress,
+		[4]uint8{},
+		nil,
+		nil,
+		[4]uint8{},
+		nil,
+		nil,
+		nil,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case eventName := <-notifications:
+		t.Errorf("unexpected notification of [%v] handler", eventName)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestProvideBTCFundingProof(t *testing.T) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancelCtx()
+
+	tbtcChain := NewTBTCLocalChain(ctx)
+
+	fundedDeposits := make(chan string, 1)
+	subscription := tbtcChain.OnDepositFunded(func(depositAddress string) {
+		fundedDeposits <- depositAddress
+	})
+	defer subscription.Unsubscribe()
+
+	tbtcChain.CreateDepositWithRandomSigningGroup(depositAddress)
+
+	keep, err := tbtcChain.Keep(depositAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = keep.SubmitKeepPublicKey([64]byte{11, 12, 13, 14, 15, 16})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = tbtcChain.RetrieveSignerPubkey(depositAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = tbtcChain.ProvideBTCFundingProof(
+		depositAddress,
+		[4]uint8{},
+		nil,
+		nil,
+		[4]uint8{},
+		0,
+		nil,
+		nil,
+		nil,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case fundedDeposit := <-fundedDeposits:
+		if fundedDeposit != depositAddress {
+			t.Errorf(
+				"unexpected funded deposit\nexpected: %v\nactual:   %v",
+				depositAddress,
+				fundedDeposit,
+			)
+		}
+	case <-ctx.Done():
+		t.Fatal(ctx.Err())
+	}
+
+	state, err := tbtcChain.CurrentState(depositAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state != chain.Active {
+		t.Errorf(
+			"unexpected deposit state\nexpected: %v\nactual:   %v",
+			chain.Active,
+			state,
+		)
+	}
+
+	if _, err := tbtcChain.FundingInfo(depositAddress); err != nil {
+		t.Errorf("unexpected error: [%v]", err)
+	}
+
+	expectedCalls := 1
+	actualCalls := tbtcChain.Logger().ProvideBTCFundingProofCalls()
+	if expectedCalls != actualCalls {
+		t.Errorf(
+			"unexpected number of ProvideBTCFundingProof calls\n"+
+				"expected: %v\nactual:   %v",
+			expectedCalls,
+			actualCalls,
+		)
+	}
+}
+
+func TestProvideBTCFundingProof_NotAwaitingFundingProof(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	tbtcChain := NewTBTCLocalChain(ctx)
+
+	tbtcChain.CreateDepositWithRandomSigningGroup(depositAddress)
+
+	err := tbtcChain.ProvideBTCFundingProof(
+		depositAddress,
+		[4]uint8{},
+		nil,
+		nil,
+		[4]uint8{},
+		0,
+		nil,
+		nil,
+		nil,
+	)
+	if err == nil {
+		t.Fatal("expected error for deposit awaiting signer setup")
+	}
+
+	state, err := tbtcChain.CurrentState(depositAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state != chain.AwaitingSignerSetup {
+		t.Errorf(
+			"unexpected deposit state\nexpected: %v\nactual:   %v",
+			chain.AwaitingSignerSetup,
+			state,
+		)
+	}
+}
+
+func TestIncreaseRedemptionFee(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	tbtcChain := NewTBTCLocalChain(ctx)
+
+	tbtcChain.CreateDepositWithRandomSigningGroup(depositAddress)
+	keep, err := tbtcChain.Keep(depositAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = keep.SubmitKeepPublicKey([64]byte{11, 12, 13, 14, 15, 16})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tbtcChain.FundDeposit(depositAddress)
+
+	err = tbtcChain.RedeemDeposit(depositAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// utxo value of the funded deposit is 10000000 and every fee increase
+	// step equals to the initial redemption fee of 10
+	outputValues := []int64{9999990, 9999980, 9999970}
+
+	for i := 1; i < len(outputValues); i++ {
+		err = tbtcChain.ProvideRedemptionSignature(
+			depositAddress,
+			0,
+			[32]uint8{1},
+			[32]uint8{2},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = tbtcChain.IncreaseRedemptionFee(
+			depositAddress,
+			toLittleEndianBytes(outputValues[i-1]),
+			toLittleEndianBytes(outputValues[i]),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expectedFee := big.NewInt(30)
+	actualFee, err := tbtcChain.DepositRedemptionFee(depositAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expectedFee.Cmp(actualFee) != 0 {
+		t.Errorf(
+			"unexpected redemption fee\nexpected: %v\nactual:   %v",
+			expectedFee,
+			actualFee,
+		)
+	}
+
+	expectedCalls := 2
+	actualCalls := tbtcChain.Logger().IncreaseRedemptionFeeCalls()
+	if expectedCalls != actualCalls {
+		t.Errorf(
+			"unexpected number of IncreaseRedemptionFee calls\n"+
+				"expected: %v\n"+
+				"actual:   %v",
+			expectedCalls,
+			actualCalls,
+		)
+	}
+}
+
+func TestIncreaseRedemptionFee_FeeNotIncreased(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	tbtcChain := NewTBTCLocalChain(ctx)
+
+	tbtcChain.CreateDepositWithRandomSigningGroup(depositAddress)
+	keep, err := tbtcChain.Keep(depositAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = keep.SubmitKeepPublicKey([64]byte{11, 12, 13, 14, 15, 16})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tbtcChain.FundDeposit(depositAddress)
+
+	err = tbtcChain.RedeemDeposit(depositAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = tbtcChain.ProvideRedemptionSignature(
+		depositAddress,
+		0,
+		[32]uint8{1},
+		[32]uint8{2},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = tbtcChain.IncreaseRedemptionFee(
+		depositAddress,
+		toLittleEndianBytes(9999990),
+		toLittleEndianBytes(10000000),
+	)
+	if err == nil {
+		t.Fatal("expected error for fee which is not increased")
+	}
+}
+
+func TestProvideRedemptionProof(t *testing.T) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancelCtx()
+
+	tbtcChain := NewTBTCLocalChain(ctx)
+
+	tbtcChain.CreateDepositWithRandomSigningGroup(depositAddress)
+	keep, err := tbtcChain.Keep(depositAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = keep.SubmitKeepPublicKey([64]byte{11, 12, 13, 14, 15, 16})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tbtcChain.FundDeposit(depositAddress)
+
+	_, err = tbtcChain.DepositRedemptionFee(depositAddress)
+	if err == nil {
+		t.Fatal("expected error for deposit which is not in redemption")
+	}
+
+	err = tbtcChain.RedeemDeposit(depositAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedFee := big.NewInt(defaultInitialRedemptionFee)
+	actualFee, err := tbtcChain.DepositRedemptionFee(depositAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expectedFee.Cmp(actualFee) != 0 {
+		t.Errorf(
+			"unexpected redemption fee\nexpected: %v\nactual:   %v",
+			expectedFee,
+			actualFee,
+		)
+	}
+
+	_, err = tbtcChain.DepositRedemptionSignature(depositAddress)
+	if err == nil {
+		t.Fatal("expected error for missing redemption signature")
+	}
+
+	err = tbtcChain.ProvideRedemptionSignature(
+		depositAddress,
+		1,
+		[32]uint8{1},
+		[32]uint8{2},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedSignature := &Signature{V: 1, R: [32]uint8{1}, S: [32]uint8{2}}
+	actualSignature, err := tbtcChain.DepositRedemptionSignature(depositAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(expectedSignature, actualSignature) {
+		t.Errorf(
+			"unexpected redemption signature\nexpected: %v\nactual:   %v",
+			expectedSignature,
+			actualSignature,
+		)
+	}
+
+	_, err = tbtcChain.DepositRedemptionProof(depositAddress)
+	if err == nil {
+		t.Fatal("expected error for missing redemption proof")
+	}
+
+	redeemedChan := make(chan string)
+	tbtcChain.OnDepositRedeemed(func(depositAddress string) {
+		redeemedChan <- depositAddress
+	})
+
+	err = tbtcChain.ProvideRedemptionProof(
+		depositAddress,
+		[4]uint8{},
+		nil,
+		nil,
+		[4]uint8{},
+		nil,
+		nil,
+		nil,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proof, err := tbtcChain.DepositRedemptionProof(depositAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if proof == nil {
+		t.Errorf("expected redemption proof to be set")
+	}
+
+	select {
+	case redeemedAddress := <-redeemedChan:
+		if redeemedAddress != depositAddress {
+			t.Errorf(
+				"unexpected redeemed deposit\nexpected: %v\nactual:   %v",
+				depositAddress,
+				redeemedAddress,
+			)
+		}
+	case <-ctx.Done():
+		t.Fatal(ctx.Err())
+	}
+
+	err = tbtcChain.ProvideRedemptionProof(
+		depositAddress,
+		[4]uint8{},
+		nil,
+		nil,
+		[4]uint8{},
+		nil,
+		nil,
+		nil,
+	)
+	if err == nil {
+		t.Fatal("expected error for already provided redemption proof")
+	}
+}
+
+func toLittleEndianBytes(value int64) [8]byte {
+	var valueBytes [8]byte
+	binary.LittleEndian.PutUint64(valueBytes[:], uint64(value))
+	return valueBytes
+}
+
+func TestPastDepositCreatedEvents(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	tbtcChain := NewTBTCLocalChain(ctx)
+
+	depositBlocks := map[string]uint64{
+		"0x4b18B7EEd1dBF6d84D4BC3F5Da4b4c2E8c2e2E6F": 30,
+		"0xb4F1cF6b8A5bC4bB0E7e2b6F3f2F8b3E8d6C5a4A": 10,
+		"0x91e4F5bB6C3d2A1e0F9b8C7d6E5f4A3b2C1d0E9f": 20,
+	}
+
+	for address, block := range depositBlocks {
+		tbtcChain.CreateDepositWithRandomSigningGroup(address)
+
+		tbtcChain.tbtcLocalChainMutex.Lock()
+		tbtcChain.deposits[address].createdAtBlock = block
+		tbtcChain.tbtcLocalChainMutex.Unlock()
+	}
+
+	var tests = map[string]struct {
+		startBlock           uint64
+		expectedBlockNumbers []uint64
+	}{
+		"all events": {
+			startBlock:           0,
+			expectedBlockNumbers: []uint64{10, 20, 30},
+		},
+		"start block equal to event block": {
+			startBlock:           20,
+			expectedBlockNumbers: []uint64{20, 30},
+		},
+		"start block after all events": {
+			startBlock:           31,
+			expectedBlockNumbers: []uint64{},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			events, err := tbtcChain.PastDepositCreatedEvents(test.startBlock)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			blockNumbers := make([]uint64, 0)
+			for _, event := range events {
+				blockNumbers = append(blockNumbers, event.BlockNumber)
+
+				if event.BlockNumber != depositBlocks[event.DepositAddress] {
+					t.Errorf(
+						"unexpected block number of deposit [%v]\n"+
+							"expected: %v\nactual:   %v",
+						event.DepositAddress,
+						depositBlocks[event.DepositAddress],
+						event.BlockNumber,
+					)
+				}
+			}
+
+			if !reflect.DeepEqual(test.expectedBlockNumbers, blockNumbers) {
+				t.Errorf(
+					"unexpected event block numbers\nexpected: %v\nactual:   %v",
+					test.expectedBlockNumbers,
+					blockNumbers,
+				)
+			}
+		})
+	}
+}
+
+func TestPastDepositRedemptionRequestedEvents(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	tbtcChain := NewTBTCLocalChain(ctx)
+
+	tbtcChain.CreateDepositWithRandomSigningGroup(depositAddress)
+
+	tbtcChain.tbtcLocalChainMutex.Lock()
+	tbtcChain.deposits[depositAddress].redemptionRequestedEvents = append(
+		tbtcChain.deposits[depositAddress].redemptionRequestedEvents,
+		&chain.DepositRedemptionRequestedEvent{BlockNumber: 30},
+		&chain.DepositRedemptionRequestedEvent{BlockNumber: 10},
+		&chain.DepositRedemptionRequestedEvent{BlockNumber: 20},
+	)
+	tbtcChain.tbtcLocalChainMutex.Unlock()
+
+	var tests = map[string]struct {
+		startBlock           uint64
+		expectedBlockNumbers []uint64
+	}{
+		"all events": {
+			startBlock:           0,
+			expectedBlockNumbers: []uint64{10, 20, 30},
+		},
+		"start block equal to event block": {
+			startBlock:           20,
+			expectedBlockNumbers: []uint64{20, 30},
+		},
+		"start block between event blocks": {
+			startBlock:           21,
+			expectedBlockNumbers: []uint64{30},
+		},
+		"start block after all events": {
+			startBlock:           31,
+			expectedBlockNumbers: []uint64{},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			events, err := tbtcChain.PastDepositRedemptionRequestedEvents(
+				test.startBlock,
+				depositAddress,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			blockNumbers := make([]uint64, 0)
+			for _, event := range events {
+				blockNumbers = append(blockNumbers, event.BlockNumber)
+			}
+
+			if !reflect.DeepEqual(test.expectedBlockNumbers, blockNumbers) {
+				t.Errorf(
+					"unexpected event block numbers\nexpected: %v\nactual:   %v",
+					test.expectedBlockNumbers,
+					blockNumbers,
+				)
+			}
+		})
+	}
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	tbtcChain := NewTBTCLocalChain(ctx)
+
+	tbtcChain.CreateDeposit(depositAddress, RandomSigningGroup(3))
+
+	keep, err := tbtcChain.Keep(depositAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	snapshot := tbtcChain.Snapshot()
+
+	anotherDepositAddress := "0x3c93a4F1D2bFA0B6D3C4A11e3b2e3F6F4A5dbC2f"
+	tbtcChain.CreateDeposit(anotherDepositAddress, RandomSigningGroup(3))
+
+	err = keep.SubmitKeepPublicKey([64]byte{11, 12, 13, 14, 15, 16})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = tbtcChain.RetrieveSignerPubkey(depositAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tbtcChain.Restore(snapshot)
+
+	if !reflect.DeepEqual(snapshot, tbtcChain.Snapshot()) {
+		t.Errorf("restored chain state does not match the snapshot")
+	}
+
+	if _, err := tbtcChain.Keep(anotherDepositAddress); err == nil {
+		t.Errorf("deposit created after the snapshot should not exist")
+	}
+
+	publicKey, err := keep.GetPublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(publicKey, make([]byte, 64)) {
+		t.Errorf("unexpected keep public key: [%x]", publicKey)
+	}
+
+	depositPubkey, err := tbtcChain.DepositPubkey(depositAddress)
+	if err == nil {
+		t.Errorf("unexpected deposit public key: [%x]", depositPubkey)
+	}
+
+	expectedRetrieveSignerPubkeyCalls := 0
+	actualRetrieveSignerPubkeyCalls := tbtcChain.Logger().RetrieveSignerPubkeyCalls()
+	if expectedRetrieveSignerPubkeyCalls != actualRetrieveSignerPubkeyCalls {
+		t.Errorf(
+			"unexpected number of RetrieveSignerPubkey calls\nexpected: [%v]\nactual:   [%v]",
+			expectedRetrieveSignerPubkeyCalls,
+			actualRetrieveSignerPubkeyCalls,
+		)
+	}
+}
+
+func TestLoggerCountsMonitoredActions(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	tbtcChain := NewTBTCLocalChain(ctx)
+
+	tbtcChain.CreateDepositWithRandomSigningGroup(depositAddress)
+	keep, err := tbtcChain.Keep(depositAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = keep.SubmitKeepPublicKey([64]byte{11, 12, 13, 14, 15, 16})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tbtcChain.FundDeposit(depositAddress)
+
+	err = tbtcChain.RedeemDeposit(depositAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = keep.SubmitSignature(&ecdsa.Signature{
+		R:          big.NewInt(1),
+		S:          big.NewInt(2),
+		RecoveryID: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = tbtcChain.ProvideRedemptionSignature(
+		depositAddress,
+		0,
+		[32]uint8{1},
+		[32]uint8{2},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = tbtcChain.IncreaseRedemptionFee(
+		depositAddress,
+		toLittleEndianBytes(9999990),
+		toLittleEndianBytes(9999980),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = tbtcChain.ProvideRedemptionProof(
+		depositAddress,
+		[4]uint8{},
+		nil,
+		nil,
+		[4]uint8{},
+		nil,
+		nil,
+		nil,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logger := tbtcChain.Logger()
+
+	calls := map[string]int{
+		"SubmitSignature":            logger.SubmitSignatureCalls(),
+		"ProvideRedemptionSignature": logger.ProvideRedemptionSignatureCalls(),
+		"IncreaseRedemptionFee":      logger.IncreaseRedemptionFeeCalls(),
+		"ProvideRedemptionProof":     logger.ProvideRedemptionProofCalls(),
+	}
+	for action, actualCalls := range calls {
+		if actualCalls != 1 {
+			t.Errorf(
+				"unexpected number of %v calls\n"+
+					"expected: %v\n"+
+					"actual:   %v",
+				action,
+				1,
+				actualCalls,
+			)
+		}
+	}
+
+	logger.Reset()
+
+	if !reflect.DeepEqual(*logger, ChainLogger{}) {
+		t.Errorf("expected all logger counters to be zeroed after reset")
+	}
+}
+
+func TestDepositEntryPoints_MalformedDepositAddress(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	tbtcChain := NewTBTCLocalChain(ctx)
+
+	entryPoints := map[string]func(depositAddress string) error{
+		"Keep": func(depositAddress string) error {
+			_, err := tbtcChain.Keep(depositAddress)
+			return err
+		},
+		"RetrieveSignerPubkey": tbtcChain.RetrieveSignerPubkey,
+		"ProvideRedemptionSignature": func(depositAddress string) error {
+			return tbtcChain.ProvideRedemptionSignature(
+				depositAddress,
+				27,
+				[32]uint8{1},
+				[32]uint8{2},
+			)
+		},
+		"IncreaseRedemptionFee": func(depositAddress string) error {
+			return tbtcChain.IncreaseRedemptionFee(
+				depositAddress,
+				toLittleEndianBytes(990),
+				toLittleEndianBytes(980),
+			)
+		},
+	}
+
+	malformedAddresses := map[string]string{
+		"empty address":         "",
+		"missing hex digit":     "0xa5FA806723A7c7c8523F33c39686f20b5261287",
+		"non-hex character":     "0xa5FA806723A7c7c8523F33c39686f20b5261287z",
+		"not an address at all": "deposit",
+	}
+
+	for entryPointName, entryPoint := range entryPoints {
+		for addressName, address := range malformedAddresses {
+			t.Run(entryPointName+"/"+addressName, func(t *testing.T) {
+				err := entryPoint(address)
+				if !errors.Is(err, chain.ErrInvalidDepositAddress) {
+					t.Errorf(
+						"unexpected error\nexpected: %v\nactual:   %v",
+						chain.ErrInvalidDepositAddress,
+						err,
+					)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,263 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-log"
+
+	"github.com/keep-network/keep-common/pkg/subscription"
+)
+
+var logger = log.Logger("keep-chain")
+
+// DefaultResubscribeBackoff is the default delay before a dropped event
+// subscription is re-established.
+const DefaultResubscribeBackoff = 5 * time.Second
+
+// EventSource describes a single type of on-chain events which can be both
+// subscribed to and looked up in past blocks. Events are passed around as
+// opaque values interpreted by the BlockNumber and Key functions.
+type EventSource struct {
+	// Name of the event, used for logging.
+	Name string
+	// Subscribe installs a new subscription delivering events to the given
+	// handler. The returned channel receives a value or gets closed when
+	// the subscription drops.
+	Subscribe func(
+		handler func(event interface{}),
+	) (subscription.EventSubscription, <-chan error)
+	// PastEvents returns events emitted in the given block range, inclusive.
+	PastEvents func(startBlock uint64, endBlock uint64) ([]interface{}, error)
+	// CurrentBlock returns the number of the current block.
+	CurrentBlock func() (uint64, error)
+	// BlockNumber returns the number of the block the event was emitted in.
+	BlockNumber func(event interface{}) uint64
+	// Key identifies the event among other events emitted in the same block.
+	Key func(event interface{}) string
+}
+
+// BackfillOpts holds options of subscriptions installed with
+// SubscribeWithBackfill.
+type BackfillOpts struct {
+	// StartBlock is the block from which past events are delivered when the
+	// subscription is installed.
+	StartBlock uint64
+	// Tick determines how often past events are looked up to catch events
+	// missed by the subscription without it being dropped. Zero value
+	// disables periodic lookups.
+	Tick time.Duration
+	// ResubscribeBackoff is the delay before a dropped subscription is
+	// re-established. DefaultResubscribeBackoff is used if not set.
+	ResubscribeBackoff time.Duration
+	// Checkpoints, if set, persists the last processed block under the
+	// CheckpointKey. A recorded checkpoint takes precedence over StartBlock
+	// so the subscription resumes where a previous one has left off.
+	Checkpoints   BlockCheckpointStore
+	CheckpointKey string
+}
+
+// SubscribeWithBackfill installs a subscription for events of the given
+// source which survives subscription drops. The last processed block is
+// tracked, optionally persisted in a checkpoint store, and each time the
+// subscription is re-established, events from that block onwards are looked
+// up so none of them is missed. Events seen
+// both by the subscription and by past events lookups are delivered to the
+// handler only once.
+func SubscribeWithBackfill(
+	source *EventSource,
+	opts BackfillOpts,
+	handler func(event interface{}),
+) (subscription.EventSubscription, error) {
+	if opts.ResubscribeBackoff == 0 {
+		opts.ResubscribeBackoff = DefaultResubscribeBackoff
+	}
+
+	startBlock := opts.StartBlock
+	if opts.Checkpoints != nil {
+		checkpoint, ok, err := opts.Checkpoints.LastBlock(opts.CheckpointKey)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"could not read [%v] block checkpoint: [%v]",
+				opts.CheckpointKey,
+				err,
+			)
+		}
+		if ok {
+			startBlock = checkpoint
+		}
+	}
+
+	bs := &backfillSubscription{
+		source:          source,
+		handler:         handler,
+		checkpoints:     opts.Checkpoints,
+		checkpointKey:   opts.CheckpointKey,
+		lastBlock:       startBlock,
+		deliveredEvents: make(map[string]uint64),
+	}
+
+	// Install the subscription before looking up past events so no event
+	// occurring in the meantime is missed.
+	eventSubscription, droppedChan := source.Subscribe(bs.deliver)
+
+	if err := bs.backfill(); err != nil {
+		eventSubscription.Unsubscribe()
+		return nil, fmt.Errorf(
+			"could not get past [%v] events: [%v]",
+			source.Name,
+			err,
+		)
+	}
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+
+	go func() {
+		var tickChan <-chan time.Time
+		if opts.Tick > 0 {
+			ticker := time.NewTicker(opts.Tick)
+			defer ticker.Stop()
+			tickChan = ticker.C
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				eventSubscription.Unsubscribe()
+				return
+			case err := <-droppedChan:
+				logger.Warningf(
+					"[%v] subscription dropped: [%v]; resubscribing",
+					source.Name,
+					err,
+				)
+
+				eventSubscription.Unsubscribe()
+
+				select {
+				case <-time.After(opts.ResubscribeBackoff):
+				case <-ctx.Done():
+					return
+				}
+
+				eventSubscription, droppedChan = source.Subscribe(bs.deliver)
+
+				if err := bs.backfill(); err != nil {
+					logger.Errorf(
+						"could not get past [%v] events after "+
+							"resubscribing: [%v]",
+						source.Name,
+						err,
+					)
+				}
+			case <-tickChan:
+				if err := bs.backfill(); err != nil {
+					logger.Errorf(
+						"could not get past [%v] events: [%v]",
+						source.Name,
+						err,
+					)
+				}
+			}
+		}
+	}()
+
+	return subscription.NewEventSubscription(cancelCtx), nil
+}
+
+type backfillSubscription struct {
+	source  *EventSource
+	handler func(event interface{})
+
+	checkpoints   BlockCheckpointStore
+	checkpointKey string
+
+	mutex sync.Mutex
+	// lastBlock is the last block all events have been processed up to.
+	// It is advanced by past events lookups only as events delivered by the
+	// subscription do not guarantee all earlier events have been seen.
+	lastBlock uint64
+	// deliveredEvents holds keys of events delivered from the lastBlock
+	// onwards, along with their block numbers.
+	deliveredEvents map[string]uint64
+}
+
+func (bs *backfillSubscription) deliver(event interface{}) {
+	blockNumber := bs.source.BlockNumber(event)
+	eventKey := fmt.Sprintf("%v-%v", blockNumber, bs.source.Key(event))
+
+	bs.mutex.Lock()
+	if _, delivered := bs.deliveredEvents[eventKey]; delivered {
+		bs.mutex.Unlock()
+		return
+	}
+	bs.deliveredEvents[eventKey] = blockNumber
+	bs.mutex.Unlock()
+
+	bs.handler(event)
+}
+
+// backfill delivers events from the last processed block up to the current
+// block. The last processed block itself is looked up again as not all of
+// its events might have been seen yet.
+func (bs *backfillSubscription) backfill() error {
+	currentBlock, err := bs.source.CurrentBlock()
+	if err != nil {
+		return err
+	}
+
+	bs.mutex.Lock()
+	startBlock := bs.lastBlock
+	bs.mutex.Unlock()
+
+	if startBlock > currentBlock {
+		return nil
+	}
+
+	events, err := bs.source.PastEvents(startBlock, currentBlock)
+	if err != nil {
+		return err
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return bs.source.BlockNumber(events[i]) <
+			bs.source.BlockNumber(events[j])
+	})
+
+	for _, event := range events {
+		bs.deliver(event)
+	}
+
+	bs.mutex.Lock()
+
+	if currentBlock > bs.lastBlock {
+		bs.lastBlock = currentBlock
+	}
+	lastBlock := bs.lastBlock
+
+	// Events older than the block the next lookup starts from can't be
+	// seen again so there is no need to remember them.
+	for eventKey, blockNumber := range bs.deliveredEvents {
+		if blockNumber < startBlock {
+			delete(bs.deliveredEvents, eventKey)
+		}
+	}
+
+	bs.mutex.Unlock()
+
+	if bs.checkpoints != nil {
+		err := bs.checkpoints.SaveLastBlock(bs.checkpointKey, lastBlock)
+		if err != nil {
+			return fmt.Errorf(
+				"could not save [%v] block checkpoint: [%v]",
+				bs.checkpointKey,
+				err,
+			)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,286 @@
+package chain
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-common/pkg/subscription"
+)
+
+type testEvent struct {
+	blockNumber uint64
+	key         string
+}
+
+// testEventSource is an event source whose subscription can be dropped on
+// demand. Events emitted while the subscription is dropped are only available
+// through the past events lookup.
+type testEventSource struct {
+	mutex        sync.Mutex
+	currentBlock uint64
+	events       []*testEvent
+	handler      func(event interface{})
+	droppedChan  chan error
+}
+
+func (tes *testEventSource) eventSource() *EventSource {
+	return &EventSource{
+		Name: "Test",
+		Subscribe: func(
+			handler func(event interface{}),
+		) (subscription.EventSubscription, <-chan error) {
+			tes.mutex.Lock()
+			defer tes.mutex.Unlock()
+
+			tes.handler = handler
+			tes.droppedChan = make(chan error, 1)
+
+			return subscription.NewEventSubscription(func() {}), tes.droppedChan
+		},
+		PastEvents: func(
+			startBlock uint64,
+			endBlock uint64,
+		) ([]interface{}, error) {
+			tes.mutex.Lock()
+			defer tes.mutex.Unlock()
+
+			events := make([]interface{}, 0)
+			for _, event := range tes.events {
+				if event.blockNumber >= startBlock &&
+					event.blockNumber <= endBlock {
+					events = append(events, event)
+				}
+			}
+			return events, nil
+		},
+		CurrentBlock: func() (uint64, error) {
+			tes.mutex.Lock()
+			defer tes.mutex.Unlock()
+
+			return tes.currentBlock, nil
+		},
+		BlockNumber: func(event interface{}) uint64 {
+			return event.(*testEvent).blockNumber
+		},
+		Key: func(event interface{}) string {
+			return event.(*testEvent).key
+		},
+	}
+}
+
+func (tes *testEventSource) emit(blockNumber uint64) {
+	event := &testEvent{
+		blockNumber: blockNumber,
+		key:         fmt.Sprintf("event-%v", blockNumber),
+	}
+
+	tes.mutex.Lock()
+	tes.currentBlock = blockNumber
+	tes.events = append(tes.events, event)
+	handler := tes.handler
+	tes.mutex.Unlock()
+
+	if handler != nil {
+		handler(event)
+	}
+}
+
+func (tes *testEventSource) drop() {
+	tes.mutex.Lock()
+	defer tes.mutex.Unlock()
+
+	tes.handler = nil
+	tes.droppedChan <- fmt.Errorf("connection lost")
+}
+
+func TestSubscribeWithBackfill_DroppedSubscription(t *testing.T) {
+	source := &testEventSource{
+		currentBlock: 10,
+		events:       []*testEvent{{blockNumber: 5, key: "event-5"}},
+	}
+
+	deliveredBlocks := make([]uint64, 0)
+	deliveredBlocksMutex := &sync.Mutex{}
+
+	eventSubscription, err := SubscribeWithBackfill(
+		source.eventSource(),
+		BackfillOpts{
+			StartBlock:         0,
+			ResubscribeBackoff: 10 * time.Millisecond,
+		},
+		func(event interface{}) {
+			deliveredBlocksMutex.Lock()
+			defer deliveredBlocksMutex.Unlock()
+
+			deliveredBlocks = append(
+				deliveredBlocks,
+				event.(*testEvent).blockNumber,
+			)
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer eventSubscription.Unsubscribe()
+
+	source.emit(11)
+
+	source.drop()
+
+	// emitted while the subscription is dropped
+	source.emit(12)
+	source.emit(13)
+
+	// wait a bit longer than the resubscribe backoff
+	time.Sleep(50 * time.Millisecond)
+
+	source.emit(14)
+
+	deliveredBlocksMutex.Lock()
+	defer deliveredBlocksMutex.Unlock()
+
+	expectedBlocks := []uint64{5, 11, 12, 13, 14}
+	if !reflect.DeepEqual(expectedBlocks, deliveredBlocks) {
+		t.Errorf(
+			"unexpected delivered events\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedBlocks,
+			deliveredBlocks,
+		)
+	}
+}
+
+func TestSubscribeWithBackfill_PeriodicLookup(t *testing.T) {
+	source := &testEventSource{currentBlock: 10}
+
+	deliveredBlocks := make(chan uint64, 10)
+
+	eventSubscription, err := SubscribeWithBackfill(
+		source.eventSource(),
+		BackfillOpts{
+			StartBlock: 10,
+			Tick:       10 * time.Millisecond,
+		},
+		func(event interface{}) {
+			deliveredBlocks <- event.(*testEvent).blockNumber
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer eventSubscription.Unsubscribe()
+
+	// the subscription misses the event without being dropped
+	source.mutex.Lock()
+	source.handler = nil
+	source.mutex.Unlock()
+
+	source.emit(11)
+
+	select {
+	case blockNumber := <-deliveredBlocks:
+		if blockNumber != 11 {
+			t.Errorf(
+				"unexpected event block\n"+
+					"expected: [%v]\n"+
+					"actual:   [%v]",
+				11,
+				blockNumber,
+			)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("missed event has not been delivered")
+	}
+}
+
+func TestSubscribeWithBackfill_Checkpoint(t *testing.T) {
+	source := &testEventSource{currentBlock: 10}
+	checkpoints := NewMemoryBlockCheckpointStore()
+	checkpointKey := "keep-Test"
+
+	opts := BackfillOpts{
+		StartBlock:    0,
+		Tick:          10 * time.Millisecond,
+		Checkpoints:   checkpoints,
+		CheckpointKey: checkpointKey,
+	}
+
+	eventSubscription, err := SubscribeWithBackfill(
+		source.eventSource(),
+		opts,
+		func(event interface{}) {},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	source.emit(11)
+	source.emit(12)
+
+	// wait a bit longer than the lookup tick
+	time.Sleep(50 * time.Millisecond)
+
+	eventSubscription.Unsubscribe()
+
+	checkpoint, ok, err := checkpoints.LastBlock(checkpointKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok || checkpoint != 12 {
+		t.Errorf(
+			"unexpected checkpoint\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			12,
+			checkpoint,
+		)
+	}
+
+	// events emitted while there is no subscription
+	source.mutex.Lock()
+	source.handler = nil
+	source.mutex.Unlock()
+	source.emit(13)
+
+	deliveredBlocks := make([]uint64, 0)
+	deliveredBlocksMutex := &sync.Mutex{}
+
+	// the new subscription starts from the checkpoint instead of the
+	// start block
+	eventSubscription, err = SubscribeWithBackfill(
+		source.eventSource(),
+		opts,
+		func(event interface{}) {
+			deliveredBlocksMutex.Lock()
+			defer deliveredBlocksMutex.Unlock()
+
+			deliveredBlocks = append(
+				deliveredBlocks,
+				event.(*testEvent).blockNumber,
+			)
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer eventSubscription.Unsubscribe()
+
+	deliveredBlocksMutex.Lock()
+	defer deliveredBlocksMutex.Unlock()
+
+	// the checkpoint block itself is looked up again
+	expectedBlocks := []uint64{12, 13}
+	if !reflect.DeepEqual(expectedBlocks, deliveredBlocks) {
+		t.Errorf(
+			"unexpected delivered events\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedBlocks,
+			deliveredBlocks,
+		)
+	}
+}
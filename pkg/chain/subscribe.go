@@ -0,0 +1,21 @@
+package chain
+
+// SubscribeOpts holds options of event subscriptions installed through the
+// chain handles.
+type SubscribeOpts struct {
+	// PastBlocks determines how many blocks from the past should be looked up
+	// for events when the subscription is installed. Past events found in this
+	// range are replayed to the handler before new events are delivered.
+	// Zero value means past events are not replayed.
+	PastBlocks uint64
+}
+
+// PastBlocks returns the number of past blocks set in the first of the
+// given options or zero if no options are given.
+func PastBlocks(opts []SubscribeOpts) uint64 {
+	if len(opts) == 0 {
+		return 0
+	}
+
+	return opts[0].PastBlocks
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/keep-network/keep-common/pkg/subscription"
 )
@@ -13,6 +14,21 @@ import (
 // ErrDepositNotFunded is an error returned when a deposit has not been funded.
 var ErrDepositNotFunded = errors.New("deposit not funded")
 
+// ErrDepositNotFound is an error returned when there is no deposit under
+// the given address.
+var ErrDepositNotFound = errors.New("deposit not found")
+
+// ErrInvalidDepositAddress is an error returned when the given deposit
+// address is malformed.
+var ErrInvalidDepositAddress = errors.New("invalid deposit address")
+
+// RedemptionProofTimeout is the time after the latest redemption request
+// within which the redemption proof must be provided, as defined by
+// TBTCConstants.REDEMPTION_PROOF_TIMEOUT. TBTCConstants is a library whose
+// constants are inlined into the tBTC contracts and are not exposed by their
+// ABIs.
+const RedemptionProofTimeout = 6 * time.Hour
+
 // TBTCHandle represents handle to the tBTC on-chain application. It extends the
 // BondedECDSAKeepApplicationHandle interface with tBTC-specific functionality.
 type TBTCHandle interface {
@@ -28,10 +44,33 @@ type Deposit interface {
 	// Keep returns the underlying keep for the provided deposit.
 	Keep(depositAddress string) (BondedECDSAKeepHandle, error)
 
+	// IsDepositKeepActive checks if the underlying keep for the provided
+	// deposit is still active.
+	IsDepositKeepActive(depositAddress string) (bool, error)
+
+	// GetDepositsForOperator returns addresses of all deposits whose keeps
+	// include the given operator as a member.
+	GetDepositsForOperator(operator ID) ([]string, error)
+
 	// RetrieveSignerPubkey retrieves the signer public key for the
 	// provided deposit.
 	RetrieveSignerPubkey(depositAddress string) error
 
+	// ProvideBTCFundingProof provides the proof of the bitcoin funding
+	// transaction for the provided deposit, moving it from awaiting the
+	// funding proof to active.
+	ProvideBTCFundingProof(
+		depositAddress string,
+		txVersion [4]uint8,
+		txInputVector []uint8,
+		txOutputVector []uint8,
+		txLocktime [4]uint8,
+		fundingOutputIndex uint8,
+		merkleProof []uint8,
+		txIndexInBlock *big.Int,
+		bitcoinHeaders []uint8,
+	) error
+
 	// ProvideRedemptionSignature provides the redemption signature for the
 	// provided deposit.
 	ProvideRedemptionSignature(
@@ -81,6 +120,12 @@ type TBTCSystem interface {
 		handler func(depositAddress string),
 	) subscription.EventSubscription
 
+	// OnDepositFunded installs a callback that is invoked when an
+	// on-chain notification of a deposit funding is seen.
+	OnDepositFunded(
+		handler func(depositAddress string),
+	) subscription.EventSubscription
+
 	// OnDepositRedemptionRequested installs a callback that is invoked when an
 	// on-chain notification of a deposit redemption request is seen.
 	OnDepositRedemptionRequested(
@@ -100,6 +145,13 @@ type TBTCSystem interface {
 		handler func(depositAddress string),
 	) subscription.EventSubscription
 
+	// PastDepositCreatedEvents returns all deposit created events which
+	// occurred after the provided start block. All implementations should
+	// return those events sorted by the block number in the ascending order.
+	PastDepositCreatedEvents(
+		startBlock uint64,
+	) ([]*DepositCreatedEvent, error)
+
 	// PastDepositRedemptionRequestedEvents returns all redemption requested
 	// events for the given deposit which occurred after the provided start block.
 	// All implementations should return those events sorted by the
@@ -115,6 +167,11 @@ type TBTCSystem interface {
 	FundingInfo(
 		depositAddress string,
 	) (*FundingInfo, error)
+
+	// RedemptionProofTimeout returns the time after the latest redemption
+	// request of a deposit within which the redemption proof must be
+	// provided before the signers can be punished.
+	RedemptionProofTimeout() (time.Duration, error)
 }
 
 // FundingInfo represents the funding information for a tbtc deposit
@@ -125,6 +182,13 @@ type FundingInfo struct {
 	OutputIndex     uint32
 }
 
+// DepositCreatedEvent is an event emitted when a new deposit is created.
+type DepositCreatedEvent struct {
+	DepositAddress string
+	KeepAddress    string
+	BlockNumber    uint64
+}
+
 // DepositRedemptionRequestedEvent is an event emitted when a deposit
 // redemption has been requested or the redemption fee has been increased.
 type DepositRedemptionRequestedEvent struct {
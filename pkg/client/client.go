@@ -43,7 +43,8 @@ const awaitingSignatureEventCheckTimeout = 60 * time.Second
 
 // Handle represents a handle to the ECDSA client.
 type Handle struct {
-	tssNode *node.Node
+	tssNode       *node.Node
+	tbtcExtension *tbtc.Handle
 }
 
 // TSSPreParamsPoolSize returns the current size of the TSS params pool.
@@ -51,6 +52,26 @@ func (h *Handle) TSSPreParamsPoolSize() int {
 	return h.tssNode.TSSPreParamsPoolSize()
 }
 
+// Shutdown stops the TBTC extension from starting new deposit monitorings
+// and waits until the running ones exit once the context the client has been
+// initialized with is done. If the given context is done before, the context
+// error is returned.
+func (h *Handle) Shutdown(ctx context.Context) error {
+	if h.tbtcExtension == nil {
+		return nil
+	}
+
+	h.tbtcExtension.UnsubscribeAll()
+
+	return h.tbtcExtension.Wait(ctx)
+}
+
+// TBTCExtension returns the handle to the TBTC extension. It returns nil
+// if the extension has not been initialized.
+func (h *Handle) TBTCExtension() *tbtc.Handle {
+	return h.tbtcExtension
+}
+
 // Initialize initializes the ECDSA client with rules related to events handling.
 // Expects a slice of sanctioned applications selected by the operator for which
 // operator will be registered as a member candidate.
@@ -109,6 +130,15 @@ func Initialize(
 
 	blockCounter := hostChain.BlockCounter()
 
+	// The bitcoin handle is shared by all liquidation recoveries so the
+	// electrs API rate limit applies to all of them together. It is nil if
+	// the bitcoin configuration is invalid; liquidation recoveries validate
+	// the configuration before using the handle.
+	bitcoinHandle, err := tbtcConfig.Bitcoin.ConnectElectrs()
+	if err != nil {
+		logger.Errorf("failed to connect to electrs API: [%v]", err)
+	}
+
 	tbtcApplicationHandle, err := hostChain.TBTCApplicationHandle()
 	if err != nil {
 		logger.Errorf(
@@ -211,6 +241,7 @@ func Initialize(
 				keep,
 				keepsRegistry,
 				derivationIndexStorage,
+				bitcoinHandle,
 				eventDeduplicator,
 				subscriptionOnSignatureRequested,
 			)
@@ -229,6 +260,7 @@ func Initialize(
 		operatorPublicKey,
 		keepsRegistry,
 		derivationIndexStorage,
+		bitcoinHandle,
 		eventDeduplicator,
 	)
 
@@ -275,6 +307,7 @@ func Initialize(
 					operatorPublicKey,
 					keepsRegistry,
 					derivationIndexStorage,
+					bitcoinHandle,
 					eventDeduplicator,
 					keep,
 					event.MemberIDs,
@@ -289,15 +322,17 @@ func Initialize(
 		}
 	})
 
-	initializeExtensions(
+	tbtcExtension := initializeExtensions(
 		ctx,
 		tbtcApplicationHandle,
 		blockCounter,
 		hostChain.BlockTimestamp,
+		tbtcConfig,
 	)
 
 	return &Handle{
-		tssNode: tssNode,
+		tssNode:       tssNode,
+		tbtcExtension: tbtcExtension,
 	}
 }
 
@@ -306,19 +341,25 @@ func initializeExtensions(
 	tbtcHandle chain.TBTCHandle,
 	blockCounter corechain.BlockCounter,
 	blockTimestamp func(blockNumber *big.Int) (uint64, error),
-) {
-	if tbtcHandle != nil {
-		tbtc.Initialize(
-			ctx,
-			tbtcHandle,
-			blockCounter,
-			blockTimestamp,
-		)
-	} else {
+	tbtcConfig *tbtc.Config,
+) *tbtc.Handle {
+	if tbtcHandle == nil {
 		logger.Errorf(
 			"could not initialize tbtc chain extension",
 		)
+		return nil
 	}
+
+	return tbtc.Initialize(
+		ctx,
+		tbtcHandle,
+		blockCounter,
+		blockTimestamp,
+		tbtcConfig.MaxConcurrentDepositMonitorings,
+		tbtcConfig.ReconciliationStartBlock,
+		tbtcConfig.CircuitBreakerThreshold,
+		tbtcConfig.GetCircuitBreakerCooldown(),
+	)
 }
 
 func checkAwaitingKeyGeneration(
@@ -332,6 +373,7 @@ func checkAwaitingKeyGeneration(
 	operatorPublicKey *operator.PublicKey,
 	keepsRegistry *registry.Keeps,
 	derivationIndexStorage *recovery.DerivationIndexStorage,
+	bitcoinHandle bitcoin.Handle,
 	eventDeduplicator *event.Deduplicator,
 ) {
 	keepCount, err := hostChain.GetKeepCount()
@@ -396,6 +438,7 @@ func checkAwaitingKeyGeneration(
 			operatorPublicKey,
 			keepsRegistry,
 			derivationIndexStorage,
+			bitcoinHandle,
 			eventDeduplicator,
 			keep,
 		)
@@ -420,6 +463,7 @@ func checkAwaitingKeyGenerationForKeep(
 	operatorPublicKey *operator.PublicKey,
 	keepsRegistry *registry.Keeps,
 	derivationIndexStorage *recovery.DerivationIndexStorage,
+	bitcoinHandle bitcoin.Handle,
 	eventDeduplicator *event.Deduplicator,
 	keep chain.BondedECDSAKeepHandle,
 ) error {
@@ -475,6 +519,7 @@ func checkAwaitingKeyGenerationForKeep(
 			operatorPublicKey,
 			keepsRegistry,
 			derivationIndexStorage,
+			bitcoinHandle,
 			eventDeduplicator,
 			keep,
 			members,
@@ -496,6 +541,7 @@ func generateKeyForKeep(
 	operatorPublicKey *operator.PublicKey,
 	keepsRegistry *registry.Keeps,
 	derivationIndexStorage *recovery.DerivationIndexStorage,
+	bitcoinHandle bitcoin.Handle,
 	eventDeduplicator *event.Deduplicator,
 	keep chain.BondedECDSAKeepHandle,
 	members []chain.ID,
@@ -605,6 +651,7 @@ func generateKeyForKeep(
 		keep,
 		keepsRegistry,
 		derivationIndexStorage,
+		bitcoinHandle,
 		eventDeduplicator,
 		subscriptionOnSignatureRequested,
 	)
@@ -975,6 +1022,7 @@ func monitorKeepTerminatedEvent(
 	keep chain.BondedECDSAKeepHandle,
 	keepsRegistry *registry.Keeps,
 	derivationIndexStorage *recovery.DerivationIndexStorage,
+	bitcoinHandle bitcoin.Handle,
 	eventDeduplicator *event.Deduplicator,
 	subscriptionOnSignatureRequested subscription.EventSubscription,
 ) {
@@ -991,7 +1039,7 @@ func monitorKeepTerminatedEvent(
 			go func(event *chain.KeepTerminatedEvent) {
 				err := tbtcConfig.Bitcoin.Validate()
 				if err != nil {
-					if (bitcoin.Config{}) == tbtcConfig.Bitcoin {
+					if tbtcConfig.Bitcoin.IsZero() {
 						logger.Errorf("missing bitcoin configuration for tbtc extension: [%v]", err)
 					} else {
 						logger.Errorf("misconfigured bitcoin configured for tbtc extension: [%v]", err)
@@ -1035,8 +1083,6 @@ func monitorKeepTerminatedEvent(
 							return err
 						}
 
-						bitcoinHandle := bitcoin.Connect(tbtcConfig.Bitcoin.ElectrsURLWithDefault())
-
 						if err := handleLiquidationRecovery(
 							ctx,
 							hostChain,
@@ -688,6 +688,10 @@ func (l *localBitcoinConnection) Broadcast(transaction string) error {
 	return l.broadcastError
 }
 
+func (l *localBitcoinConnection) IsDryRun() bool {
+	return false
+}
+
 func (l *localBitcoinConnection) VbyteFeeFor25Blocks() (int32, error) {
 	if l.vbyteFeeFor25BlocksError != nil {
 		return 0, l.vbyteFeeFor25BlocksError
@@ -696,6 +700,10 @@ func (l *localBitcoinConnection) VbyteFeeFor25Blocks() (int32, error) {
 	return l.vbyteFeeFor25Blocks, nil
 }
 
+func (l *localBitcoinConnection) VbyteFeeForTarget(blocks int) (int32, error) {
+	return l.VbyteFeeFor25Blocks()
+}
+
 func (l *localBitcoinConnection) IsAddressUnused(btcAddress string) (bool, error) {
 	if l.isAddressUnusedError != nil {
 		return true, nil
@@ -703,3 +711,23 @@ func (l *localBitcoinConnection) IsAddressUnused(btcAddress string) (bool, error
 
 	return l.isAddressUnused, l.isAddressUnusedError
 }
+
+func (l *localBitcoinConnection) AddressTransactionStatus(
+	btcAddress string,
+) (*bitcoin.AddressStatus, error) {
+	return &bitcoin.AddressStatus{}, nil
+}
+
+func (l *localBitcoinConnection) GetUnspentOutputs(
+	btcAddress string,
+) ([]bitcoin.UnspentOutput, error) {
+	return []bitcoin.UnspentOutput{}, nil
+}
+
+func (l *localBitcoinConnection) GetRawTransaction(txid string) (string, error) {
+	return "", bitcoin.ErrTransactionNotFound
+}
+
+func (l *localBitcoinConnection) GetTransactionConfirmations(txid string) (int, error) {
+	return 0, bitcoin.ErrTransactionNotFound
+}
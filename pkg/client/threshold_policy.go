@@ -0,0 +1,26 @@
+package client
+
+import (
+	"github.com/keep-network/keep-ecdsa/pkg/chain"
+)
+
+// MeetsThresholdPolicy checks whether the honest threshold of the given keep
+// is at least the given minimum honest threshold. Zero minimum accepts keeps
+// with any honest threshold. The policy should be checked before the operator
+// joins a keep; once the operator has been selected into the keep, refusing
+// to generate a key only makes the keep fail for all its members.
+func MeetsThresholdPolicy(
+	keep chain.BondedECDSAKeepHandle,
+	minHonestThreshold uint64,
+) (bool, error) {
+	honestThreshold, err := keep.GetHonestThreshold()
+	if err != nil {
+		return false, err
+	}
+
+	return meetsThresholdPolicy(honestThreshold, minHonestThreshold), nil
+}
+
+func meetsThresholdPolicy(honestThreshold, minHonestThreshold uint64) bool {
+	return honestThreshold >= minHonestThreshold
+}
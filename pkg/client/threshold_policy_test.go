@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	chainLocal "github.com/keep-network/keep-ecdsa/pkg/chain/local"
+)
+
+func TestMeetsThresholdPolicy(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	localChain := chainLocal.Connect(ctx)
+
+	minHonestThreshold := uint64(2)
+
+	var tests = map[string]struct {
+		members        []common.Address
+		expectedResult bool
+	}{
+		"honest threshold below the minimum": {
+			members:        chainLocal.RandomSigningGroup(1),
+			expectedResult: false,
+		},
+		"honest threshold equal to the minimum": {
+			members:        chainLocal.RandomSigningGroup(2),
+			expectedResult: true,
+		},
+		"honest threshold above the minimum": {
+			members:        chainLocal.RandomSigningGroup(3),
+			expectedResult: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			keep := localChain.OpenKeep(
+				chainLocal.RandomSigningGroup(1)[0],
+				common.Address{},
+				test.members,
+			)
+
+			result, err := MeetsThresholdPolicy(keep, minHonestThreshold)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if result != test.expectedResult {
+				t.Errorf(
+					"unexpected result\n"+
+						"expected: [%v]\n"+
+						"actual:   [%v]",
+					test.expectedResult,
+					result,
+				)
+			}
+		})
+	}
+}
+
+func TestMeetsThresholdPolicy_Disabled(t *testing.T) {
+	if !meetsThresholdPolicy(1, 0) {
+		t.Errorf("zero minimum honest threshold should accept any keep")
+	}
+}
@@ -5,8 +5,18 @@
 package ecdsa
 
 import (
+	cecdsa "crypto/ecdsa"
 	"fmt"
 	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+)
+
+var (
+	// curveOrder is the order of the secp256k1 curve.
+	curveOrder = btcec.S256().N
+	// halfCurveOrder is the upper bound of S of a canonical signature.
+	halfCurveOrder = new(big.Int).Rsh(curveOrder, 1)
 )
 
 // Signature holds a signature in a form of two big.Int `r` and `s` values and a
@@ -25,3 +35,92 @@ type Signature struct {
 func (s *Signature) String() string {
 	return fmt.Sprintf("R: %#x, S: %#x, RecoveryID: %d", s.R, s.S, s.RecoveryID)
 }
+
+// IsCanonical checks whether the signature is in the low-S form, that is
+// whether its S value is not above the half of the secp256k1 curve order.
+// Both Ethereum and Bitcoin reject signatures which are not canonical.
+func (s *Signature) IsCanonical() bool {
+	return s.S.Cmp(halfCurveOrder) <= 0
+}
+
+// Canonicalize converts the signature to the low-S form in place. If S is
+// above the half of the secp256k1 curve order, it is replaced with N - S and
+// the recovery ID parity is flipped, so the signature still recovers to the
+// same public key. A canonical signature is left untouched.
+func (s *Signature) Canonicalize() {
+	if s.IsCanonical() {
+		return
+	}
+
+	s.S = new(big.Int).Sub(curveOrder, s.S)
+	s.RecoveryID ^= 1
+}
+
+// DER encodes the signature in the strict DER form required by Bitcoin
+// scripts. The encoding is minimal and uses the low-S form of the signature;
+// the signature itself is not modified. If a sighash type is given, it is
+// appended to the encoding. R and S must be in the [1, N-1] range, where N is
+// the secp256k1 curve order.
+func (s *Signature) DER(sigHashType ...byte) ([]byte, error) {
+	if len(sigHashType) > 1 {
+		return nil, fmt.Errorf(
+			"at most one sighash type expected; got [%v]",
+			len(sigHashType),
+		)
+	}
+
+	if s.R.Sign() <= 0 || s.R.Cmp(curveOrder) >= 0 {
+		return nil, fmt.Errorf("signature R [%v] is out of range", s.R)
+	}
+
+	if s.S.Sign() <= 0 || s.S.Cmp(curveOrder) >= 0 {
+		return nil, fmt.Errorf("signature S [%v] is out of range", s.S)
+	}
+
+	canonicalSignature := &Signature{
+		R:          s.R,
+		S:          s.S,
+		RecoveryID: s.RecoveryID,
+	}
+	canonicalSignature.Canonicalize()
+
+	encoded := (&btcec.Signature{
+		R: canonicalSignature.R,
+		S: canonicalSignature.S,
+	}).Serialize()
+
+	return append(encoded, sigHashType...), nil
+}
+
+// SignDigest signs the given 32-byte digest with the given secp256k1 private
+// key. The digest is signed as is, without any further hashing or prefixing.
+// The returned signature is canonical and its recovery ID, in {0, 1}, allows
+// recovering the public key of the signer.
+func SignDigest(
+	privateKey *cecdsa.PrivateKey,
+	digest [32]byte,
+) (*Signature, error) {
+	if privateKey == nil {
+		return nil, fmt.Errorf("private key is not set")
+	}
+
+	// The compact signature is [27 + recovery ID, R, S].
+	compactSignature, err := btcec.SignCompact(
+		btcec.S256(),
+		(*btcec.PrivateKey)(privateKey),
+		digest[:],
+		false,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not sign digest: [%v]", err)
+	}
+
+	signature := &Signature{
+		R:          new(big.Int).SetBytes(compactSignature[1:33]),
+		S:          new(big.Int).SetBytes(compactSignature[33:65]),
+		RecoveryID: int(compactSignature[0] - 27),
+	}
+	signature.Canonicalize()
+
+	return signature, nil
+}
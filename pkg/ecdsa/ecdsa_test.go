@@ -1,8 +1,13 @@
 package ecdsa
 
 import (
+	"bytes"
 	"math/big"
 	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
 func TestSignatureString(t *testing.T) {
@@ -23,3 +28,209 @@ func TestSignatureString(t *testing.T) {
 		)
 	}
 }
+
+func TestSignatureCanonicalize(t *testing.T) {
+	curveOrder := btcec.S256().N
+	halfCurveOrder := new(big.Int).Rsh(curveOrder, 1)
+
+	var tests = map[string]struct {
+		signature         *Signature
+		expectedCanonical bool
+		expectedSignature *Signature
+	}{
+		"low S": {
+			signature:         &Signature{R: big.NewInt(3), S: big.NewInt(7), RecoveryID: 1},
+			expectedCanonical: true,
+			expectedSignature: &Signature{R: big.NewInt(3), S: big.NewInt(7), RecoveryID: 1},
+		},
+		"S equal to half of the curve order": {
+			signature:         &Signature{R: big.NewInt(3), S: halfCurveOrder, RecoveryID: 0},
+			expectedCanonical: true,
+			expectedSignature: &Signature{R: big.NewInt(3), S: halfCurveOrder, RecoveryID: 0},
+		},
+		"high S with even recovery id": {
+			signature: &Signature{
+				R:          big.NewInt(3),
+				S:          new(big.Int).Sub(curveOrder, big.NewInt(7)),
+				RecoveryID: 0,
+			},
+			expectedCanonical: false,
+			expectedSignature: &Signature{R: big.NewInt(3), S: big.NewInt(7), RecoveryID: 1},
+		},
+		"high S with odd recovery id": {
+			signature: &Signature{
+				R:          big.NewInt(3),
+				S:          new(big.Int).Sub(curveOrder, big.NewInt(7)),
+				RecoveryID: 1,
+			},
+			expectedCanonical: false,
+			expectedSignature: &Signature{R: big.NewInt(3), S: big.NewInt(7), RecoveryID: 0},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			if test.signature.IsCanonical() != test.expectedCanonical {
+				t.Errorf(
+					"unexpected canonical form\n"+
+						"expected: [%v]\n"+
+						"actual:   [%v]",
+					test.expectedCanonical,
+					test.signature.IsCanonical(),
+				)
+			}
+
+			test.signature.Canonicalize()
+
+			if test.signature.S.Cmp(test.expectedSignature.S) != 0 ||
+				test.signature.RecoveryID != test.expectedSignature.RecoveryID {
+				t.Errorf(
+					"unexpected canonicalized signature\n"+
+						"expected: [%v]\n"+
+						"actual:   [%v]",
+					test.expectedSignature,
+					test.signature,
+				)
+			}
+
+			if !test.signature.IsCanonical() {
+				t.Errorf("signature is not canonical after canonicalization")
+			}
+		})
+	}
+}
+
+func TestSignatureDER(t *testing.T) {
+	curveOrder := btcec.S256().N
+
+	r, _ := new(big.Int).SetString(
+		"6e5cf1c1c2d9c2d0e3ab76c8d6d52ee7fc0d0a3aef3d09b5b1c9a8c6e5d4c3b2",
+		16,
+	)
+	lowS, _ := new(big.Int).SetString(
+		"1d6c1a6f0e8f2a3b4c5d6e7f8091a2b3c4d5e6f708192a3b4c5d6e7f80912",
+		16,
+	)
+	highS := new(big.Int).Sub(curveOrder, lowS)
+
+	var tests = map[string]struct {
+		signature   *Signature
+		sigHashType []byte
+		expectedDER []byte
+	}{
+		"low S": {
+			signature:   &Signature{R: r, S: lowS, RecoveryID: 0},
+			expectedDER: (&btcec.Signature{R: r, S: lowS}).Serialize(),
+		},
+		"high S": {
+			signature:   &Signature{R: r, S: highS, RecoveryID: 1},
+			expectedDER: (&btcec.Signature{R: r, S: lowS}).Serialize(),
+		},
+		"small values": {
+			signature:   &Signature{R: big.NewInt(3), S: big.NewInt(7)},
+			expectedDER: []byte{0x30, 0x06, 0x02, 0x01, 0x03, 0x02, 0x01, 0x07},
+		},
+		"with sighash type": {
+			signature:   &Signature{R: big.NewInt(3), S: big.NewInt(7)},
+			sigHashType: []byte{0x01},
+			expectedDER: []byte{0x30, 0x06, 0x02, 0x01, 0x03, 0x02, 0x01, 0x07, 0x01},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			originalS := new(big.Int).Set(test.signature.S)
+
+			der, err := test.signature.DER(test.sigHashType...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(test.expectedDER, der) {
+				t.Errorf(
+					"unexpected DER encoding\n"+
+						"expected: [%x]\n"+
+						"actual:   [%x]",
+					test.expectedDER,
+					der,
+				)
+			}
+
+			if test.signature.S.Cmp(originalS) != 0 {
+				t.Errorf("signature has been modified by the encoding")
+			}
+		})
+	}
+}
+
+func TestSignatureDER_OutOfRange(t *testing.T) {
+	curveOrder := btcec.S256().N
+
+	var tests = map[string]struct {
+		signature *Signature
+	}{
+		"zero R": {
+			signature: &Signature{R: big.NewInt(0), S: big.NewInt(7)},
+		},
+		"S equal to curve order": {
+			signature: &Signature{R: big.NewInt(3), S: curveOrder},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			if _, err := test.signature.DER(); err == nil {
+				t.Errorf("expected error for signature out of range")
+			}
+		})
+	}
+}
+
+func TestSignDigest(t *testing.T) {
+	privateKey, err := crypto.HexToECDSA(
+		"289c2857d4598e37fb9647507e47a309d6133539bf21a8b9cb6df88fd5232032",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedAddress := common.HexToAddress(
+		"0x970E8128AB834E8EAC17Ab8E3812F010678CF791",
+	)
+
+	digest := [32]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	signature, err := SignDigest(privateKey, digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !signature.IsCanonical() {
+		t.Errorf("signature should be canonical")
+	}
+
+	serializedSignature := append(
+		common.LeftPadBytes(signature.R.Bytes(), 32),
+		common.LeftPadBytes(signature.S.Bytes(), 32)...,
+	)
+	serializedSignature = append(
+		serializedSignature,
+		byte(signature.RecoveryID),
+	)
+
+	publicKey, err := crypto.SigToPub(digest[:], serializedSignature)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	address := crypto.PubkeyToAddress(*publicKey)
+	if address != expectedAddress {
+		t.Errorf(
+			"unexpected recovered address\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedAddress.Hex(),
+			address.Hex(),
+		)
+	}
+}
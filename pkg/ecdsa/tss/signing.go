@@ -152,9 +152,15 @@ func convertSignatureTSStoECDSA(tssSignature common.SignatureData) ecdsa.Signatu
 	recoveryInt := int(0)
 	recoveryInt = (recoveryInt << 8) | int(recoveryBytes[0])
 
-	return ecdsa.Signature{
+	signature := ecdsa.Signature{
 		R:          new(big.Int).SetBytes(tssSignature.GetR()),
 		S:          new(big.Int).SetBytes(tssSignature.GetS()),
 		RecoveryID: recoveryInt,
 	}
+
+	// Keep contracts and bitcoin transactions accept only canonical
+	// signatures.
+	signature.Canonicalize()
+
+	return signature
 }
@@ -0,0 +1,79 @@
+package tbtc
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// ExponentialBackoff returns a backoff function which can be passed to the
+// deposit monitoring routines. For the given action attempt n, the returned
+// function computes a delay of initial * factor^(n-1). The computed delay
+// never exceeds max. Attempt numbers lower than 1 are treated as the first
+// attempt.
+func ExponentialBackoff(
+	initial time.Duration,
+	max time.Duration,
+	factor float64,
+) func(int) time.Duration {
+	return func(attempt int) time.Duration {
+		if attempt < 1 {
+			attempt = 1
+		}
+
+		backoff := float64(initial) * math.Pow(factor, float64(attempt-1))
+
+		// Compare as floats to not overflow the duration on large attempt
+		// numbers.
+		if backoff >= float64(max) || math.IsNaN(backoff) {
+			return max
+		}
+
+		return time.Duration(backoff)
+	}
+}
+
+// WithJitter wraps the given backoff function and randomizes each computed
+// delay by up to +/- fraction of its value. This way, signers restarted at the
+// same time do not retry their actions at the same moment. The returned delay
+// is never negative.
+func WithJitter(
+	base func(int) time.Duration,
+	fraction float64,
+) func(int) time.Duration {
+	return withJitter(
+		base,
+		fraction,
+		// #nosec G404 (insecure random number source (rand))
+		// No need to use secure randomness for jitter value.
+		rand.NewSource(time.Now().UnixNano()),
+	)
+}
+
+func withJitter(
+	base func(int) time.Duration,
+	fraction float64,
+	source rand.Source,
+) func(int) time.Duration {
+	// #nosec G404 (insecure random number source (rand))
+	// No need to use secure randomness for jitter value.
+	random := rand.New(source)
+	randomMutex := &sync.Mutex{}
+
+	return func(attempt int) time.Duration {
+		randomMutex.Lock()
+		// Random factor from range [-1, 1).
+		factor := 2*random.Float64() - 1
+		randomMutex.Unlock()
+
+		backoff := float64(base(attempt))
+		jittered := backoff + backoff*fraction*factor
+
+		if jittered < 0 {
+			return 0
+		}
+
+		return time.Duration(jittered)
+	}
+}
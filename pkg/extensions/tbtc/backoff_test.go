@@ -0,0 +1,144 @@
+package tbtc
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := ExponentialBackoff(
+		100*time.Millisecond,
+		30*time.Second,
+		2,
+	)
+
+	expectedBackoffs := []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		800 * time.Millisecond,
+		1600 * time.Millisecond,
+		3200 * time.Millisecond,
+		6400 * time.Millisecond,
+		12800 * time.Millisecond,
+		25600 * time.Millisecond,
+		30 * time.Second,
+		30 * time.Second,
+		30 * time.Second,
+	}
+
+	for i, expectedBackoff := range expectedBackoffs {
+		attempt := i + 1
+
+		actualBackoff := backoff(attempt)
+		if expectedBackoff != actualBackoff {
+			t.Errorf(
+				"unexpected backoff for attempt [%v]\n"+
+					"expected: [%v]\n"+
+					"actual:   [%v]",
+				attempt,
+				expectedBackoff,
+				actualBackoff,
+			)
+		}
+	}
+}
+
+func TestExponentialBackoff_LargeAttempt(t *testing.T) {
+	max := time.Minute
+
+	backoff := ExponentialBackoff(time.Second, max, 2)
+
+	actualBackoff := backoff(10000)
+	if max != actualBackoff {
+		t.Errorf(
+			"unexpected backoff\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			max,
+			actualBackoff,
+		)
+	}
+}
+
+func TestExponentialBackoff_NonPositiveAttempt(t *testing.T) {
+	initial := time.Second
+
+	backoff := ExponentialBackoff(initial, time.Minute, 2)
+
+	for _, attempt := range []int{0, -1, -100} {
+		actualBackoff := backoff(attempt)
+		if initial != actualBackoff {
+			t.Errorf(
+				"unexpected backoff for attempt [%v]\n"+
+					"expected: [%v]\n"+
+					"actual:   [%v]",
+				attempt,
+				initial,
+				actualBackoff,
+			)
+		}
+	}
+}
+
+func TestWithJitter(t *testing.T) {
+	fraction := 0.5
+
+	backoff := withJitter(constantBackoff, fraction, rand.NewSource(1))
+
+	base := float64(constantBackoff(1))
+	minBackoff := time.Duration(base - base*fraction)
+	maxBackoff := time.Duration(base + base*fraction)
+
+	for attempt := 1; attempt <= 100; attempt++ {
+		actualBackoff := backoff(attempt)
+		if actualBackoff < minBackoff || actualBackoff > maxBackoff {
+			t.Errorf(
+				"backoff for attempt [%v] out of expected range\n"+
+					"expected: [%v, %v]\n"+
+					"actual:   [%v]",
+				attempt,
+				minBackoff,
+				maxBackoff,
+				actualBackoff,
+			)
+		}
+	}
+}
+
+func TestWithJitter_Deterministic(t *testing.T) {
+	backoff1 := withJitter(constantBackoff, 0.5, rand.NewSource(10))
+	backoff2 := withJitter(constantBackoff, 0.5, rand.NewSource(10))
+
+	for attempt := 1; attempt <= 10; attempt++ {
+		backoffValue1 := backoff1(attempt)
+		backoffValue2 := backoff2(attempt)
+
+		if backoffValue1 != backoffValue2 {
+			t.Errorf(
+				"unexpected backoff for attempt [%v]\n"+
+					"expected: [%v]\n"+
+					"actual:   [%v]",
+				attempt,
+				backoffValue1,
+				backoffValue2,
+			)
+		}
+	}
+}
+
+func TestWithJitter_NeverNegative(t *testing.T) {
+	backoff := withJitter(constantBackoff, 5, rand.NewSource(1))
+
+	for attempt := 1; attempt <= 100; attempt++ {
+		actualBackoff := backoff(attempt)
+		if actualBackoff < 0 {
+			t.Errorf(
+				"negative backoff for attempt [%v]: [%v]",
+				attempt,
+				actualBackoff,
+			)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package tbtc
+
+import (
+	"sync"
+	"time"
+)
+
+// CircuitBreakerState describes whether a deposit monitoring is allowed to
+// perform its action.
+type CircuitBreakerState int
+
+const (
+	// CircuitBreakerClosed means the monitoring action is performed normally.
+	CircuitBreakerClosed CircuitBreakerState = iota
+	// CircuitBreakerOpen means the monitoring action has failed too many
+	// times in a row and is suspended until the cooldown period elapses.
+	CircuitBreakerOpen
+	// CircuitBreakerHalfOpen means the cooldown period has elapsed and the
+	// next action attempt decides whether the breaker closes or opens again.
+	CircuitBreakerHalfOpen
+)
+
+func (cbs CircuitBreakerState) String() string {
+	switch cbs {
+	case CircuitBreakerClosed:
+		return "closed"
+	case CircuitBreakerOpen:
+		return "open"
+	case CircuitBreakerHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// actionCircuitBreaker suspends the action of a single deposit monitoring
+// once it fails the threshold number of times in a row. A nil breaker never
+// opens.
+type actionCircuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mutex               sync.Mutex
+	state               CircuitBreakerState
+	consecutiveFailures int
+}
+
+// newActionCircuitBreaker creates a breaker opening after threshold
+// consecutive failures. Zero threshold disables the breaker and nil
+// is returned.
+func newActionCircuitBreaker(
+	threshold int,
+	cooldown time.Duration,
+) *actionCircuitBreaker {
+	if threshold <= 0 {
+		return nil
+	}
+
+	return &actionCircuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+	}
+}
+
+// recordFailure registers a failed action. It returns true if the failure
+// opened the breaker, in which case the action should not be attempted
+// again before the cooldown period elapses.
+func (acb *actionCircuitBreaker) recordFailure() bool {
+	if acb == nil {
+		return false
+	}
+
+	acb.mutex.Lock()
+	defer acb.mutex.Unlock()
+
+	acb.consecutiveFailures++
+
+	if acb.state == CircuitBreakerHalfOpen ||
+		acb.consecutiveFailures >= acb.threshold {
+		acb.state = CircuitBreakerOpen
+		return true
+	}
+
+	return false
+}
+
+// recordSuccess registers a successful action and closes the breaker.
+func (acb *actionCircuitBreaker) recordSuccess() {
+	if acb == nil {
+		return
+	}
+
+	acb.mutex.Lock()
+	defer acb.mutex.Unlock()
+
+	acb.consecutiveFailures = 0
+	acb.state = CircuitBreakerClosed
+}
+
+// cooldownElapsed moves an open breaker to the half-open state. It returns
+// true if the breaker was open.
+func (acb *actionCircuitBreaker) cooldownElapsed() bool {
+	if acb == nil {
+		return false
+	}
+
+	acb.mutex.Lock()
+	defer acb.mutex.Unlock()
+
+	if acb.state != CircuitBreakerOpen {
+		return false
+	}
+
+	acb.state = CircuitBreakerHalfOpen
+
+	return true
+}
+
+func (acb *actionCircuitBreaker) currentState() CircuitBreakerState {
+	if acb == nil {
+		return CircuitBreakerClosed
+	}
+
+	acb.mutex.Lock()
+	defer acb.mutex.Unlock()
+
+	return acb.state
+}
+
+// circuitBreakers keeps track of the breakers of running deposit
+// monitorings, grouped by the deposit address and the monitoring name.
+type circuitBreakers struct {
+	mutex    sync.RWMutex
+	breakers map[string]map[string]*actionCircuitBreaker
+}
+
+func newCircuitBreakers() *circuitBreakers {
+	return &circuitBreakers{
+		breakers: make(map[string]map[string]*actionCircuitBreaker),
+	}
+}
+
+func (cb *circuitBreakers) register(
+	depositAddress string,
+	monitoringName string,
+	breaker *actionCircuitBreaker,
+) {
+	if breaker == nil {
+		return
+	}
+
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+
+	if _, ok := cb.breakers[depositAddress]; !ok {
+		cb.breakers[depositAddress] = make(map[string]*actionCircuitBreaker)
+	}
+
+	cb.breakers[depositAddress][monitoringName] = breaker
+}
+
+func (cb *circuitBreakers) unregister(
+	depositAddress string,
+	monitoringName string,
+) {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+
+	delete(cb.breakers[depositAddress], monitoringName)
+
+	if len(cb.breakers[depositAddress]) == 0 {
+		delete(cb.breakers, depositAddress)
+	}
+}
+
+// states returns the current breaker states of all monitorings running
+// for the given deposit, keyed by the monitoring name.
+func (cb *circuitBreakers) states(
+	depositAddress string,
+) map[string]CircuitBreakerState {
+	cb.mutex.RLock()
+	defer cb.mutex.RUnlock()
+
+	states := make(map[string]CircuitBreakerState)
+	for monitoringName, breaker := range cb.breakers[depositAddress] {
+		states[monitoringName] = breaker.currentState()
+	}
+
+	return states
+}
@@ -0,0 +1,271 @@
+package tbtc
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestActionCircuitBreaker_StateTransitions(t *testing.T) {
+	breaker := newActionCircuitBreaker(2, time.Minute)
+
+	assertState := func(expectedState CircuitBreakerState) {
+		if breaker.currentState() != expectedState {
+			t.Errorf(
+				"unexpected circuit breaker state\n"+
+					"expected: [%v]\n"+
+					"actual:   [%v]",
+				expectedState,
+				breaker.currentState(),
+			)
+		}
+	}
+
+	if breaker.recordFailure() {
+		t.Errorf("circuit breaker opened before reaching the threshold")
+	}
+	assertState(CircuitBreakerClosed)
+
+	if !breaker.recordFailure() {
+		t.Errorf("circuit breaker not opened after reaching the threshold")
+	}
+	assertState(CircuitBreakerOpen)
+
+	if !breaker.cooldownElapsed() {
+		t.Errorf("open circuit breaker not moved to half-open state")
+	}
+	assertState(CircuitBreakerHalfOpen)
+
+	if !breaker.recordFailure() {
+		t.Errorf("half-open circuit breaker not opened after a failure")
+	}
+	assertState(CircuitBreakerOpen)
+
+	breaker.cooldownElapsed()
+	breaker.recordSuccess()
+	assertState(CircuitBreakerClosed)
+
+	if breaker.recordFailure() {
+		t.Errorf("consecutive failures not reset after a success")
+	}
+	assertState(CircuitBreakerClosed)
+}
+
+func TestActionCircuitBreaker_Disabled(t *testing.T) {
+	breaker := newActionCircuitBreaker(0, time.Minute)
+
+	for i := 0; i < 10; i++ {
+		if breaker.recordFailure() {
+			t.Fatalf("disabled circuit breaker opened")
+		}
+	}
+
+	if breaker.currentState() != CircuitBreakerClosed {
+		t.Errorf(
+			"unexpected circuit breaker state\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			CircuitBreakerClosed,
+			breaker.currentState(),
+		)
+	}
+}
+
+func TestMonitorDeposit_CircuitBreakerOpened(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	threshold := 3
+
+	var actCounter uint64
+	actFn := func(ctx context.Context, depositAddress string) error {
+		atomic.AddUint64(&actCounter, 1)
+		return fmt.Errorf("scripted failure")
+	}
+
+	shouldStop := func() (bool, error) {
+		return false, nil
+	}
+
+	breaker := newActionCircuitBreaker(threshold, time.Hour)
+
+	stopReasonChan := make(chan StopReason)
+	go func() {
+		stopReasonChan <- monitorDeposit(
+			ctx,
+			newMonitoringLog(
+				logger,
+				monitoringLogFields{
+					monitorType:    "monitoring",
+					depositAddress: "deposit",
+				},
+			),
+			"deposit",
+			make(chan struct{}),
+			make(chan StopReason),
+			0,
+			actFn,
+			shouldStop,
+			constantBackoff,
+			0,
+			breaker,
+			defaultClassifyError,
+			newMetrics().monitoring("monitoring"),
+		)
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for breaker.currentState() != CircuitBreakerOpen {
+		if time.Now().After(deadline) {
+			t.Fatalf("circuit breaker has not been opened")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// wait a while to make sure no action is attempted during the cooldown
+	time.Sleep(200 * time.Millisecond)
+
+	if atomic.LoadUint64(&actCounter) != uint64(threshold) {
+		t.Errorf(
+			"unexpected number of action invocations\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			threshold,
+			atomic.LoadUint64(&actCounter),
+		)
+	}
+
+	cancelCtx()
+
+	if stopReason := <-stopReasonChan; stopReason != StopReasonContextCancelled {
+		t.Errorf(
+			"unexpected stop reason\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			StopReasonContextCancelled,
+			stopReason,
+		)
+	}
+}
+
+func TestMonitorDeposit_CircuitBreakerCooldownElapsed(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	// scripted action failing twice and succeeding afterwards
+	var actCounter uint64
+	actFn := func(ctx context.Context, depositAddress string) error {
+		if atomic.AddUint64(&actCounter, 1) <= 2 {
+			return fmt.Errorf("scripted failure")
+		}
+		return nil
+	}
+
+	shouldStop := func() (bool, error) {
+		return false, nil
+	}
+
+	breaker := newActionCircuitBreaker(2, 100*time.Millisecond)
+
+	stopReason := monitorDeposit(
+		ctx,
+		newMonitoringLog(
+			logger,
+			monitoringLogFields{
+				monitorType:    "monitoring",
+				depositAddress: "deposit",
+			},
+		),
+		"deposit",
+		make(chan struct{}),
+		make(chan StopReason),
+		0,
+		actFn,
+		shouldStop,
+		constantBackoff,
+		0,
+		breaker,
+		defaultClassifyError,
+		newMetrics().monitoring("monitoring"),
+	)
+
+	if stopReason != StopReasonActionPerformed {
+		t.Errorf(
+			"unexpected stop reason\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			StopReasonActionPerformed,
+			stopReason,
+		)
+	}
+
+	expectedActCounter := uint64(3)
+	if actCounter != expectedActCounter {
+		t.Errorf(
+			"unexpected number of action invocations\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedActCounter,
+			actCounter,
+		)
+	}
+
+	if breaker.currentState() != CircuitBreakerClosed {
+		t.Errorf(
+			"unexpected circuit breaker state\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			CircuitBreakerClosed,
+			breaker.currentState(),
+		)
+	}
+}
+
+func TestCircuitBreakers_States(t *testing.T) {
+	breakers := newCircuitBreakers()
+
+	openBreaker := newActionCircuitBreaker(1, time.Minute)
+	openBreaker.recordFailure()
+
+	breakers.register("deposit", "retrieve pubkey", openBreaker)
+	breakers.register(
+		"deposit",
+		"provide redemption proof",
+		newActionCircuitBreaker(1, time.Minute),
+	)
+	breakers.register(
+		"other deposit",
+		"retrieve pubkey",
+		newActionCircuitBreaker(1, time.Minute),
+	)
+
+	expectedStates := map[string]CircuitBreakerState{
+		"retrieve pubkey":          CircuitBreakerOpen,
+		"provide redemption proof": CircuitBreakerClosed,
+	}
+	if !reflect.DeepEqual(expectedStates, breakers.states("deposit")) {
+		t.Errorf(
+			"unexpected circuit breaker states\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedStates,
+			breakers.states("deposit"),
+		)
+	}
+
+	breakers.unregister("deposit", "retrieve pubkey")
+	breakers.unregister("deposit", "provide redemption proof")
+
+	if len(breakers.states("deposit")) != 0 {
+		t.Errorf(
+			"unexpected circuit breaker states\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			map[string]CircuitBreakerState{},
+			breakers.states("deposit"),
+		)
+	}
+}
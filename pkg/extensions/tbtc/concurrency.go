@@ -0,0 +1,121 @@
+package tbtc
+
+import (
+	"context"
+	"sync"
+)
+
+// MonitoringConcurrency describes how many deposits are monitored at once by
+// the TBTC extension.
+type MonitoringConcurrency struct {
+	// Number of deposits actively monitored.
+	Active int
+	// Number of deposit monitorings waiting for a free slot.
+	Queued int
+	// Maximum number of deposits monitored at once. Zero means there is
+	// no limit.
+	Limit int
+}
+
+// depositMonitoringLimiter bounds the number of deposits monitored at once.
+// A deposit occupies a single slot no matter how many monitorings are running
+// for it. Monitorings of deposits that do not fit into the limit wait for
+// a slot to be released.
+type depositMonitoringLimiter struct {
+	limit int
+	slots chan struct{}
+
+	mutex  sync.Mutex
+	active map[string]int
+	queued int
+}
+
+func newDepositMonitoringLimiter(limit int) *depositMonitoringLimiter {
+	limiter := &depositMonitoringLimiter{
+		limit:  limit,
+		active: make(map[string]int),
+	}
+
+	if limit > 0 {
+		limiter.slots = make(chan struct{}, limit)
+	}
+
+	return limiter
+}
+
+// acquire blocks until the given deposit can be actively monitored or the
+// context is done. It returns false if the context is done before a slot
+// becomes available.
+func (dml *depositMonitoringLimiter) acquire(
+	ctx context.Context,
+	depositAddress string,
+) bool {
+	dml.mutex.Lock()
+	if dml.slots == nil || dml.active[depositAddress] > 0 {
+		dml.active[depositAddress]++
+		dml.mutex.Unlock()
+		return true
+	}
+	dml.queued++
+	dml.mutex.Unlock()
+
+	acquired := false
+	select {
+	case dml.slots <- struct{}{}:
+		acquired = true
+	case <-ctx.Done():
+	}
+
+	dml.mutex.Lock()
+	defer dml.mutex.Unlock()
+
+	dml.queued--
+
+	if !acquired {
+		return false
+	}
+
+	dml.active[depositAddress]++
+
+	// Another monitoring of the same deposit may have taken a slot in the
+	// meantime. The deposit needs only one of them.
+	if dml.active[depositAddress] > 1 {
+		<-dml.slots
+	}
+
+	return true
+}
+
+// release frees the slot taken by the given deposit once all its
+// monitorings have completed.
+func (dml *depositMonitoringLimiter) release(depositAddress string) {
+	dml.mutex.Lock()
+	defer dml.mutex.Unlock()
+
+	count, ok := dml.active[depositAddress]
+	if !ok {
+		return
+	}
+
+	if count > 1 {
+		dml.active[depositAddress] = count - 1
+		return
+	}
+
+	delete(dml.active, depositAddress)
+
+	if dml.slots != nil {
+		<-dml.slots
+	}
+}
+
+func (dml *depositMonitoringLimiter) concurrency() MonitoringConcurrency {
+	dml.mutex.Lock()
+	defer dml.mutex.Unlock()
+
+	return MonitoringConcurrency{
+		Active: len(dml.active),
+		Queued: dml.queued,
+		Limit:  dml.limit,
+	}
+}
@@ -11,6 +11,10 @@ import (
 const (
 	// The default value of a timeout for liquidation recovery.
 	defaultLiquidationRecoveryTimeout = 48 * time.Hour
+
+	// The default time for which the action of a deposit monitoring is
+	// suspended once its circuit breaker opens.
+	defaultCircuitBreakerCooldown = 30 * time.Minute
 )
 
 // Config stores configuration of application extensions responsible for
@@ -19,6 +23,18 @@ type Config struct {
 	TBTCSystem                 string
 	Bitcoin                    bitcoin.Config
 	LiquidationRecoveryTimeout configtime.Duration
+	// Maximum number of deposits monitored at once. Zero means there is
+	// no limit.
+	MaxConcurrentDepositMonitorings int
+	// First block from which deposit actions missed while the client was
+	// down are resumed on startup. Zero means actions requested within
+	// the last deposit term are resumed.
+	ReconciliationStartBlock uint64
+	// Number of consecutive failures of a deposit monitoring action after
+	// which the action is suspended for CircuitBreakerCooldown. Zero
+	// disables the circuit breaker.
+	CircuitBreakerThreshold int
+	CircuitBreakerCooldown  configtime.Duration
 }
 
 // GetLiquidationRecoveryTimeout returns the liquidation recovery timeout. If a
@@ -31,3 +47,14 @@ func (c *Config) GetLiquidationRecoveryTimeout() time.Duration {
 
 	return timeout
 }
+
+// GetCircuitBreakerCooldown returns the circuit breaker cooldown. If a value
+// is not set it returns a default value.
+func (c *Config) GetCircuitBreakerCooldown() time.Duration {
+	cooldown := c.CircuitBreakerCooldown.ToDuration()
+	if cooldown == 0 {
+		cooldown = defaultCircuitBreakerCooldown
+	}
+
+	return cooldown
+}
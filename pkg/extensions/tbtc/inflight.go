@@ -0,0 +1,54 @@
+package tbtc
+
+import "sync"
+
+// inFlightActions makes sure only one action runs at a time for the given key.
+// Actions triggered for a key while another one is in flight are coalesced
+// with the in-flight action and share its result.
+type inFlightActions struct {
+	mutex   sync.Mutex
+	actions map[string]*inFlightAction
+}
+
+type inFlightAction struct {
+	done chan struct{}
+	err  error
+}
+
+func newInFlightActions() *inFlightActions {
+	return &inFlightActions{
+		actions: make(map[string]*inFlightAction),
+	}
+}
+
+// do executes actionFn unless an action for the given key is already in
+// flight. In that case, it waits for the in-flight action to complete and
+// returns its result. The returned flag is true if the result is shared with
+// the in-flight action.
+func (ifa *inFlightActions) do(
+	key string,
+	actionFn func() error,
+) (shared bool, err error) {
+	ifa.mutex.Lock()
+	if action, ok := ifa.actions[key]; ok {
+		ifa.mutex.Unlock()
+		<-action.done
+		return true, action.err
+	}
+
+	action := &inFlightAction{done: make(chan struct{})}
+	ifa.actions[key] = action
+	ifa.mutex.Unlock()
+
+	defer func() {
+		ifa.mutex.Lock()
+		delete(ifa.actions, key)
+		ifa.mutex.Unlock()
+
+		close(action.done)
+	}()
+
+	action.err = actionFn()
+
+	return false, action.err
+}
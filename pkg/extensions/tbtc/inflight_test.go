@@ -0,0 +1,111 @@
+package tbtc
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestInFlightActions_Coalesce(t *testing.T) {
+	inFlight := newInFlightActions()
+
+	actionStarted := make(chan struct{})
+	releaseAction := make(chan struct{})
+	expectedErr := fmt.Errorf("action failed")
+
+	var actionCounter uint64
+	actionFn := func() error {
+		atomic.AddUint64(&actionCounter, 1)
+		close(actionStarted)
+		<-releaseAction
+		return expectedErr
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	var firstShared, secondShared bool
+	var firstErr, secondErr error
+
+	go func() {
+		defer wg.Done()
+		firstShared, firstErr = inFlight.do("deposit", actionFn)
+	}()
+
+	<-actionStarted
+
+	go func() {
+		defer wg.Done()
+		secondShared, secondErr = inFlight.do("deposit", actionFn)
+	}()
+
+	// wait a while before completing the first action to make sure
+	// the second trigger occurs while the first action is in flight
+	time.Sleep(100 * time.Millisecond)
+	close(releaseAction)
+	wg.Wait()
+
+	if actionCounter != 1 {
+		t.Errorf(
+			"unexpected number of action invocations\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			1,
+			actionCounter,
+		)
+	}
+
+	if firstShared || !secondShared {
+		t.Errorf(
+			"unexpected shared flags\n"+
+				"expected: [false true]\n"+
+				"actual:   [%v %v]",
+			firstShared,
+			secondShared,
+		)
+	}
+
+	if firstErr != expectedErr || secondErr != expectedErr {
+		t.Errorf(
+			"unexpected errors\n"+
+				"expected: [%v %v]\n"+
+				"actual:   [%v %v]",
+			expectedErr,
+			expectedErr,
+			firstErr,
+			secondErr,
+		)
+	}
+}
+
+func TestInFlightActions_Sequential(t *testing.T) {
+	inFlight := newInFlightActions()
+
+	var actionCounter uint64
+	actionFn := func() error {
+		atomic.AddUint64(&actionCounter, 1)
+		return nil
+	}
+
+	for i := 0; i < 2; i++ {
+		shared, err := inFlight.do("deposit", actionFn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if shared {
+			t.Errorf("unexpected shared result of a sequential action")
+		}
+	}
+
+	if actionCounter != 2 {
+		t.Errorf(
+			"unexpected number of action invocations\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			2,
+			actionCounter,
+		)
+	}
+}
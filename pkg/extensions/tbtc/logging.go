@@ -0,0 +1,77 @@
+package tbtc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// leveledLogger is the part of the package logger used by deposit
+// monitorings.
+type leveledLogger interface {
+	Infof(format string, args ...interface{})
+	Warningf(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+}
+
+// monitoringLogFields identify a single deposit monitoring in log messages
+// so the lifecycle of a deposit can be traced on a busy node.
+type monitoringLogFields struct {
+	monitorType    string
+	depositAddress string
+	keepAddress    string
+	attempt        int
+}
+
+// String formats the fields as key=[value] pairs. The keep address and
+// the attempt are omitted if they are not known.
+func (mlf monitoringLogFields) String() string {
+	fields := []string{
+		fmt.Sprintf("monitorType=[%v]", mlf.monitorType),
+		fmt.Sprintf("depositAddress=[%v]", mlf.depositAddress),
+	}
+
+	if mlf.keepAddress != "" {
+		fields = append(fields, fmt.Sprintf("keepAddress=[%v]", mlf.keepAddress))
+	}
+
+	if mlf.attempt > 0 {
+		fields = append(fields, fmt.Sprintf("attempt=[%v]", mlf.attempt))
+	}
+
+	return strings.Join(fields, " ")
+}
+
+// monitoringLog writes log messages tagged with the fields of a deposit
+// monitoring.
+type monitoringLog struct {
+	logger leveledLogger
+	fields monitoringLogFields
+}
+
+func newMonitoringLog(
+	logger leveledLogger,
+	fields monitoringLogFields,
+) *monitoringLog {
+	return &monitoringLog{logger, fields}
+}
+
+// withAttempt returns a log tagged with the given action attempt number
+// in addition to the fields of this log.
+func (ml *monitoringLog) withAttempt(attempt int) *monitoringLog {
+	fields := ml.fields
+	fields.attempt = attempt
+
+	return newMonitoringLog(ml.logger, fields)
+}
+
+func (ml *monitoringLog) Infof(format string, args ...interface{}) {
+	ml.logger.Infof("%v; %v", fmt.Sprintf(format, args...), ml.fields)
+}
+
+func (ml *monitoringLog) Warningf(format string, args ...interface{}) {
+	ml.logger.Warningf("%v; %v", fmt.Sprintf(format, args...), ml.fields)
+}
+
+func (ml *monitoringLog) Errorf(format string, args ...interface{}) {
+	ml.logger.Errorf("%v; %v", fmt.Sprintf(format, args...), ml.fields)
+}
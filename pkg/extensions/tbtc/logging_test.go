@@ -0,0 +1,152 @@
+package tbtc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type recordingLogger struct {
+	mutex    sync.Mutex
+	messages []string
+}
+
+func (rl *recordingLogger) record(format string, args ...interface{}) {
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+
+	rl.messages = append(rl.messages, fmt.Sprintf(format, args...))
+}
+
+func (rl *recordingLogger) Infof(format string, args ...interface{}) {
+	rl.record(format, args...)
+}
+
+func (rl *recordingLogger) Warningf(format string, args ...interface{}) {
+	rl.record(format, args...)
+}
+
+func (rl *recordingLogger) Errorf(format string, args ...interface{}) {
+	rl.record(format, args...)
+}
+
+func TestMonitoringLogFields(t *testing.T) {
+	var tests = map[string]struct {
+		fields         monitoringLogFields
+		expectedString string
+	}{
+		"all fields": {
+			fields: monitoringLogFields{
+				monitorType:    "retrieve pubkey",
+				depositAddress: "0xa5FA806723A7c7c8523F33c39686f20b52612877",
+				keepAddress:    "0x41048F9B90290A2e96D07f537F3A7E97620E9e47",
+				attempt:        2,
+			},
+			expectedString: "monitorType=[retrieve pubkey] " +
+				"depositAddress=[0xa5FA806723A7c7c8523F33c39686f20b52612877] " +
+				"keepAddress=[0x41048F9B90290A2e96D07f537F3A7E97620E9e47] " +
+				"attempt=[2]",
+		},
+		"unknown keep address and attempt": {
+			fields: monitoringLogFields{
+				monitorType:    "retrieve pubkey",
+				depositAddress: "0xa5FA806723A7c7c8523F33c39686f20b52612877",
+			},
+			expectedString: "monitorType=[retrieve pubkey] " +
+				"depositAddress=[0xa5FA806723A7c7c8523F33c39686f20b52612877]",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			if test.expectedString != test.fields.String() {
+				t.Errorf(
+					"unexpected fields string\n"+
+						"expected: [%v]\n"+
+						"actual:   [%v]",
+					test.expectedString,
+					test.fields.String(),
+				)
+			}
+		})
+	}
+}
+
+func TestMonitorDeposit_ActionFailedLogContainsDepositAddress(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	recordingLogger := &recordingLogger{}
+
+	actFn := func(ctx context.Context, depositAddress string) error {
+		return fmt.Errorf("scripted failure")
+	}
+
+	shouldStop := func() (bool, error) {
+		return false, nil
+	}
+
+	stopReason := monitorDeposit(
+		ctx,
+		newMonitoringLog(
+			recordingLogger,
+			monitoringLogFields{
+				monitorType:    "retrieve pubkey",
+				depositAddress: depositAddress,
+			},
+		),
+		depositAddress,
+		make(chan struct{}),
+		make(chan StopReason),
+		0,
+		actFn,
+		shouldStop,
+		constantBackoff,
+		maxActAttempts,
+		nil,
+		defaultClassifyError,
+		newMetrics().monitoring("retrieve pubkey"),
+	)
+
+	if stopReason != StopReasonActionGaveUp {
+		t.Errorf(
+			"unexpected stop reason\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			StopReasonActionGaveUp,
+			stopReason,
+		)
+	}
+
+	actionFailedMessages := 0
+	for _, message := range recordingLogger.messages {
+		if !strings.Contains(message, "could not perform monitored action") {
+			continue
+		}
+
+		actionFailedMessages++
+
+		expectedField := fmt.Sprintf("depositAddress=[%v]", depositAddress)
+		if !strings.Contains(message, expectedField) {
+			t.Errorf(
+				"action failed message does not contain deposit address\n"+
+					"expected field: [%v]\n"+
+					"message:        [%v]",
+				expectedField,
+				message,
+			)
+		}
+	}
+
+	if actionFailedMessages != maxActAttempts {
+		t.Errorf(
+			"unexpected number of action failed messages\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			maxActAttempts,
+			actionFailedMessages,
+		)
+	}
+}
@@ -0,0 +1,87 @@
+package tbtc
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// MonitoringMetrics holds action counters of a single deposit monitoring.
+type MonitoringMetrics struct {
+	// Number of times the monitoring action has been performed.
+	Attempts uint64
+	// Number of times the monitoring action has completed successfully.
+	Successes uint64
+	// Number of times the monitoring action has failed.
+	Failures uint64
+}
+
+// Metrics tracks action counters of all deposit monitorings run by the
+// TBTC extension. Counters are grouped by the monitoring name.
+type Metrics struct {
+	countersMutex sync.RWMutex
+	counters      map[string]*monitoringCounters
+}
+
+type monitoringCounters struct {
+	attempts  uint64
+	successes uint64
+	failures  uint64
+}
+
+func newMetrics() *Metrics {
+	return &Metrics{
+		counters: make(map[string]*monitoringCounters),
+	}
+}
+
+// Snapshot returns a copy of the current counter values for all monitorings.
+// The returned map is keyed by the monitoring name.
+func (m *Metrics) Snapshot() map[string]MonitoringMetrics {
+	m.countersMutex.RLock()
+	defer m.countersMutex.RUnlock()
+
+	snapshot := make(map[string]MonitoringMetrics, len(m.counters))
+	for monitoringName, counters := range m.counters {
+		snapshot[monitoringName] = MonitoringMetrics{
+			Attempts:  atomic.LoadUint64(&counters.attempts),
+			Successes: atomic.LoadUint64(&counters.successes),
+			Failures:  atomic.LoadUint64(&counters.failures),
+		}
+	}
+
+	return snapshot
+}
+
+func (m *Metrics) monitoring(monitoringName string) *monitoringCounters {
+	m.countersMutex.RLock()
+	counters, ok := m.counters[monitoringName]
+	m.countersMutex.RUnlock()
+
+	if ok {
+		return counters
+	}
+
+	m.countersMutex.Lock()
+	defer m.countersMutex.Unlock()
+
+	if counters, ok := m.counters[monitoringName]; ok {
+		return counters
+	}
+
+	counters = &monitoringCounters{}
+	m.counters[monitoringName] = counters
+
+	return counters
+}
+
+func (mc *monitoringCounters) recordAttempt() {
+	atomic.AddUint64(&mc.attempts, 1)
+}
+
+func (mc *monitoringCounters) recordSuccess() {
+	atomic.AddUint64(&mc.successes, 1)
+}
+
+func (mc *monitoringCounters) recordFailure() {
+	atomic.AddUint64(&mc.failures, 1)
+}
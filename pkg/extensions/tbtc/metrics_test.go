@@ -0,0 +1,55 @@
+package tbtc
+
+import (
+	"testing"
+)
+
+func TestMetricsSnapshot(t *testing.T) {
+	metrics := newMetrics()
+
+	counters := metrics.monitoring("monitoring")
+	counters.recordAttempt()
+	counters.recordAttempt()
+	counters.recordFailure()
+	counters.recordSuccess()
+
+	snapshot := metrics.Snapshot()
+
+	// further changes must not affect the taken snapshot
+	counters.recordAttempt()
+
+	expectedMetrics := MonitoringMetrics{
+		Attempts:  2,
+		Successes: 1,
+		Failures:  1,
+	}
+	actualMetrics := snapshot["monitoring"]
+	if expectedMetrics != actualMetrics {
+		t.Errorf(
+			"unexpected monitoring metrics\n"+
+				"expected: [%+v]\n"+
+				"actual:   [%+v]",
+			expectedMetrics,
+			actualMetrics,
+		)
+	}
+}
+
+func TestMetricsSnapshot_SameMonitoring(t *testing.T) {
+	metrics := newMetrics()
+
+	metrics.monitoring("monitoring").recordAttempt()
+	metrics.monitoring("monitoring").recordAttempt()
+
+	expectedAttempts := uint64(2)
+	actualAttempts := metrics.Snapshot()["monitoring"].Attempts
+	if expectedAttempts != actualAttempts {
+		t.Errorf(
+			"unexpected number of attempts\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedAttempts,
+			actualAttempts,
+		)
+	}
+}
@@ -0,0 +1,93 @@
+package recovery
+
+import (
+	"github.com/btcsuite/btcd/wire"
+)
+
+// AddressType is a type of a bitcoin address the recovery transaction can pay
+// to.
+type AddressType int
+
+// Address types supported as recovery transaction outputs.
+const (
+	P2PKH AddressType = iota
+	P2SH
+	P2WPKH
+	P2WSH
+)
+
+// outputScriptSize returns the size in bytes of the output script paying to
+// an address of the given type. Unknown types are assumed to have the
+// longest supported script so the estimate errs on the side of a higher fee.
+func (at AddressType) outputScriptSize() int {
+	switch at {
+	case P2PKH:
+		return 25 // OP_DUP OP_HASH160 <20 bytes> OP_EQUALVERIFY OP_CHECKSIG
+	case P2SH:
+		return 23 // OP_HASH160 <20 bytes> OP_EQUAL
+	case P2WPKH:
+		return 22 // OP_0 <20 bytes>
+	default:
+		return 34 // OP_0 <32 bytes>
+	}
+}
+
+const (
+	// witnessScaleFactor is the factor by which non-witness data is
+	// weighted more than the witness data according to [BIP141].
+	witnessScaleFactor = 4
+
+	// transactionOverheadSize is the size of the version and lock time
+	// fields.
+	transactionOverheadSize = 4 + 4
+	// witnessMarkerAndFlagSize is the size of the segwit marker and flag
+	// fields.
+	witnessMarkerAndFlagSize = 2
+
+	// inputSize is the size of a P2WPKH input: the outpoint hash and index,
+	// the empty scriptSig length and the sequence.
+	inputSize = 32 + 4 + 1 + 4
+	// inputWitnessSize is the size of a P2WPKH input witness: the number of
+	// stack items, the longest DER signature with the hash type and the
+	// compressed public key, each preceded by its length.
+	inputWitnessSize = 1 + 1 + 74 + 1 + 33
+
+	// outputValueSize is the size of an output value field.
+	outputValueSize = 8
+)
+
+// EstimateVirtualSize estimates the virtual size of a recovery transaction
+// spending the given number of P2WPKH inputs to the given number of outputs
+// of the given type. The estimate follows the weight rules of [BIP141] and
+// assumes the longest possible signatures, so the actual transaction is never
+// larger.
+//
+// [BIP141]: https://github.com/bitcoin/bips/blob/master/bip-0141.mediawiki
+func EstimateVirtualSize(
+	inputCount int,
+	outputCount int,
+	outputType AddressType,
+) int {
+	outputScriptSize := outputType.outputScriptSize()
+	outputSize := outputValueSize +
+		wire.VarIntSerializeSize(uint64(outputScriptSize)) +
+		outputScriptSize
+
+	baseSize := transactionOverheadSize +
+		wire.VarIntSerializeSize(uint64(inputCount)) +
+		inputCount*inputSize +
+		wire.VarIntSerializeSize(uint64(outputCount)) +
+		outputCount*outputSize
+
+	witnessSize := witnessMarkerAndFlagSize + inputCount*inputWitnessSize
+
+	weight := baseSize*witnessScaleFactor + witnessSize
+
+	return (weight + witnessScaleFactor - 1) / witnessScaleFactor
+}
+
+// RecommendedFee computes the fee of a transaction of the given virtual size
+// paying the given fee rate in satoshi per vbyte.
+func RecommendedFee(vsize int, satPerVbyte int32) int64 {
+	return int64(vsize) * int64(satPerVbyte)
+}
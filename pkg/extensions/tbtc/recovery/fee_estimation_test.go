@@ -0,0 +1,91 @@
+package recovery
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/mempool"
+	"github.com/btcsuite/btcutil"
+)
+
+func TestEstimateVirtualSize(t *testing.T) {
+	// The estimate assumes the longest signatures and rounds the vsize up,
+	// so it may exceed the size of the constructed transaction slightly.
+	tolerance := 1
+
+	var tests = map[string]struct {
+		inputCount  int
+		outputCount int
+	}{
+		"single input, single output": {
+			inputCount:  1,
+			outputCount: 1,
+		},
+		"single input, multiple outputs": {
+			inputCount:  1,
+			outputCount: 3,
+		},
+		"multiple inputs, multiple outputs": {
+			inputCount:  5,
+			outputCount: 3,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			previousOutputs := make([]*previousOutput, test.inputCount)
+			for i := range previousOutputs {
+				previousOutputs[i] = &previousOutput{
+					transactionHashHex: "0b99dea9655f219991001e9296cfe2103dd918a21ef477a14121d1a0ba9491f1",
+					outputIndex:        uint32(i),
+					value:              int64(100000000),
+				}
+			}
+
+			recipientAddresses := make([]string, test.outputCount)
+			for i := range recipientAddresses {
+				recipientAddresses[i] = "bcrt1q5sz7jly79m76a5e8py6kv402q07p725vm4s0zl"
+			}
+
+			tx, err := constructUnsignedTransaction(
+				previousOutputs,
+				int64(700),
+				recipientAddresses,
+				&chaincfg.TestNet3Params,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			actualVsize := int(mempool.GetTxVirtualSize(btcutil.NewTx(tx)))
+			estimatedVsize := EstimateVirtualSize(
+				test.inputCount,
+				test.outputCount,
+				P2WPKH,
+			)
+
+			if estimatedVsize < actualVsize ||
+				estimatedVsize > actualVsize+tolerance {
+				t.Errorf(
+					"unexpected vsize estimate\nexpected: %d (+%d)\nactual:   %d",
+					actualVsize,
+					tolerance,
+					estimatedVsize,
+				)
+			}
+		})
+	}
+}
+
+func TestRecommendedFee(t *testing.T) {
+	expectedFee := int64(7500)
+
+	fee := RecommendedFee(100, 75)
+	if fee != expectedFee {
+		t.Errorf(
+			"unexpected fee\nexpected: %d\nactual:   %d",
+			expectedFee,
+			fee,
+		)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	cecdsa "crypto/ecdsa"
 	"encoding/hex"
 	"fmt"
+	"math/big"
 	"strings"
 
 	"github.com/ipfs/go-log"
@@ -65,24 +66,104 @@ func publicKeyToP2WPKHScriptCode(
 	return script, nil
 }
 
-// constructUnsignedTransaction produces an unsigned transaction
+// ComputeP2WPKHSigHash computes the [BIP143] signature hash of the input at the
+// given index of the transaction, spending a p2wpkh output of the given amount
+// with the given scriptCode. This is the hash signed for every input of the
+// recovery transaction, with the SIGHASH_ALL hash type.
+//
+// [BIP143]: https://github.com/bitcoin/bips/blob/master/bip-0143.mediawiki
+func ComputeP2WPKHSigHash(
+	tx *wire.MsgTx,
+	inputIndex int,
+	scriptCode []byte,
+	amount int64,
+) ([]byte, error) {
+	if inputIndex < 0 || inputIndex >= len(tx.TxIn) {
+		return nil, fmt.Errorf(
+			"input index [%d] is out of range for transaction with [%d] inputs",
+			inputIndex,
+			len(tx.TxIn),
+		)
+	}
+
+	return txscript.CalcWitnessSigHash(
+		scriptCode,
+		txscript.NewTxSigHashes(tx),
+		txscript.SigHashAll,
+		tx,
+		inputIndex,
+		amount,
+	)
+}
+
+// previousOutput is a transaction output spent by the recovery transaction.
+type previousOutput struct {
+	transactionHashHex string
+	outputIndex        uint32
+	value              int64
+}
+
+// replaceByFeeSequence is the input sequence number signaling the transaction
+// is replaceable according to [BIP125].
+//
+// [BIP125]: https://github.com/bitcoin/bips/blob/master/bip-0125.mediawiki
+const replaceByFeeSequence = uint32(0xfffffffd)
+
+// minRelayFeePerVbyte is the default minimum relay fee rate of Bitcoin Core
+// nodes, in satoshi per vbyte. Transactions paying less are not accepted to
+// mempool and fail to broadcast.
+const minRelayFeePerVbyte = int64(1)
+
+// transactionConfig holds optional settings of the constructed transaction.
+type transactionConfig struct {
+	changeAddress string
+	changeValue   int64
+	replaceByFee  bool
+}
+
+// transactionOption sets an optional setting of the constructed transaction.
+type transactionOption func(config *transactionConfig)
+
+// withChange makes the constructed transaction return the given value to the
+// given change address. Zero change value means no change output is added.
+func withChange(changeAddress string, changeValue int64) transactionOption {
+	return func(config *transactionConfig) {
+		config.changeAddress = changeAddress
+		config.changeValue = changeValue
+	}
+}
+
+// withReplaceByFee makes the constructed transaction opt into [BIP125]
+// replace-by-fee, so that it can be fee-bumped if it gets stuck in mempool.
+func withReplaceByFee() transactionOption {
+	return func(config *transactionConfig) {
+		config.replaceByFee = true
+	}
+}
+
+// constructUnsignedTransaction produces an unsigned transaction spending all
+// the given previous outputs. The total value of the previous outputs, minus
+// the fee and the optional change, is split equally between recipients.
 func constructUnsignedTransaction(
-	previousTransactionHashHex string,
-	previousOutputIndex uint32,
-	previousOutputValue int64,
+	previousOutputs []*previousOutput,
 	feePerVbyte int64,
 	recipientAddresses []string,
 	chainParams *chaincfg.Params,
+	options ...transactionOption,
 ) (*wire.MsgTx, error) {
-	// If the previous output transaction hash is passed as a []byte, can use
-	// chainhash.NewHash.
-	previousOutputTransactionHash, err := chainhash.NewHashFromStr(
-		previousTransactionHashHex,
-	)
-	if err != nil {
+	config := &transactionConfig{}
+	for _, option := range options {
+		option(config)
+	}
+
+	if len(previousOutputs) == 0 {
+		return nil, fmt.Errorf("at least one previous output is required")
+	}
+
+	if config.changeValue < 0 {
 		return nil, fmt.Errorf(
-			"error decoding outpoint transaction hash: [%s]",
-			previousOutputTransactionHash,
+			"change value [%d] must not be negative",
+			config.changeValue,
 		)
 	}
 
@@ -96,27 +177,46 @@ func constructUnsignedTransaction(
 	dummyCompressedPublicKeyForWitness := bytes.Repeat([]byte{0}, 33)
 
 	tx := wire.NewMsgTx(wire.TxVersion)
-	txIn := wire.NewTxIn(
-		wire.NewOutPoint(previousOutputTransactionHash, previousOutputIndex),
-		[]byte{}, // scriptSig is empty here
-		[][]byte{
-			dummySignatureForWitness,
-			dummyCompressedPublicKeyForWitness,
-		},
-	)
-	txIn.Sequence = 0
-	tx.AddTxIn(txIn)
 
-	for _, recipientAddress := range recipientAddresses {
-		address, err := btcutil.DecodeAddress(recipientAddress, chainParams)
+	previousOutputsValue := int64(0)
+	for _, previousOutput := range previousOutputs {
+		// If the previous output transaction hash is passed as a []byte, can
+		// use chainhash.NewHash.
+		previousOutputTransactionHash, err := chainhash.NewHashFromStr(
+			previousOutput.transactionHashHex,
+		)
 		if err != nil {
 			return nil, fmt.Errorf(
-				"error decoding recipient address [%s]: [%s]",
-				recipientAddress,
+				"error decoding outpoint transaction hash [%s]: [%s]",
+				previousOutput.transactionHashHex,
 				err,
 			)
 		}
-		outputScript, err := txscript.PayToAddrScript(address)
+
+		// Every input is spent with its own witness, so each one gets its
+		// own placeholder for fee purposes.
+		txIn := wire.NewTxIn(
+			wire.NewOutPoint(
+				previousOutputTransactionHash,
+				previousOutput.outputIndex,
+			),
+			[]byte{}, // scriptSig is empty here
+			[][]byte{
+				dummySignatureForWitness,
+				dummyCompressedPublicKeyForWitness,
+			},
+		)
+		txIn.Sequence = 0
+		if config.replaceByFee {
+			txIn.Sequence = replaceByFeeSequence
+		}
+		tx.AddTxIn(txIn)
+
+		previousOutputsValue += previousOutput.value
+	}
+
+	for _, recipientAddress := range recipientAddresses {
+		outputScript, err := addressToOutputScript(recipientAddress, chainParams)
 		if err != nil {
 			return nil, fmt.Errorf(
 				"error constructing script from recipient address [%s]: [%s]",
@@ -131,27 +231,284 @@ func constructUnsignedTransaction(
 		))
 	}
 
+	if config.changeValue > 0 {
+		changeScript, err := addressToOutputScript(
+			config.changeAddress,
+			chainParams,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error constructing script from change address [%s]: [%s]",
+				config.changeAddress,
+				err,
+			)
+		}
+
+		tx.AddTxOut(wire.NewTxOut(config.changeValue, changeScript))
+	}
+
 	// Compute weight and vsize per [BIP141], except vsize is truncated
 	// instead of rounded up, then compute the final fee and set the
 	// per-recipient value. Could result in a fractionally low fee.
 	vsize := mempool.GetTxVirtualSize(btcutil.NewTx(tx))
 	fee := feePerVbyte * int64(vsize)
-	perRecipientValue := (previousOutputValue - fee) / int64(len(recipientAddresses))
-	for _, txOut := range tx.TxOut {
+
+	if err := validateRelayFee(fee, vsize); err != nil {
+		return nil, err
+	}
+
+	recipientsValue := previousOutputsValue - fee - config.changeValue
+	if recipientsValue < 0 {
+		return nil, fmt.Errorf(
+			"previous outputs value [%d] does not cover fee [%d] and change [%d]",
+			previousOutputsValue,
+			fee,
+			config.changeValue,
+		)
+	}
+
+	perRecipientValue := recipientsValue / int64(len(recipientAddresses))
+	for _, txOut := range tx.TxOut[:len(recipientAddresses)] {
 		txOut.Value = perRecipientValue
 	}
 
-	if fee > previousOutputValue/20 {
+	if fee > previousOutputsValue/20 {
 		logger.Warnf(
 			"transaction fee [%d] is greater than 5%% of the UTXO value [%d]",
 			fee,
-			previousOutputValue,
+			previousOutputsValue,
 		)
 	}
 
 	return tx, nil
 }
 
+// replaceWithHigherFee produces an unsigned replacement of the given
+// replaceable transaction spending the same inputs with a fee computed from
+// the given fee per vbyte. The first recipientsCount outputs of the
+// transaction are recipient outputs; the remaining ones, like the change
+// output, are kept intact. The fee increase is covered by reducing every
+// recipient output proportionally to its value. Truncation of the reduced
+// values could result in a fractionally high fee.
+//
+// According to [BIP125] rule 4, the replacement must pay for its own
+// bandwidth on top of the fee of the replaced transaction, so the fee must
+// increase by at least the minimum relay fee for the transaction vsize.
+//
+// [BIP125]: https://github.com/bitcoin/bips/blob/master/bip-0125.mediawiki
+func replaceWithHigherFee(
+	unsignedTransaction *wire.MsgTx,
+	previousOutputsValue int64,
+	feePerVbyte int64,
+	recipientsCount int,
+) (*wire.MsgTx, error) {
+	for i, txIn := range unsignedTransaction.TxIn {
+		if txIn.Sequence != replaceByFeeSequence {
+			return nil, fmt.Errorf(
+				"input [%d] of the transaction does not signal replace-by-fee",
+				i,
+			)
+		}
+	}
+
+	if recipientsCount <= 0 || recipientsCount > len(unsignedTransaction.TxOut) {
+		return nil, fmt.Errorf(
+			"recipients count [%d] does not fit transaction with [%d] outputs",
+			recipientsCount,
+			len(unsignedTransaction.TxOut),
+		)
+	}
+
+	// For safety's sake, work on a deep copy, as mutations follow.
+	replacementTransaction := unsignedTransaction.Copy()
+	recipientOutputs := replacementTransaction.TxOut[:recipientsCount]
+
+	outputsValue := int64(0)
+	for _, txOut := range replacementTransaction.TxOut {
+		outputsValue += txOut.Value
+	}
+
+	recipientsValue := int64(0)
+	for _, txOut := range recipientOutputs {
+		recipientsValue += txOut.Value
+	}
+
+	currentFee := previousOutputsValue - outputsValue
+
+	// Replacement has the same inputs and outputs so its vsize does not
+	// change.
+	vsize := int64(
+		mempool.GetTxVirtualSize(btcutil.NewTx(replacementTransaction)),
+	)
+	fee := feePerVbyte * vsize
+	minFee := currentFee + minRelayFeePerVbyte*vsize
+	if fee < minFee {
+		return nil, fmt.Errorf(
+			"new fee [%d] is below [%d] required to replace the "+
+				"transaction paying fee [%d]",
+			fee,
+			minFee,
+			currentFee,
+		)
+	}
+
+	if err := validateRelayFee(fee, vsize); err != nil {
+		return nil, err
+	}
+
+	newRecipientsValue := recipientsValue - (fee - currentFee)
+	if newRecipientsValue <= 0 {
+		return nil, fmt.Errorf(
+			"recipient outputs value [%d] does not cover fee increase [%d]",
+			recipientsValue,
+			fee-currentFee,
+		)
+	}
+
+	for i, txOut := range recipientOutputs {
+		// value * newRecipientsValue / recipientsValue could overflow int64
+		reducedValue := new(big.Int).Div(
+			new(big.Int).Mul(
+				big.NewInt(txOut.Value),
+				big.NewInt(newRecipientsValue),
+			),
+			big.NewInt(recipientsValue),
+		).Int64()
+
+		if reducedValue <= 0 {
+			return nil, fmt.Errorf(
+				"output [%d] does not cover its share of the fee",
+				i,
+			)
+		}
+
+		txOut.Value = reducedValue
+	}
+
+	return replacementTransaction, nil
+}
+
+// validateRelayFee checks if the given fee of a transaction with the given
+// vsize meets the minimum relay fee rate, so the transaction is not rejected
+// when broadcast.
+func validateRelayFee(fee int64, vsize int64) error {
+	if fee < minRelayFeePerVbyte*vsize {
+		return fmt.Errorf(
+			"transaction fee [%d] implies a fee rate of [%.2f] sat/vbyte "+
+				"which is below the minimum relay fee rate of [%d] sat/vbyte",
+			fee,
+			float64(fee)/float64(vsize),
+			minRelayFeePerVbyte,
+		)
+	}
+
+	return nil
+}
+
+// addressToOutputScript decodes the given address and builds the output
+// script paying to it. Supported are P2PKH, P2SH, P2WPKH, and P2WSH
+// addresses.
+func addressToOutputScript(
+	encodedAddress string,
+	chainParams *chaincfg.Params,
+) ([]byte, error) {
+	address, err := btcutil.DecodeAddress(encodedAddress, chainParams)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding address: [%s]", err)
+	}
+
+	switch address.(type) {
+	case *btcutil.AddressPubKeyHash,
+		*btcutil.AddressScriptHash,
+		*btcutil.AddressWitnessPubKeyHash,
+		*btcutil.AddressWitnessScriptHash:
+		return txscript.PayToAddrScript(address)
+	default:
+		return nil, fmt.Errorf("unsupported address type: [%T]", address)
+	}
+}
+
+// NormalizeSignature validates that R and S of the signature are in the
+// [1, N-1] range, where N is the secp256k1 curve order, and returns the
+// signature in the low-S form required by [BIP62]. If S is above the half of
+// the curve order, it is replaced with N - S and the recovery ID parity is
+// flipped, so the signature still recovers to the same public key.
+//
+// [BIP62]: https://github.com/bitcoin/bips/blob/master/bip-0062.mediawiki
+func NormalizeSignature(signature *ecdsa.Signature) (*ecdsa.Signature, error) {
+	curveOrder := btcec.S256().N
+
+	if signature.R.Sign() <= 0 || signature.R.Cmp(curveOrder) >= 0 {
+		return nil, fmt.Errorf("signature R [%v] is out of range", signature.R)
+	}
+
+	if signature.S.Sign() <= 0 || signature.S.Cmp(curveOrder) >= 0 {
+		return nil, fmt.Errorf("signature S [%v] is out of range", signature.S)
+	}
+
+	if signature.IsCanonical() {
+		return signature, nil
+	}
+
+	normalizedSignature := &ecdsa.Signature{
+		R:          signature.R,
+		S:          signature.S,
+		RecoveryID: signature.RecoveryID,
+	}
+	normalizedSignature.Canonicalize()
+
+	return normalizedSignature, nil
+}
+
+// buildSignedTransaction attaches the witness built from the signature and
+// the public key at the given position to every input of the transaction.
+// There must be exactly one signature and one public key per input.
+func buildSignedTransaction(
+	unsignedTransaction *wire.MsgTx,
+	signatures []*ecdsa.Signature,
+	publicKeys []*cecdsa.PublicKey,
+) (*wire.MsgTx, error) {
+	if len(signatures) != len(unsignedTransaction.TxIn) {
+		return nil, fmt.Errorf(
+			"number of signatures [%d] does not match number of inputs [%d]",
+			len(signatures),
+			len(unsignedTransaction.TxIn),
+		)
+	}
+
+	if len(publicKeys) != len(unsignedTransaction.TxIn) {
+		return nil, fmt.Errorf(
+			"number of public keys [%d] does not match number of inputs [%d]",
+			len(publicKeys),
+			len(unsignedTransaction.TxIn),
+		)
+	}
+
+	// For safety's sake, work on a deep copy, as mutations follow.
+	signedTransaction := unsignedTransaction.Copy()
+
+	for i, txIn := range signedTransaction.TxIn {
+		// The witness signature field is the DER signature followed by
+		// the hash type.
+		witnessSignature, err := signatures[i].DER(byte(txscript.SigHashAll))
+		if err != nil {
+			return nil, fmt.Errorf(
+				"invalid signature for input [%d]: [%v]",
+				i,
+				err,
+			)
+		}
+
+		txIn.Witness = wire.TxWitness{
+			witnessSignature,
+			// The second part of the witness is the compressed public key.
+			(*btcec.PublicKey)(publicKeys[i]).SerializeCompressed(),
+		}
+	}
+
+	return signedTransaction, nil
+}
+
 // buildSignedTransactionHexString generates the final transaction hex string
 // that can then be submitted to the chain
 func buildSignedTransactionHexString(
@@ -159,20 +516,22 @@ func buildSignedTransactionHexString(
 	signature *ecdsa.Signature,
 	publicKey *cecdsa.PublicKey,
 ) (string, error) {
-	// For safety's sake, work on a deep copy, as mutations follow.
-	signedTransaction := unsignedTransaction.Copy()
-
-	btcSignature := &btcec.Signature{R: signature.R, S: signature.S}
-
-	// The witness is for the first input, since this is known to be a
-	// single-input transaction.
-	signedTransaction.TxIn[0].Witness = wire.TxWitness{
-		// The witness signature field is the DER signature followed by the hash type.
-		append(btcSignature.Serialize(), byte(txscript.SigHashAll)),
-		// The second part of the witness is the compressed public key.
-		(*btcec.PublicKey)(publicKey).SerializeCompressed(),
+	// The transaction is known to be a single-input transaction.
+	signedTransaction, err := buildSignedTransaction(
+		unsignedTransaction,
+		[]*ecdsa.Signature{signature},
+		[]*cecdsa.PublicKey{publicKey},
+	)
+	if err != nil {
+		return "", err
 	}
 
+	return encodeTransactionHexString(signedTransaction)
+}
+
+// encodeTransactionHexString encodes the signed transaction to the hex string
+// that can be broadcast.
+func encodeTransactionHexString(signedTransaction *wire.MsgTx) (string, error) {
 	// BtcEncode writes bytes, we wrap it in an hex encoder wrapped
 	// around a strings. Builder to get a hex string.
 	transactionHexBuilder := &strings.Builder{}
@@ -193,32 +552,33 @@ func buildSignedTransactionHexString(
 	return transactionHexBuilder.String(), nil
 }
 
-// BuildBitcoinTransaction generates a signed transaction hex string that can
-// recover an underlying bitcoin deposit that has been liquidated.
-func BuildBitcoinTransaction(
-	ctx context.Context,
-	networkProvider net.Provider,
-	hostChain chain.Handle,
-	fundingInfo *chain.FundingInfo,
-	signer *tss.ThresholdSigner,
+// signFunc calculates a signature over the given sighash.
+type signFunc func(sighash []byte) (*ecdsa.Signature, error)
+
+// assembleRecoveryTransaction constructs the transaction spending the given
+// previous outputs, all locked to the p2wpkh address of the given public key,
+// signs every input with the given sign function, and returns the hex string
+// of the signed transaction.
+func assembleRecoveryTransaction(
+	previousOutputs []*previousOutput,
+	feePerVbyte int64,
+	recipientAddresses []string,
+	publicKey *cecdsa.PublicKey,
+	sign signFunc,
 	chainParams *chaincfg.Params,
-	retrievalAddresses []string,
-	maxFeePerVByte int32,
+	options ...transactionOption,
 ) (string, error) {
-	scriptCodeBytes, err := publicKeyToP2WPKHScriptCode(signer.PublicKey(), chainParams)
+	scriptCodeBytes, err := publicKeyToP2WPKHScriptCode(publicKey, chainParams)
 	if err != nil {
 		return "", fmt.Errorf("failed to retrieve the script code: [%v]", err)
 	}
 
-	previousOutputValue := int64(chain.UtxoValueBytesToUint32(fundingInfo.UtxoValueBytes))
-
 	unsignedTransaction, err := constructUnsignedTransaction(
-		fundingInfo.TransactionHash,
-		fundingInfo.OutputIndex,
-		previousOutputValue,
-		int64(maxFeePerVByte),
-		retrievalAddresses,
+		previousOutputs,
+		feePerVbyte,
+		recipientAddresses,
 		chainParams,
+		options...,
 	)
 	if err != nil {
 		return "", fmt.Errorf("failed to construct the unsigned transaction: [%w]", err)
@@ -229,42 +589,85 @@ func BuildBitcoinTransaction(
 		unsignedTransaction,
 	)
 
-	sighashBytes, err := txscript.CalcWitnessSigHash(
-		scriptCodeBytes,
-		txscript.NewTxSigHashes(unsignedTransaction),
-		txscript.SigHashAll,
-		unsignedTransaction,
-		0,
-		previousOutputValue,
-	)
-	if err != nil {
-		return "", fmt.Errorf("failed to calculate the sighash bytes: [%w]", err)
-	}
+	signatures := make([]*ecdsa.Signature, len(previousOutputs))
+	publicKeys := make([]*cecdsa.PublicKey, len(previousOutputs))
+	for i, previousOutput := range previousOutputs {
+		sighashBytes, err := ComputeP2WPKHSigHash(
+			unsignedTransaction,
+			i,
+			scriptCodeBytes,
+			previousOutput.value,
+		)
+		if err != nil {
+			return "", fmt.Errorf("failed to calculate the sighash bytes: [%w]", err)
+		}
 
-	logger.Debugf(
-		"calculated liquidation recovery transcation sighash: [%x]",
-		sighashBytes,
-	)
+		logger.Debugf(
+			"calculated liquidation recovery transcation sighash for input [%d]: [%x]",
+			i,
+			sighashBytes,
+		)
+
+		signature, err := sign(sighashBytes)
+		if err != nil {
+			return "", fmt.Errorf("failed to calculate signature: [%w]", err)
+		}
+
+		logger.Debugf(
+			"calculated liquidation recovery transcation signature for sighash [%x]: [%v]",
+			sighashBytes,
+			signature,
+		)
 
-	signature, err := signer.CalculateSignature(
-		ctx,
-		sighashBytes,
-		networkProvider,
-		hostChain.Signing().PublicKeyToAddress,
+		signatures[i] = signature
+		publicKeys[i] = publicKey
+	}
+
+	signedTransaction, err := buildSignedTransaction(
+		unsignedTransaction,
+		signatures,
+		publicKeys,
 	)
 	if err != nil {
-		return "", fmt.Errorf("failed to calculate signature: [%w]", err)
+		return "", err
 	}
 
-	logger.Debugf(
-		"calculated liquidation recovery transcation signature for sighash [%x]: [%v]",
-		sighashBytes,
-		signature,
-	)
+	return encodeTransactionHexString(signedTransaction)
+}
 
-	return buildSignedTransactionHexString(
-		unsignedTransaction,
-		signature,
+// BuildBitcoinTransaction generates a signed transaction hex string that can
+// recover an underlying bitcoin deposit that has been liquidated.
+func BuildBitcoinTransaction(
+	ctx context.Context,
+	networkProvider net.Provider,
+	hostChain chain.Handle,
+	fundingInfo *chain.FundingInfo,
+	signer *tss.ThresholdSigner,
+	chainParams *chaincfg.Params,
+	retrievalAddresses []string,
+	maxFeePerVByte int32,
+) (string, error) {
+	previousOutputValue := int64(chain.UtxoValueBytesToUint32(fundingInfo.UtxoValueBytes))
+
+	return assembleRecoveryTransaction(
+		[]*previousOutput{
+			{
+				transactionHashHex: fundingInfo.TransactionHash,
+				outputIndex:        fundingInfo.OutputIndex,
+				value:              previousOutputValue,
+			},
+		},
+		int64(maxFeePerVByte),
+		retrievalAddresses,
 		signer.PublicKey(),
+		func(sighash []byte) (*ecdsa.Signature, error) {
+			return signer.CalculateSignature(
+				ctx,
+				sighash,
+				networkProvider,
+				hostChain.Signing().PublicKeyToAddress,
+			)
+		},
+		chainParams,
 	)
 }
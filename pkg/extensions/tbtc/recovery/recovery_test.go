@@ -6,15 +6,19 @@ import (
 	cecdsa "crypto/ecdsa"
 	"crypto/elliptic"
 	"encoding/hex"
+	"fmt"
 	"math/big"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/btcsuite/btcd/btcec"
 	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/mempool"
 	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/google/go-cmp/cmp"
 	"github.com/ipfs/go-log"
@@ -50,6 +54,64 @@ func TestPublicKeyToP2WPKHScriptCode(t *testing.T) {
 	}
 }
 
+func TestComputeP2WPKHSigHash(t *testing.T) {
+	unsignedTx, err := constructUnsignedTransaction(
+		[]*previousOutput{
+			{
+				transactionHashHex: "0b99dea9655f219991001e9296cfe2103dd918a21ef477a14121d1a0ba9491f1",
+				outputIndex:        uint32(0),
+				value:              int64(100000000),
+			},
+			{
+				transactionHashHex: "c27c3bfa8293ac6b303b9f7455ae23b7c24b8814915a6511976027064efc4d51",
+				outputIndex:        uint32(1),
+				value:              int64(50000000),
+			},
+		},
+		int64(700),
+		[]string{"bcrt1q5sz7jly79m76a5e8py6kv402q07p725vm4s0zl"},
+		&chaincfg.TestNet3Params,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// scriptCode from the BIP143 native p2wpkh example
+	scriptCode, _ := hex.DecodeString("76a9141d0f172a0ecb48aee1be1f2687d2963ae33f71a188ac")
+	amount := int64(50000000)
+	inputIndex := 1
+
+	expectedSigHash, err := txscript.CalcWitnessSigHash(
+		scriptCode,
+		txscript.NewTxSigHashes(unsignedTx),
+		txscript.SigHashAll,
+		unsignedTx,
+		inputIndex,
+		amount,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sigHash, err := ComputeP2WPKHSigHash(unsignedTx, inputIndex, scriptCode, amount)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(expectedSigHash, sigHash) {
+		t.Errorf(
+			"unexpected sighash\nexpected: %x\nactual:   %x",
+			expectedSigHash,
+			sigHash,
+		)
+	}
+
+	_, err = ComputeP2WPKHSigHash(unsignedTx, 2, scriptCode, amount)
+	if err == nil {
+		t.Error("expected error for input index out of range")
+	}
+}
+
 func TestConstructUnsignedTransaction(t *testing.T) {
 	recipientAddresses := []string{
 		"bcrt1q5sz7jly79m76a5e8py6kv402q07p725vm4s0zl",
@@ -68,9 +130,13 @@ func TestConstructUnsignedTransaction(t *testing.T) {
 	expectedTx.Deserialize(bytes.NewReader(expectedTxBytes))
 
 	actualTx, err := constructUnsignedTransaction(
-		"0b99dea9655f219991001e9296cfe2103dd918a21ef477a14121d1a0ba9491f1",
-		uint32(0),
-		previousOutputValue,
+		[]*previousOutput{
+			{
+				transactionHashHex: "0b99dea9655f219991001e9296cfe2103dd918a21ef477a14121d1a0ba9491f1",
+				outputIndex:        uint32(0),
+				value:              previousOutputValue,
+			},
+		},
 		int64(700),
 		recipientAddresses,
 		&chaincfg.TestNet3Params,
@@ -82,6 +148,510 @@ func TestConstructUnsignedTransaction(t *testing.T) {
 	assert.DeepEqual(t, actualTx, expectedTx)
 }
 
+func TestConstructUnsignedTransaction_MultipleInputs(t *testing.T) {
+	recipientAddresses := []string{
+		"bcrt1q5sz7jly79m76a5e8py6kv402q07p725vm4s0zl",
+		"bcrt1qlxt5a04pefwkl90mna2sn79nu7asq3excx60h0",
+		"bcrt1qjhpgmmhaxfwj6t7zf3dvs2fhdhx02g8qn3xwsf",
+	}
+
+	previousOutputs := []*previousOutput{
+		{
+			transactionHashHex: "0b99dea9655f219991001e9296cfe2103dd918a21ef477a14121d1a0ba9491f1",
+			outputIndex:        uint32(0),
+			value:              int64(100000000),
+		},
+		{
+			transactionHashHex: "c27c3bfa8293ac6b303b9f7455ae23b7c24b8814915a6511976027064efc4d51",
+			outputIndex:        uint32(1),
+			value:              int64(50000000),
+		},
+	}
+
+	feePerVbyte := int64(700)
+
+	actualTx, err := constructUnsignedTransaction(
+		previousOutputs,
+		feePerVbyte,
+		recipientAddresses,
+		&chaincfg.TestNet3Params,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var serializedTx bytes.Buffer
+	if err := actualTx.Serialize(&serializedTx); err != nil {
+		t.Fatal(err)
+	}
+	decodedTx := wire.NewMsgTx(0)
+	if err := decodedTx.Deserialize(&serializedTx); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(decodedTx.TxIn) != len(previousOutputs) {
+		t.Fatalf(
+			"wrong number of inputs\nexpected: %d\nactual:   %d",
+			len(previousOutputs),
+			len(decodedTx.TxIn),
+		)
+	}
+
+	for i, txIn := range decodedTx.TxIn {
+		expectedOutpoint := previousOutputs[i].transactionHashHex
+		if txIn.PreviousOutPoint.Hash.String() != expectedOutpoint {
+			t.Errorf(
+				"unexpected outpoint hash of input [%d]\nexpected: %s\nactual:   %s",
+				i,
+				expectedOutpoint,
+				txIn.PreviousOutPoint.Hash.String(),
+			)
+		}
+		if txIn.PreviousOutPoint.Index != previousOutputs[i].outputIndex {
+			t.Errorf(
+				"unexpected outpoint index of input [%d]\nexpected: %d\nactual:   %d",
+				i,
+				previousOutputs[i].outputIndex,
+				txIn.PreviousOutPoint.Index,
+			)
+		}
+		if len(txIn.Witness) != 2 {
+			t.Errorf(
+				"unexpected witness placeholder of input [%d]\nexpected: %d items\nactual:   %d items",
+				i,
+				2,
+				len(txIn.Witness),
+			)
+		}
+	}
+
+	fee := feePerVbyte * int64(mempool.GetTxVirtualSize(btcutil.NewTx(actualTx)))
+	expectedOutputValue := (int64(150000000) - fee) / int64(len(recipientAddresses))
+	for _, txOut := range decodedTx.TxOut {
+		if txOut.Value != expectedOutputValue {
+			t.Errorf(
+				"incorrect output value\nexpected: %d\nactual:   %d",
+				expectedOutputValue,
+				txOut.Value,
+			)
+		}
+	}
+}
+
+func TestConstructUnsignedTransaction_WithChange(t *testing.T) {
+	recipientAddresses := []string{
+		"bcrt1q5sz7jly79m76a5e8py6kv402q07p725vm4s0zl",
+		"bcrt1qlxt5a04pefwkl90mna2sn79nu7asq3excx60h0",
+	}
+	changeAddress := "bcrt1qjhpgmmhaxfwj6t7zf3dvs2fhdhx02g8qn3xwsf"
+	changeValue := int64(20000)
+
+	expectedChangeScript, _ := hex.DecodeString(
+		"001495c28deefd325d2d2fc24c5ac829376dccf520e0",
+	)
+
+	previousOutputValue := int64(100000000)
+	feePerVbyte := int64(700)
+
+	actualTx, err := constructUnsignedTransaction(
+		[]*previousOutput{
+			{
+				transactionHashHex: "0b99dea9655f219991001e9296cfe2103dd918a21ef477a14121d1a0ba9491f1",
+				outputIndex:        uint32(0),
+				value:              previousOutputValue,
+			},
+		},
+		feePerVbyte,
+		recipientAddresses,
+		&chaincfg.TestNet3Params,
+		withChange(changeAddress, changeValue),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(actualTx.TxOut) != len(recipientAddresses)+1 {
+		t.Fatalf(
+			"wrong number of outputs\nexpected: %d\nactual:   %d",
+			len(recipientAddresses)+1,
+			len(actualTx.TxOut),
+		)
+	}
+
+	changeOutput := actualTx.TxOut[len(actualTx.TxOut)-1]
+	if !bytes.Equal(expectedChangeScript, changeOutput.PkScript) {
+		t.Errorf(
+			"unexpected change output script\nexpected: %x\nactual:   %x",
+			expectedChangeScript,
+			changeOutput.PkScript,
+		)
+	}
+	if changeOutput.Value != changeValue {
+		t.Errorf(
+			"unexpected change output value\nexpected: %d\nactual:   %d",
+			changeValue,
+			changeOutput.Value,
+		)
+	}
+
+	fee := feePerVbyte * int64(mempool.GetTxVirtualSize(btcutil.NewTx(actualTx)))
+	expectedOutputValue := (previousOutputValue - fee - changeValue) /
+		int64(len(recipientAddresses))
+	for _, txOut := range actualTx.TxOut[:len(recipientAddresses)] {
+		if txOut.Value != expectedOutputValue {
+			t.Errorf(
+				"incorrect output value\nexpected: %d\nactual:   %d",
+				expectedOutputValue,
+				txOut.Value,
+			)
+		}
+	}
+}
+
+func TestConstructUnsignedTransaction_ChangeNotCovered(t *testing.T) {
+	_, err := constructUnsignedTransaction(
+		[]*previousOutput{
+			{
+				transactionHashHex: "0b99dea9655f219991001e9296cfe2103dd918a21ef477a14121d1a0ba9491f1",
+				outputIndex:        uint32(0),
+				value:              int64(100000),
+			},
+		},
+		int64(700),
+		[]string{"bcrt1q5sz7jly79m76a5e8py6kv402q07p725vm4s0zl"},
+		&chaincfg.TestNet3Params,
+		withChange("bcrt1qjhpgmmhaxfwj6t7zf3dvs2fhdhx02g8qn3xwsf", int64(90000)),
+	)
+	if err == nil {
+		t.Fatal("expected error for previous outputs not covering change and fee")
+	}
+}
+
+func TestConstructUnsignedTransaction_FeeBelowMinimumRelayFee(t *testing.T) {
+	_, err := constructUnsignedTransaction(
+		[]*previousOutput{
+			{
+				transactionHashHex: "0b99dea9655f219991001e9296cfe2103dd918a21ef477a14121d1a0ba9491f1",
+				outputIndex:        uint32(0),
+				value:              int64(100000000),
+			},
+		},
+		int64(0),
+		[]string{"bcrt1q5sz7jly79m76a5e8py6kv402q07p725vm4s0zl"},
+		&chaincfg.TestNet3Params,
+	)
+	if err == nil {
+		t.Fatal("expected error for fee below the minimum relay fee")
+	}
+
+	expectedError := "implies a fee rate of [0.00] sat/vbyte which is below " +
+		"the minimum relay fee rate of [1] sat/vbyte"
+	if !strings.Contains(err.Error(), expectedError) {
+		t.Errorf(
+			"unexpected error\nexpected to contain: %v\nactual:              %v",
+			expectedError,
+			err,
+		)
+	}
+}
+
+func TestConstructUnsignedTransaction_ReplaceByFee(t *testing.T) {
+	actualTx, err := constructUnsignedTransaction(
+		[]*previousOutput{
+			{
+				transactionHashHex: "0b99dea9655f219991001e9296cfe2103dd918a21ef477a14121d1a0ba9491f1",
+				outputIndex:        uint32(0),
+				value:              int64(100000000),
+			},
+			{
+				transactionHashHex: "c27c3bfa8293ac6b303b9f7455ae23b7c24b8814915a6511976027064efc4d51",
+				outputIndex:        uint32(1),
+				value:              int64(50000000),
+			},
+		},
+		int64(700),
+		[]string{"bcrt1q5sz7jly79m76a5e8py6kv402q07p725vm4s0zl"},
+		&chaincfg.TestNet3Params,
+		withReplaceByFee(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, txIn := range actualTx.TxIn {
+		if txIn.Sequence != 0xfffffffd {
+			t.Errorf(
+				"unexpected sequence of input [%d]\nexpected: %x\nactual:   %x",
+				i,
+				0xfffffffd,
+				txIn.Sequence,
+			)
+		}
+	}
+}
+
+func TestReplaceWithHigherFee(t *testing.T) {
+	previousOutputValue := int64(100000000)
+	changeValue := int64(10000000)
+
+	unsignedTx, err := constructReplaceableTransaction(
+		previousOutputValue,
+		changeValue,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replacementTx, err := replaceWithHigherFee(
+		unsignedTx,
+		previousOutputValue,
+		int64(1400),
+		2,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if replacementTx.TxIn[0].PreviousOutPoint != unsignedTx.TxIn[0].PreviousOutPoint {
+		t.Errorf("replacement transaction must spend the same inputs")
+	}
+
+	if replacementTx.TxOut[2].Value != changeValue {
+		t.Errorf(
+			"unexpected change output value\nexpected: %d\nactual:   %d",
+			changeValue,
+			replacementTx.TxOut[2].Value,
+		)
+	}
+
+	vsize := int64(mempool.GetTxVirtualSize(btcutil.NewTx(replacementTx)))
+	expectedRecipientsValue := previousOutputValue - changeValue - 1400*vsize
+
+	actualRecipientsValue := int64(0)
+	for i, txOut := range replacementTx.TxOut[:2] {
+		if txOut.Value >= unsignedTx.TxOut[i].Value {
+			t.Errorf("output [%d] value should be reduced", i)
+		}
+		actualRecipientsValue += txOut.Value
+	}
+
+	// truncation of each recipient output value can lower the total by at
+	// most one satoshi per output
+	if actualRecipientsValue > expectedRecipientsValue ||
+		actualRecipientsValue < expectedRecipientsValue-2 {
+		t.Errorf(
+			"unexpected recipient outputs value\nexpected: %d\nactual:   %d",
+			expectedRecipientsValue,
+			actualRecipientsValue,
+		)
+	}
+}
+
+func TestReplaceWithHigherFee_InsufficientFeeIncrease(t *testing.T) {
+	previousOutputValue := int64(100000000)
+
+	unsignedTx, err := constructReplaceableTransaction(
+		previousOutputValue,
+		int64(10000000),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Raise the current fee by one satoshi, so that one more sat/vbyte
+	// increases the fee by less than the minimum relay fee for the
+	// transaction vsize.
+	unsignedTx.TxOut[0].Value--
+
+	_, err = replaceWithHigherFee(
+		unsignedTx,
+		previousOutputValue,
+		int64(701),
+		2,
+	)
+	if err == nil {
+		t.Fatal("expected error for fee increase below the minimum relay fee")
+	}
+}
+
+func TestReplaceWithHigherFee_InvalidRecipientsCount(t *testing.T) {
+	previousOutputValue := int64(100000000)
+
+	unsignedTx, err := constructReplaceableTransaction(
+		previousOutputValue,
+		int64(10000000),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = replaceWithHigherFee(
+		unsignedTx,
+		previousOutputValue,
+		int64(1400),
+		4,
+	)
+	if err == nil {
+		t.Fatal("expected error for recipients count above outputs count")
+	}
+}
+
+func TestReplaceWithHigherFee_NotReplaceable(t *testing.T) {
+	previousOutputValue := int64(100000000)
+
+	unsignedTx, err := constructUnsignedTransaction(
+		[]*previousOutput{
+			{
+				transactionHashHex: "0b99dea9655f219991001e9296cfe2103dd918a21ef477a14121d1a0ba9491f1",
+				outputIndex:        uint32(0),
+				value:              previousOutputValue,
+			},
+		},
+		int64(700),
+		[]string{"bcrt1q5sz7jly79m76a5e8py6kv402q07p725vm4s0zl"},
+		&chaincfg.TestNet3Params,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = replaceWithHigherFee(
+		unsignedTx,
+		previousOutputValue,
+		int64(1400),
+		1,
+	)
+	if err == nil {
+		t.Fatal("expected error for transaction not signaling replace-by-fee")
+	}
+}
+
+// constructReplaceableTransaction constructs a replaceable transaction paying
+// 700 sat/vbyte with two recipient outputs and a change output of the given
+// value.
+func constructReplaceableTransaction(
+	previousOutputValue int64,
+	changeValue int64,
+) (*wire.MsgTx, error) {
+	return constructUnsignedTransaction(
+		[]*previousOutput{
+			{
+				transactionHashHex: "0b99dea9655f219991001e9296cfe2103dd918a21ef477a14121d1a0ba9491f1",
+				outputIndex:        uint32(0),
+				value:              previousOutputValue,
+			},
+		},
+		int64(700),
+		[]string{
+			"bcrt1q5sz7jly79m76a5e8py6kv402q07p725vm4s0zl",
+			"bcrt1qlxt5a04pefwkl90mna2sn79nu7asq3excx60h0",
+		},
+		&chaincfg.TestNet3Params,
+		withReplaceByFee(),
+		withChange("bcrt1qjhpgmmhaxfwj6t7zf3dvs2fhdhx02g8qn3xwsf", changeValue),
+	)
+}
+
+func TestAddressToOutputScript(t *testing.T) {
+	var tests = map[string]struct {
+		address              string
+		expectedOutputScript string
+		expectedError        bool
+	}{
+		"p2pkh": {
+			address:              "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2",
+			expectedOutputScript: "76a91477bff20c60e522dfaa3350c39b030a5d004e839a88ac",
+		},
+		"p2sh": {
+			address:              "3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy",
+			expectedOutputScript: "a914b472a266d0bd89c13706a4132ccfb16f7c3b9fcb87",
+		},
+		// BIP173 test vectors
+		"p2wpkh": {
+			address:              "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4",
+			expectedOutputScript: "0014751e76e8199196d454941c45d1b3a323f1433bd6",
+		},
+		"p2wsh": {
+			address:              "bc1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3qccfmv3",
+			expectedOutputScript: "00201863143c14c5166804bd19203356da136c985678cd4d27a1b8c6329604903262",
+		},
+		"public key": {
+			address:       "0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798",
+			expectedError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			outputScript, err := addressToOutputScript(
+				test.address,
+				&chaincfg.MainNetParams,
+			)
+			if test.expectedError {
+				if err == nil {
+					t.Fatal("expected error for unsupported address type")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if hex.EncodeToString(outputScript) != test.expectedOutputScript {
+				t.Errorf(
+					"unexpected output script\nexpected: %s\nactual:   %x",
+					test.expectedOutputScript,
+					outputScript,
+				)
+			}
+		})
+	}
+}
+
+func TestConstructUnsignedTransaction_MixedRecipients(t *testing.T) {
+	recipientAddresses := []string{
+		"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2",
+		"3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy",
+		"bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4",
+		"bc1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3qccfmv3",
+	}
+
+	expectedScriptClasses := []txscript.ScriptClass{
+		txscript.PubKeyHashTy,
+		txscript.ScriptHashTy,
+		txscript.WitnessV0PubKeyHashTy,
+		txscript.WitnessV0ScriptHashTy,
+	}
+
+	actualTx, err := constructUnsignedTransaction(
+		[]*previousOutput{
+			{
+				transactionHashHex: "0b99dea9655f219991001e9296cfe2103dd918a21ef477a14121d1a0ba9491f1",
+				outputIndex:        uint32(0),
+				value:              int64(100000000),
+			},
+		},
+		int64(700),
+		recipientAddresses,
+		&chaincfg.MainNetParams,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, txOut := range actualTx.TxOut {
+		scriptClass := txscript.GetScriptClass(txOut.PkScript)
+		if scriptClass != expectedScriptClasses[i] {
+			t.Errorf(
+				"unexpected script class of output [%d]\nexpected: %v\nactual:   %v",
+				i,
+				expectedScriptClasses[i],
+				scriptClass,
+			)
+		}
+	}
+}
+
 func TestBuildSignedTransactionHexString(t *testing.T) {
 	unsignedTxHex := "01000000000101f19194baa0d12141a177f41ea218d93d10e2cf96921e009199215f65a9de990b000000000000000000039003fc0100000000160014a405e97c9e2efdaed32709356655ea03fc1f2a8c9003fc0100000000160014f9974ebea1ca5d6f95fb9f5509f8b3e7bb0047269003fc010000000016001495c28deefd325d2d2fc24c5ac829376dccf520e0024a00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000002100000000000000000000000000000000000000000000000000000000000000000000000000"
 	expectedSignedTx := "01000000000101f19194baa0d12141a177f41ea218d93d10e2cf96921e009199215f65a9de990b000000000000000000039003fc0100000000160014a405e97c9e2efdaed32709356655ea03fc1f2a8c9003fc0100000000160014f9974ebea1ca5d6f95fb9f5509f8b3e7bb0047269003fc010000000016001495c28deefd325d2d2fc24c5ac829376dccf520e0020930060201030201070121020000000007de3ebb640d2b021590c09d5e739597d02d939224d227a17403607500000000"
@@ -114,6 +684,231 @@ func TestBuildSignedTransactionHexString(t *testing.T) {
 	}
 }
 
+func TestBuildSignedTransaction_MultipleInputs(t *testing.T) {
+	unsignedTx, err := constructUnsignedTransaction(
+		[]*previousOutput{
+			{
+				transactionHashHex: "0b99dea9655f219991001e9296cfe2103dd918a21ef477a14121d1a0ba9491f1",
+				outputIndex:        uint32(0),
+				value:              int64(100000000),
+			},
+			{
+				transactionHashHex: "c27c3bfa8293ac6b303b9f7455ae23b7c24b8814915a6511976027064efc4d51",
+				outputIndex:        uint32(1),
+				value:              int64(50000000),
+			},
+		},
+		int64(700),
+		[]string{"bcrt1q5sz7jly79m76a5e8py6kv402q07p725vm4s0zl"},
+		&chaincfg.TestNet3Params,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signatures := []*ecdsa.Signature{
+		{R: big.NewInt(3), S: big.NewInt(7), RecoveryID: 1},
+		{R: big.NewInt(11), S: big.NewInt(13), RecoveryID: 0},
+	}
+
+	publicKeys := make([]*cecdsa.PublicKey, len(signatures))
+	for i := range publicKeys {
+		privateKey, err := btcec.NewPrivateKey(btcec.S256())
+		if err != nil {
+			t.Fatal(err)
+		}
+		publicKeys[i] = privateKey.PubKey().ToECDSA()
+	}
+
+	signedTx, err := buildSignedTransaction(unsignedTx, signatures, publicKeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, txIn := range signedTx.TxIn {
+		btcSignature := &btcec.Signature{R: signatures[i].R, S: signatures[i].S}
+		expectedWitness := wire.TxWitness{
+			append(btcSignature.Serialize(), byte(txscript.SigHashAll)),
+			(*btcec.PublicKey)(publicKeys[i]).SerializeCompressed(),
+		}
+
+		assert.DeepEqual(t, txIn.Witness, expectedWitness)
+	}
+
+	// the unsigned transaction must be left intact
+	for i, txIn := range unsignedTx.TxIn {
+		if !bytes.Equal(txIn.Witness[0], bytes.Repeat([]byte{0}, 74)) {
+			t.Errorf("witness of unsigned transaction input [%d] has been modified", i)
+		}
+	}
+}
+
+func TestBuildSignedTransaction_SignatureCountMismatch(t *testing.T) {
+	unsignedTxHex := "01000000000101f19194baa0d12141a177f41ea218d93d10e2cf96921e009199215f65a9de990b000000000000000000039003fc0100000000160014a405e97c9e2efdaed32709356655ea03fc1f2a8c9003fc0100000000160014f9974ebea1ca5d6f95fb9f5509f8b3e7bb0047269003fc010000000016001495c28deefd325d2d2fc24c5ac829376dccf520e0024a00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000002100000000000000000000000000000000000000000000000000000000000000000000000000"
+
+	privateKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKey := privateKey.PubKey().ToECDSA()
+
+	_, err = buildSignedTransaction(
+		decodeTransaction(t, unsignedTxHex),
+		[]*ecdsa.Signature{
+			{R: big.NewInt(3), S: big.NewInt(7), RecoveryID: 1},
+			{R: big.NewInt(11), S: big.NewInt(13), RecoveryID: 0},
+		},
+		[]*cecdsa.PublicKey{publicKey, publicKey},
+	)
+
+	expectedError := "number of signatures [2] does not match number of inputs [1]"
+	if err == nil || err.Error() != expectedError {
+		t.Errorf(
+			"unexpected error\nexpected: %v\nactual:   %v",
+			expectedError,
+			err,
+		)
+	}
+}
+
+func TestNormalizeSignature(t *testing.T) {
+	curveOrder := btcec.S256().N
+
+	var tests = map[string]struct {
+		signature         *ecdsa.Signature
+		expectedSignature *ecdsa.Signature
+		expectedError     string
+	}{
+		"low S": {
+			signature:         &ecdsa.Signature{R: big.NewInt(3), S: big.NewInt(7), RecoveryID: 1},
+			expectedSignature: &ecdsa.Signature{R: big.NewInt(3), S: big.NewInt(7), RecoveryID: 1},
+		},
+		"high S": {
+			signature: &ecdsa.Signature{
+				R:          big.NewInt(3),
+				S:          new(big.Int).Sub(curveOrder, big.NewInt(7)),
+				RecoveryID: 1,
+			},
+			expectedSignature: &ecdsa.Signature{R: big.NewInt(3), S: big.NewInt(7), RecoveryID: 0},
+		},
+		"zero R": {
+			signature:     &ecdsa.Signature{R: big.NewInt(0), S: big.NewInt(7), RecoveryID: 1},
+			expectedError: "signature R [0] is out of range",
+		},
+		"S equal to curve order": {
+			signature:     &ecdsa.Signature{R: big.NewInt(3), S: curveOrder, RecoveryID: 1},
+			expectedError: fmt.Sprintf("signature S [%v] is out of range", curveOrder),
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			signature, err := NormalizeSignature(test.signature)
+			if test.expectedError != "" {
+				if err == nil || err.Error() != test.expectedError {
+					t.Errorf(
+						"unexpected error\nexpected: %v\nactual:   %v",
+						test.expectedError,
+						err,
+					)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.DeepEqual(t, signature, test.expectedSignature, cmp.Comparer(
+				func(x, y *big.Int) bool { return x.Cmp(y) == 0 },
+			))
+		})
+	}
+}
+
+func TestBuildSignedTransactionHexString_HighS(t *testing.T) {
+	unsignedTxHex := "01000000000101f19194baa0d12141a177f41ea218d93d10e2cf96921e009199215f65a9de990b000000000000000000039003fc0100000000160014a405e97c9e2efdaed32709356655ea03fc1f2a8c9003fc0100000000160014f9974ebea1ca5d6f95fb9f5509f8b3e7bb0047269003fc010000000016001495c28deefd325d2d2fc24c5ac829376dccf520e0024a00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000002100000000000000000000000000000000000000000000000000000000000000000000000000"
+	// the same transaction as signed with the low-S signature in
+	// TestBuildSignedTransactionHexString
+	expectedSignedTx := "01000000000101f19194baa0d12141a177f41ea218d93d10e2cf96921e009199215f65a9de990b000000000000000000039003fc0100000000160014a405e97c9e2efdaed32709356655ea03fc1f2a8c9003fc0100000000160014f9974ebea1ca5d6f95fb9f5509f8b3e7bb0047269003fc010000000016001495c28deefd325d2d2fc24c5ac829376dccf520e0020930060201030201070121020000000007de3ebb640d2b021590c09d5e739597d02d939224d227a17403607500000000"
+
+	publicKey := &cecdsa.PublicKey{
+		Curve: elliptic.P224(),
+		X:     bigIntFromString(t, "828612351041249926199933036276541218289243364325366441967565889653"),
+		Y:     bigIntFromString(t, "985040320797760939221216987624001720525496952574017416820319442840"),
+	}
+
+	highSSignature := &ecdsa.Signature{
+		R:          big.NewInt(int64(3)),
+		S:          new(big.Int).Sub(btcec.S256().N, big.NewInt(7)),
+		RecoveryID: 1,
+	}
+
+	signedTxHex, err := buildSignedTransactionHexString(
+		decodeTransaction(t, unsignedTxHex),
+		highSSignature,
+		publicKey,
+	)
+	if err != nil {
+		t.Fatalf("failed to build signed transaction: %v", err)
+	}
+
+	if signedTxHex != expectedSignedTx {
+		t.Errorf(
+			"invalid signed transaction\n- actual\n+ expected\n%s",
+			cmp.Diff(decodeTransaction(t, signedTxHex), decodeTransaction(t, expectedSignedTx)))
+	}
+}
+
+func TestAssembleRecoveryTransaction(t *testing.T) {
+	privateKey, _ := btcec.PrivKeyFromBytes(
+		btcec.S256(),
+		bytes.Repeat([]byte{0x01}, 32),
+	)
+
+	sign := func(sighash []byte) (*ecdsa.Signature, error) {
+		signature, err := privateKey.Sign(sighash)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.Signature{R: signature.R, S: signature.S}, nil
+	}
+
+	previousOutputValue := int64(100000000)
+
+	signedTxHex, err := assembleRecoveryTransaction(
+		[]*previousOutput{
+			{
+				transactionHashHex: "0b99dea9655f219991001e9296cfe2103dd918a21ef477a14121d1a0ba9491f1",
+				outputIndex:        uint32(0),
+				value:              previousOutputValue,
+			},
+		},
+		int64(700),
+		[]string{
+			"bcrt1q5sz7jly79m76a5e8py6kv402q07p725vm4s0zl",
+			"bcrt1qlxt5a04pefwkl90mna2sn79nu7asq3excx60h0",
+		},
+		privateKey.PubKey().ToECDSA(),
+		sign,
+		&chaincfg.TestNet3Params,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signedTx := decodeTransaction(t, signedTxHex)
+
+	if len(signedTx.TxOut) != 2 {
+		t.Errorf(
+			"unexpected number of outputs\nexpected: %d\nactual:   %d",
+			2,
+			len(signedTx.TxOut),
+		)
+	}
+
+	validateTransaction(t, signedTx, previousOutputValue)
+}
+
 func TestBuildBitcoinTransaction(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 
@@ -129,7 +129,7 @@ func TestResolveAddress(t *testing.T) {
 				t.Fatal(err)
 			}
 			for _, usedIndex := range testData.usedIndexes {
-				dis.save(testData.beneficiaryAddress, usedIndex)
+				dis.save(testData.beneficiaryAddress, usedIndex, "")
 			}
 
 			handle := newMockBitcoinHandle()
@@ -181,12 +181,12 @@ func TestResolveBeneficiaryAddress_ExpectedFailure(t *testing.T) {
 		"empty string": {
 			"",
 			&chaincfg.MainNetParams,
-			"insufficient length for public key",
+			"invalid extended public key",
 		},
 		"BIP32 private key": {
 			"xprv9s21ZrQH143K24Mfq5zL5MhWK9hUhhGbd45hLXo2Pq2oqzMMo63oStZzF93Y5wvzdUayhgkkFoicQZcP3y52uPPxFnfoLZB21Teqt1VvEHx",
 			&chaincfg.MainNetParams,
-			"unusable seed",
+			"key is private",
 		},
 		"complete nonsense": {
 			"lorem ipsum dolor sit amet, consec",
@@ -4,11 +4,14 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil/hdkeychain"
 	"github.com/keep-network/keep-common/pkg/persistence"
 	"github.com/keep-network/keep-ecdsa/pkg/chain/bitcoin"
 )
@@ -16,6 +19,10 @@ import (
 const (
 	chainName     = "bitcoin"
 	directoryName = "derivation_indexes"
+
+	// tempFileSuffix marks index files which are being written and have not
+	// been committed yet.
+	tempFileSuffix = ".tmp"
 )
 
 // DerivationIndexStorage provides access to the derivation index persistence
@@ -52,23 +59,46 @@ func NewDerivationIndexStorage(path string) (*DerivationIndexStorage, error) {
 // ypub6Xxan668aiJqvh4SVfd7EzqjWvf36gWufTkhWHv3gaxnBh44HpkTi2TTkm1u136qjUxk7F3jGzoyfrGpHvALMgJgbF4WNXpoPu3QYrqogMK => ypub_QYrqogMK
 // zpub6rePDVHfRP14VpYiejwepBhzu45UbvqvzE3ZMdDnNykG47mZYyGTjsuq6uzQYRakSrHyix1YTXKohag4GDZLcHcLvhSAs2MQNF8VDaZuQT9 => zpub_VDaZuQT9
 // This both obfuscates the whole extended key and makes the folder easier to digest for human reading.
+// The key is normalized first so that different representations of the same
+// key share the path.
 // We algo return the directory and truncated public key separately as a
 // convenience for other methods like persistence.EnsureDirectoryExists.
 func (dis *DerivationIndexStorage) getStoragePath(extendedPublicKey string) (string, string, string, error) {
-	trimmedKey := strings.TrimSpace(extendedPublicKey)
-	if len(trimmedKey) < 12 {
-		return "", "", "", fmt.Errorf("insufficient length for public key %s", trimmedKey)
+	normalizedKey, err := normalizeExtendedPublicKey(extendedPublicKey)
+	if err != nil {
+		return "", "", "", err
 	}
-	publicKeyDescriptor := trimmedKey[:4]
-	suffix := trimmedKey[len(trimmedKey)-8:]
+	publicKeyDescriptor := normalizedKey[:4]
+	suffix := normalizedKey[len(normalizedKey)-8:]
 	directory := fmt.Sprintf("%s/%s/%s", dis.path, chainName, directoryName)
 	truncatedKey := fmt.Sprintf("%s_%s", publicKeyDescriptor, suffix)
 	path := fmt.Sprintf("%s/%s", directory, truncatedKey)
 	return path, directory, truncatedKey, nil
 }
 
-// save marks an index as used for a particular extendedPublicKey
-func (dis *DerivationIndexStorage) save(extendedPublicKey string, index uint32) error {
+// normalizeExtendedPublicKey parses the extended public key and serializes it
+// back to its canonical form.
+func normalizeExtendedPublicKey(extendedPublicKey string) (string, error) {
+	key, err := hdkeychain.NewKeyFromString(strings.TrimSpace(extendedPublicKey))
+	if err != nil {
+		return "", fmt.Errorf("invalid extended public key: [%v]", err)
+	}
+
+	if key.IsPrivate() {
+		return "", fmt.Errorf("invalid extended public key: [key is private]")
+	}
+
+	return key.String(), nil
+}
+
+// save marks an index as used for a particular extendedPublicKey. Every
+// used index is kept as a file named after the index and holding the bitcoin
+// address derived at that index, if known.
+func (dis *DerivationIndexStorage) save(
+	extendedPublicKey string,
+	index uint32,
+	btcAddress string,
+) error {
 	dirPath, directory, truncatedKey, err := dis.getStoragePath(extendedPublicKey)
 	if err != nil {
 		return err
@@ -81,28 +111,69 @@ func (dis *DerivationIndexStorage) save(extendedPublicKey string, index uint32)
 
 	files, err := ioutil.ReadDir(dirPath)
 	if err != nil {
-		logger.Errorf("something went wrong trying to clean up old index files: [%v]", err)
+		logger.Errorf("something went wrong trying to clean up temporary index files: [%v]", err)
 	}
 
-	// clean up old indexes
+	// clean up leftovers of interrupted writes, these indexes have not been
+	// committed
 	for _, file := range files {
-		fileIndex, err := strconv.Atoi(file.Name())
-		if err != nil {
-			logger.Errorf("something went wrong trying to clean up old index files: [%v]", err)
-			continue
-		}
-
-		if uint32(fileIndex) < index {
+		if strings.HasSuffix(file.Name(), tempFileSuffix) {
 			err = os.Remove(fmt.Sprintf("%s/%s", dirPath, file.Name()))
 			if err != nil {
-				logger.Errorf("something went wrong trying to clean up old index files: [%v]", err)
-				continue
+				logger.Errorf("something went wrong trying to clean up temporary index files: [%v]", err)
 			}
 		}
 	}
+
 	filePath := fmt.Sprintf("%s/%d", dirPath, index)
 
-	return persistence.Write(filePath, []byte{})
+	return writeFileAtomically(filePath, []byte(btcAddress))
+}
+
+// writeFileAtomically writes the file at the given path so that it is
+// either fully written or not present at all, even if the process crashes
+// in the middle of the write. The file is written under a temporary name
+// first and then renamed, which is atomic on POSIX file systems.
+func writeFileAtomically(filePath string, data []byte) error {
+	tempFilePath := filePath + tempFileSuffix
+
+	file, err := os.OpenFile(
+		tempFilePath,
+		os.O_WRONLY|os.O_CREATE|os.O_TRUNC,
+		0600,
+	)
+	if err != nil {
+		return fmt.Errorf("could not create file [%s]: [%v]", tempFilePath, err)
+	}
+
+	_, err = file.Write(data)
+	if err != nil {
+		closeFile(file)
+		return fmt.Errorf("could not write file [%s]: [%v]", tempFilePath, err)
+	}
+
+	err = file.Sync()
+	if err != nil {
+		closeFile(file)
+		return fmt.Errorf("could not sync file [%s]: [%v]", tempFilePath, err)
+	}
+
+	err = file.Close()
+	if err != nil {
+		return fmt.Errorf("could not close file [%s]: [%v]", tempFilePath, err)
+	}
+
+	err = os.Rename(tempFilePath, filePath)
+	if err != nil {
+		return fmt.Errorf(
+			"could not rename file [%s] to [%s]: [%v]",
+			tempFilePath,
+			filePath,
+			err,
+		)
+	}
+
+	return nil
 }
 
 // Read returns the most recently used index for the extended public key
@@ -119,6 +190,12 @@ func (dis *DerivationIndexStorage) read(extendedPublicKey string) (int, error) {
 	}
 
 	for _, file := range files {
+		// temporary files are not committed indexes, they are left over
+		// when the process crashed while saving the index
+		if strings.HasSuffix(file.Name(), tempFileSuffix) {
+			continue
+		}
+
 		fileIndex, err := strconv.Atoi(file.Name())
 		if err != nil {
 			return 0, err
@@ -131,18 +208,115 @@ func (dis *DerivationIndexStorage) read(extendedPublicKey string) (int, error) {
 	return index, nil
 }
 
-// GetNextAddress returns the next unused btc address for the extended public key
-func (dis *DerivationIndexStorage) GetNextAddress(
+// IndexRecord describes a derivation index used for an extended public key.
+type IndexRecord struct {
+	Index uint32
+	// BtcAddress is the address derived at the index. It is empty if the
+	// index has been reserved without deriving an address.
+	BtcAddress string
+	SavedAt    time.Time
+}
+
+// UsedIndices returns records of all the indexes used for the extended public
+// key, sorted by index in ascending order.
+func (dis *DerivationIndexStorage) UsedIndices(
 	extendedPublicKey string,
-	handle bitcoin.Handle,
-	chainParams *chaincfg.Params,
-	isDryRun bool,
-) (string, error) {
+) ([]IndexRecord, error) {
 	dis.mutex.Lock()
 	defer dis.mutex.Unlock()
+
 	dirPath, _, _, err := dis.getStoragePath(extendedPublicKey)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	files, err := ioutil.ReadDir(dirPath)
+	if os.IsNotExist(err) {
+		return []IndexRecord{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	records := make([]IndexRecord, 0, len(files))
+	for _, file := range files {
+		if strings.HasSuffix(file.Name(), tempFileSuffix) {
+			continue
+		}
+
+		fileIndex, err := strconv.ParseUint(file.Name(), 10, 32)
+		if err != nil {
+			return nil, err
+		}
+
+		btcAddress, err := ioutil.ReadFile(fmt.Sprintf("%s/%s", dirPath, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, IndexRecord{
+			Index:      uint32(fileIndex),
+			BtcAddress: string(btcAddress),
+			SavedAt:    file.ModTime(),
+		})
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Index < records[j].Index
+	})
+
+	return records, nil
+}
+
+// GetNextIndex returns the index following the most recently committed index
+// for the extended public key, or zero if no index has been committed yet.
+// Indexes of interrupted writes are not considered committed.
+func (dis *DerivationIndexStorage) GetNextIndex(
+	extendedPublicKey string,
+) (uint32, error) {
+	dis.mutex.Lock()
+	defer dis.mutex.Unlock()
+
+	return dis.nextIndex(extendedPublicKey)
+}
+
+// ReserveNextIndex computes the index following the most recently committed
+// index for the extended public key and commits it together with the given
+// btc address in one step, so concurrent callers never get the same index.
+func (dis *DerivationIndexStorage) ReserveNextIndex(
+	extendedPublicKey string,
+	btcAddress string,
+) (uint32, error) {
+	dis.mutex.Lock()
+	defer dis.mutex.Unlock()
+
+	return dis.reserveNextIndex(extendedPublicKey, btcAddress)
+}
+
+// reserveNextIndex commits the index following the most recently committed
+// index for the extended public key. The caller must hold the storage mutex.
+func (dis *DerivationIndexStorage) reserveNextIndex(
+	extendedPublicKey string,
+	btcAddress string,
+) (uint32, error) {
+	index, err := dis.nextIndex(extendedPublicKey)
+	if err != nil {
+		return 0, err
+	}
+
+	err = dis.save(extendedPublicKey, index, btcAddress)
+	if err != nil {
+		return 0, err
+	}
+
+	return index, nil
+}
+
+func (dis *DerivationIndexStorage) nextIndex(
+	extendedPublicKey string,
+) (uint32, error) {
+	dirPath, _, _, err := dis.getStoragePath(extendedPublicKey)
+	if err != nil {
+		return 0, err
 	}
 
 	lastIndex := -1
@@ -150,17 +324,99 @@ func (dis *DerivationIndexStorage) GetNextAddress(
 	if err == nil {
 		lastIndex, err = dis.read(extendedPublicKey)
 		if err != nil {
-			return "", err
+			return 0, err
 		}
 	} else if !os.IsNotExist(err) {
+		return 0, err
+	}
+
+	return uint32(lastIndex + 1), nil
+}
+
+// ScanForNextUnusedIndex looks for the first index, starting from the index
+// following the most recently committed one, which is followed by gapLimit
+// consecutive unused addresses, including the address at that index. Unlike
+// GetNextAddress, it does not treat addresses whose usage could not be
+// determined as unused but returns an error. The found index is not
+// committed.
+func (dis *DerivationIndexStorage) ScanForNextUnusedIndex(
+	extendedPublicKey string,
+	gapLimit int,
+	handle bitcoin.Handle,
+	chainParams *chaincfg.Params,
+) (uint32, error) {
+	if gapLimit <= 0 {
+		return 0, fmt.Errorf("gap limit [%d] must be positive", gapLimit)
+	}
+
+	dis.mutex.Lock()
+	defer dis.mutex.Unlock()
+
+	startIndex, err := dis.nextIndex(extendedPublicKey)
+	if err != nil {
+		return 0, err
+	}
+
+	firstUnusedIndex := startIndex
+	unusedCount := 0
+	for batchStartIndex := startIndex; ; batchStartIndex += uint32(gapLimit) {
+		addresses, err := bitcoin.DeriveAddresses(
+			strings.TrimSpace(extendedPublicKey),
+			bitcoin.ExternalChain,
+			batchStartIndex,
+			uint32(gapLimit),
+			chainParams,
+		)
+		if err != nil {
+			return 0, err
+		}
+
+		for i, address := range addresses {
+			index := batchStartIndex + uint32(i)
+
+			isUnused, err := handle.IsAddressUnused(address)
+			if err != nil {
+				return 0, fmt.Errorf(
+					"could not determine if address [%s] at index [%d] is unused: [%w]",
+					address,
+					index,
+					err,
+				)
+			}
+
+			if !isUnused {
+				firstUnusedIndex = index + 1
+				unusedCount = 0
+				continue
+			}
+
+			unusedCount++
+			if unusedCount == gapLimit {
+				return firstUnusedIndex, nil
+			}
+		}
+	}
+}
+
+// GetNextAddress returns the next unused btc address for the extended public key
+func (dis *DerivationIndexStorage) GetNextAddress(
+	extendedPublicKey string,
+	handle bitcoin.Handle,
+	chainParams *chaincfg.Params,
+	isDryRun bool,
+) (string, error) {
+	dis.mutex.Lock()
+	defer dis.mutex.Unlock()
+
+	index, err := dis.nextIndex(extendedPublicKey)
+	if err != nil {
 		return "", err
 	}
 
-	startIndex := uint32(lastIndex + 1)
-	for i := uint32(0); true; i++ {
-		index := startIndex + i
+	for ; true; index++ {
 		derivedAddress, err := bitcoin.DeriveAddress(
 			strings.TrimSpace(extendedPublicKey),
+			bitcoin.ExternalChain,
 			index,
 			chainParams,
 		)
@@ -177,7 +433,9 @@ func (dis *DerivationIndexStorage) GetNextAddress(
 		}
 
 		if isDryRun != true {
-			err = dis.save(extendedPublicKey, index)
+			// the mutex is held for the whole lookup, so the reserved index
+			// is the one the address has been derived at
+			_, err = dis.reserveNextIndex(extendedPublicKey, derivedAddress)
 			if err != nil {
 				return "", err
 			}
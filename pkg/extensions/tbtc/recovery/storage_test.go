@@ -1,9 +1,12 @@
 package recovery
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/keep-network/keep-ecdsa/pkg/chain/bitcoin"
@@ -25,12 +28,30 @@ func newMockBitcoinHandle() *mockBitcoinHandle {
 func (mbh mockBitcoinHandle) Broadcast(transaction string) error {
 	return mbh.broadcast(transaction)
 }
+func (mbh mockBitcoinHandle) IsDryRun() bool {
+	return false
+}
 func (mbh mockBitcoinHandle) VbyteFeeFor25Blocks() (int32, error) {
 	return mbh.vbyteFeeFor25Blocks()
 }
+func (mbh mockBitcoinHandle) VbyteFeeForTarget(blocks int) (int32, error) {
+	return mbh.vbyteFeeFor25Blocks()
+}
 func (mbh mockBitcoinHandle) IsAddressUnused(btcAddress string) (bool, error) {
 	return mbh.isAddressUnused(btcAddress)
 }
+func (mbh mockBitcoinHandle) AddressTransactionStatus(btcAddress string) (*bitcoin.AddressStatus, error) {
+	return &bitcoin.AddressStatus{}, nil
+}
+func (mbh mockBitcoinHandle) GetUnspentOutputs(btcAddress string) ([]bitcoin.UnspentOutput, error) {
+	return []bitcoin.UnspentOutput{}, nil
+}
+func (mbh mockBitcoinHandle) GetRawTransaction(txid string) (string, error) {
+	return "", bitcoin.ErrTransactionNotFound
+}
+func (mbh mockBitcoinHandle) GetTransactionConfirmations(txid string) (int, error) {
+	return 0, bitcoin.ErrTransactionNotFound
+}
 
 func TestDerivationIndexStorage_GetNextAddressOnNewKey(t *testing.T) {
 	chainParams := &chaincfg.MainNetParams
@@ -51,7 +72,7 @@ func TestDerivationIndexStorage_GetNextAddressOnNewKey(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		expectedBtcAddress, err := bitcoin.DeriveAddress(extendedPublicKey, i, chainParams)
+		expectedBtcAddress, err := bitcoin.DeriveAddress(extendedPublicKey, bitcoin.ExternalChain, i, chainParams)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -162,6 +183,7 @@ func TestDerivationIndexStorage_SaveThenGetNextAddress(t *testing.T) {
 				err = dis.save(
 					input.publicKey,
 					uint32(input.index),
+					"",
 				)
 				if err != nil {
 					t.Fatal(err)
@@ -209,7 +231,7 @@ func TestDerivationIndexStorage_GetNextAddressDryRun(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	err = dis.save(publicKey, uint32(usedIndex))
+	err = dis.save(publicKey, uint32(usedIndex), "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -248,26 +270,34 @@ func TestDerivationIndexStorage_OverwriteExistingPair(t *testing.T) {
 	}
 	extendedPublicKey := "xpub6Cg41S21VrxkW1WBTZJn95KNpHozP2Xc6AhG27ZcvZvH8XyNzunEqLdk9dxyXQUoy7ALWQFNn5K1me74aEMtS6pUgNDuCYTTMsJzCAk9sk1"
 	index := uint32(89)
-	err = dis.save(extendedPublicKey, index)
+	err = dis.save(extendedPublicKey, index, "")
 	if err != nil {
 		t.Fatal(err)
 	}
-	err = dis.save(extendedPublicKey, index)
+	err = dis.save(extendedPublicKey, index, "")
 	if err != nil {
 		t.Errorf("unexpected error trying to overwrite extendedPublicKey [%s] at index [%d]: [%v]", extendedPublicKey, index, err)
 	}
 }
 
-func TestDerivationIndexStorage_ShortExtendedPublicKeys(t *testing.T) {
+func TestDerivationIndexStorage_InvalidExtendedPublicKeys(t *testing.T) {
 	null := "\xff" // represents no error
 	testData := map[string]struct {
 		input         keyAndIndex
 		expectedError string
 	}{
-		"6-letter key":  {keyAndIndex{"abc123", 8}, "insufficient length for public key"},
-		"11-letter key": {keyAndIndex{"1111.1111.1", 12}, "insufficient length for public key"},
-		"12-letter key": {keyAndIndex{"1111.1111.11", 16}, null},
-		"13-letter key": {keyAndIndex{"1111.1111.111", 20}, null},
+		"6-letter key":  {keyAndIndex{"abc123", 8}, "invalid extended public key"},
+		"11-letter key": {keyAndIndex{"1111.1111.1", 12}, "invalid extended public key"},
+		"12-letter key": {keyAndIndex{"1111.1111.11", 16}, "invalid extended public key"},
+		"13-letter key": {keyAndIndex{"1111.1111.111", 20}, "invalid extended public key"},
+		"bad checksum": {
+			keyAndIndex{"xpub6Cg41S21VrxkW1WBTZJn95KNpHozP2Xc6AhG27ZcvZvH8XyNzunEqLdk9dxyXQUoy7ALWQFNn5K1me74aEMtS6pUgNDuCYTTMsJzCAk9sk2", 24},
+			"invalid extended public key",
+		},
+		"valid key": {
+			keyAndIndex{"xpub6Cg41S21VrxkW1WBTZJn95KNpHozP2Xc6AhG27ZcvZvH8XyNzunEqLdk9dxyXQUoy7ALWQFNn5K1me74aEMtS6pUgNDuCYTTMsJzCAk9sk1", 28},
+			null,
+		},
 	}
 	for testName, testData := range testData {
 		t.Run(testName, func(t *testing.T) {
@@ -281,7 +311,7 @@ func TestDerivationIndexStorage_ShortExtendedPublicKeys(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			err = dis.save(testData.input.publicKey, uint32(testData.input.index))
+			err = dis.save(testData.input.publicKey, uint32(testData.input.index), "")
 			if testData.expectedError == null {
 				if err != nil {
 					t.Errorf("unexpected error: [%v]", err)
@@ -358,7 +388,7 @@ func TestDerivationIndexStorage_MultipleAsyncGetNextAddresses(t *testing.T) {
 	}
 	extendedPublicKey := "xpub6Cg41S21VrxkW1WBTZJn95KNpHozP2Xc6AhG27ZcvZvH8XyNzunEqLdk9dxyXQUoy7ALWQFNn5K1me74aEMtS6pUgNDuCYTTMsJzCAk9sk1"
 	index := uint32(831)
-	err = dis.save(extendedPublicKey, index)
+	err = dis.save(extendedPublicKey, index, "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -379,7 +409,7 @@ func TestDerivationIndexStorage_MultipleAsyncGetNextAddresses(t *testing.T) {
 			for i := 0; i < iterations; i++ {
 				// the valid indexes should be 840, 850, 860, 870...
 				index := uint32(840) + 10*uint32(i)
-				derivedAddress, err := bitcoin.DeriveAddress(extendedPublicKey, index, chainParams)
+				derivedAddress, err := bitcoin.DeriveAddress(extendedPublicKey, bitcoin.ExternalChain, index, chainParams)
 				if err != nil {
 					getNextAddressResults <- pair{"", err}
 					return
@@ -400,7 +430,7 @@ func TestDerivationIndexStorage_MultipleAsyncGetNextAddresses(t *testing.T) {
 		}
 		// the valid indexes should be 840, 850, 860, 870...
 		expectedIndex := uint32(840) + 10*uint32(i)
-		expectedAddress, err := bitcoin.DeriveAddress(extendedPublicKey, expectedIndex, chainParams)
+		expectedAddress, err := bitcoin.DeriveAddress(extendedPublicKey, bitcoin.ExternalChain, expectedIndex, chainParams)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -424,9 +454,393 @@ func TestDervationIndexStorage_SaveOverwrites(t *testing.T) {
 	index := uint32(831)
 	iterations := 10
 	for i := 0; i < iterations; i++ {
-		err = dis.save(extendedPublicKey, index)
+		err = dis.save(extendedPublicKey, index, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestDerivationIndexStorage_GetNextIndexOnNewKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "example")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	dis, err := NewDerivationIndexStorage(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	extendedPublicKey := "xpub6Cg41S21VrxkW1WBTZJn95KNpHozP2Xc6AhG27ZcvZvH8XyNzunEqLdk9dxyXQUoy7ALWQFNn5K1me74aEMtS6pUgNDuCYTTMsJzCAk9sk1"
+
+	nextIndex, err := dis.GetNextIndex(extendedPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nextIndex != 0 {
+		t.Errorf("unexpected next index\nexpected: %d\nactual:   %d", 0, nextIndex)
+	}
+}
+
+func TestDerivationIndexStorage_SaveLeavesNoTemporaryFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "example")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	dis, err := NewDerivationIndexStorage(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	extendedPublicKey := "xpub6Cg41S21VrxkW1WBTZJn95KNpHozP2Xc6AhG27ZcvZvH8XyNzunEqLdk9dxyXQUoy7ALWQFNn5K1me74aEMtS6pUgNDuCYTTMsJzCAk9sk1"
+
+	for _, index := range []uint32{3, 7, 12} {
+		err = dis.save(extendedPublicKey, index, "")
 		if err != nil {
 			t.Fatal(err)
 		}
 	}
+
+	dirPath, _, _, err := dis.getStoragePath(extendedPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files, err := ioutil.ReadDir(dirPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// every committed index is stored as a file named after the index
+	fileNames := make([]string, len(files))
+	for i, file := range files {
+		fileNames[i] = file.Name()
+	}
+	expectedFileNames := []string{"12", "3", "7"}
+	if !reflect.DeepEqual(expectedFileNames, fileNames) {
+		t.Errorf(
+			"unexpected index files\nexpected: %v\nactual:   %v",
+			expectedFileNames,
+			fileNames,
+		)
+	}
+}
+
+func TestDerivationIndexStorage_IgnoresLeftoverTemporaryFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "example")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	dis, err := NewDerivationIndexStorage(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	extendedPublicKey := "xpub6Cg41S21VrxkW1WBTZJn95KNpHozP2Xc6AhG27ZcvZvH8XyNzunEqLdk9dxyXQUoy7ALWQFNn5K1me74aEMtS6pUgNDuCYTTMsJzCAk9sk1"
+	committedIndex := uint32(5)
+
+	err = dis.save(extendedPublicKey, committedIndex, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// simulate a crash in the middle of writing a higher index
+	dirPath, _, _, err := dis.getStoragePath(extendedPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(fmt.Sprintf("%s/%d%s", dirPath, 9, tempFileSuffix), []byte{}, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nextIndex, err := dis.GetNextIndex(extendedPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nextIndex != committedIndex+1 {
+		t.Errorf(
+			"unexpected next index\nexpected: %d\nactual:   %d",
+			committedIndex+1,
+			nextIndex,
+		)
+	}
+
+	expectedBtcAddress := "1QETuEAw5UBdYtz6vJw8L9582TdrrE4b3B" // index 6
+	address, err := dis.GetNextAddress(extendedPublicKey, newMockBitcoinHandle(), &chaincfg.MainNetParams, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if address != expectedBtcAddress {
+		t.Errorf("incorrect derived address\nexpected: %s\nactual:   %s", expectedBtcAddress, address)
+	}
+
+	storedIndex, err := dis.read(extendedPublicKey)
+	if err != nil {
+		t.Fatalf("failed to read last used index: %s", err)
+	}
+	if storedIndex != int(committedIndex+1) {
+		t.Errorf(
+			"the resolved index does not match\nexpected: %d\nactual:   %d",
+			committedIndex+1,
+			storedIndex,
+		)
+	}
+}
+
+func TestDerivationIndexStorage_ReserveNextIndexConcurrently(t *testing.T) {
+	dir, err := ioutil.TempDir("", "example")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	dis, err := NewDerivationIndexStorage(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	extendedPublicKey := "xpub6Cg41S21VrxkW1WBTZJn95KNpHozP2Xc6AhG27ZcvZvH8XyNzunEqLdk9dxyXQUoy7ALWQFNn5K1me74aEMtS6pUgNDuCYTTMsJzCAk9sk1"
+
+	type result struct {
+		index uint32
+		err   error
+	}
+	iterations := 50
+	results := make(chan result, iterations)
+	for i := 0; i < iterations; i++ {
+		go func(i int) {
+			index, err := dis.ReserveNextIndex(
+				extendedPublicKey,
+				fmt.Sprintf("address-%d", i),
+			)
+			results <- result{index, err}
+		}(i)
+	}
+
+	reservedIndexes := make(map[uint32]bool)
+	for i := 0; i < iterations; i++ {
+		result := <-results
+		if result.err != nil {
+			t.Fatal(result.err)
+		}
+		if reservedIndexes[result.index] {
+			t.Errorf("index [%d] has been reserved more than once", result.index)
+		}
+		reservedIndexes[result.index] = true
+	}
+
+	for index := uint32(0); index < uint32(iterations); index++ {
+		if !reservedIndexes[index] {
+			t.Errorf("index [%d] has not been reserved", index)
+		}
+	}
+
+	nextIndex, err := dis.GetNextIndex(extendedPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nextIndex != uint32(iterations) {
+		t.Errorf("unexpected next index\nexpected: %d\nactual:   %d", iterations, nextIndex)
+	}
+
+	records, err := dis.UsedIndices(extendedPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	storedAddresses := make(map[string]bool)
+	for _, record := range records {
+		storedAddresses[record.BtcAddress] = true
+	}
+	for i := 0; i < iterations; i++ {
+		address := fmt.Sprintf("address-%d", i)
+		if !storedAddresses[address] {
+			t.Errorf("address [%s] has not been stored", address)
+		}
+	}
+}
+
+func TestDerivationIndexStorage_KeyRepresentationsShareCounter(t *testing.T) {
+	dir, err := ioutil.TempDir("", "example")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	dis, err := NewDerivationIndexStorage(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	extendedPublicKey := "xpub6Cg41S21VrxkW1WBTZJn95KNpHozP2Xc6AhG27ZcvZvH8XyNzunEqLdk9dxyXQUoy7ALWQFNn5K1me74aEMtS6pUgNDuCYTTMsJzCAk9sk1"
+
+	err = dis.save("  "+extendedPublicKey+"\n", 41, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	index, err := dis.ReserveNextIndex(
+		"\t"+extendedPublicKey,
+		"1Je1vYfst9yGF95KkYitQ7QhdLUkNVCzfX",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if index != 42 {
+		t.Errorf("unexpected reserved index\nexpected: %d\nactual:   %d", 42, index)
+	}
+
+	nextIndex, err := dis.GetNextIndex(extendedPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nextIndex != 43 {
+		t.Errorf("unexpected next index\nexpected: %d\nactual:   %d", 43, nextIndex)
+	}
+}
+
+func TestDerivationIndexStorage_UsedIndices(t *testing.T) {
+	dir, err := ioutil.TempDir("", "example")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	dis, err := NewDerivationIndexStorage(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	extendedPublicKey := "xpub6Cg41S21VrxkW1WBTZJn95KNpHozP2Xc6AhG27ZcvZvH8XyNzunEqLdk9dxyXQUoy7ALWQFNn5K1me74aEMtS6pUgNDuCYTTMsJzCAk9sk1"
+
+	records, err := dis.UsedIndices(extendedPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 0 {
+		t.Errorf("unexpected number of records\nexpected: %d\nactual:   %d", 0, len(records))
+	}
+
+	savedIndexes := []keyAndAddress{
+		{extendedPublicKey, "1Je1vYfst9yGF95KkYitQ7QhdLUkNVCzfX", 173},
+		{extendedPublicKey, "1QETuEAw5UBdYtz6vJw8L9582TdrrE4b3B", 6},
+		{extendedPublicKey, "", 40},
+		{extendedPublicKey, "1Cck1ps6NGB9LGjrymNS21KC7fJyU4X3fw", 5091},
+	}
+
+	startTime := time.Now().Add(-time.Second)
+	for _, saved := range savedIndexes {
+		err = dis.save(saved.publicKey, uint32(saved.index), saved.btcAddress)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	records, err = dis.UsedIndices(extendedPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedRecords := []keyAndAddress{
+		savedIndexes[1],
+		savedIndexes[2],
+		savedIndexes[0],
+		savedIndexes[3],
+	}
+	if len(records) != len(expectedRecords) {
+		t.Fatalf(
+			"unexpected number of records\nexpected: %d\nactual:   %d",
+			len(expectedRecords),
+			len(records),
+		)
+	}
+	for i, record := range records {
+		expected := expectedRecords[i]
+		if record.Index != uint32(expected.index) {
+			t.Errorf(
+				"unexpected index of record [%d]\nexpected: %d\nactual:   %d",
+				i,
+				expected.index,
+				record.Index,
+			)
+		}
+		if record.BtcAddress != expected.btcAddress {
+			t.Errorf(
+				"unexpected address of record [%d]\nexpected: %s\nactual:   %s",
+				i,
+				expected.btcAddress,
+				record.BtcAddress,
+			)
+		}
+		if record.SavedAt.Before(startTime) {
+			t.Errorf(
+				"unexpected save time of record [%d]\nexpected after: %v\nactual:         %v",
+				i,
+				startTime,
+				record.SavedAt,
+			)
+		}
+	}
+}
+
+func TestDerivationIndexStorage_ScanForNextUnusedIndex(t *testing.T) {
+	dir, err := ioutil.TempDir("", "example")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	dis, err := NewDerivationIndexStorage(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	extendedPublicKey := "xpub6Cg41S21VrxkW1WBTZJn95KNpHozP2Xc6AhG27ZcvZvH8XyNzunEqLdk9dxyXQUoy7ALWQFNn5K1me74aEMtS6pUgNDuCYTTMsJzCAk9sk1"
+	chainParams := &chaincfg.MainNetParams
+
+	err = dis.save(extendedPublicKey, 5, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// indexes 6 to 9 are used, then there is a gap of one unused address
+	// followed by another used one at index 11
+	usedIndexes := []uint32{6, 7, 8, 9, 11}
+	usedAddresses := make(map[string]bool)
+	for _, index := range usedIndexes {
+		address, err := bitcoin.DeriveAddress(extendedPublicKey, bitcoin.ExternalChain, index, chainParams)
+		if err != nil {
+			t.Fatal(err)
+		}
+		usedAddresses[address] = true
+	}
+
+	handle := newMockBitcoinHandle()
+	handle.isAddressUnused = func(btcAddress string) (bool, error) {
+		return !usedAddresses[btcAddress], nil
+	}
+
+	index, err := dis.ScanForNextUnusedIndex(extendedPublicKey, 3, handle, chainParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedIndex := uint32(12)
+	if index != expectedIndex {
+		t.Errorf("unexpected index\nexpected: %d\nactual:   %d", expectedIndex, index)
+	}
+}
+
+func TestDerivationIndexStorage_ScanForNextUnusedIndexError(t *testing.T) {
+	dir, err := ioutil.TempDir("", "example")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	dis, err := NewDerivationIndexStorage(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	extendedPublicKey := "xpub6Cg41S21VrxkW1WBTZJn95KNpHozP2Xc6AhG27ZcvZvH8XyNzunEqLdk9dxyXQUoy7ALWQFNn5K1me74aEMtS6pUgNDuCYTTMsJzCAk9sk1"
+
+	handle := newMockBitcoinHandle()
+	handle.isAddressUnused = func(btcAddress string) (bool, error) {
+		return true, fmt.Errorf("electrs is down")
+	}
+
+	_, err = dis.ScanForNextUnusedIndex(extendedPublicKey, 3, handle, &chaincfg.MainNetParams)
+	if !ErrorContains(err, "electrs is down") {
+		t.Errorf("unexpected error\nexpected: electrs is down\nactual:   %v", err)
+	}
 }
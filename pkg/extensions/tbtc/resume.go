@@ -0,0 +1,137 @@
+package tbtc
+
+import (
+	"github.com/keep-network/keep-ecdsa/pkg/chain"
+)
+
+// registerMonitoringResumer registers the start event handler of the
+// monitoring which should be started for deposits in the given state when
+// existing deposits are reconciled.
+func (t *tbtc) registerMonitoringResumer(
+	depositState chain.DepositState,
+	handler depositEventHandler,
+) {
+	t.monitoringResumersMutex.Lock()
+	defer t.monitoringResumersMutex.Unlock()
+
+	t.monitoringResumers[depositState] = handler
+}
+
+func (t *tbtc) monitoringResumer(
+	depositState chain.DepositState,
+) (depositEventHandler, bool) {
+	t.monitoringResumersMutex.Lock()
+	defer t.monitoringResumersMutex.Unlock()
+
+	handler, ok := t.monitoringResumers[depositState]
+	return handler, ok
+}
+
+// reconcileExistingDeposits looks up deposits created within the last deposit
+// term and starts monitorings for those whose current state requires
+// an action requested at or after the given start block. Monitorings are
+// started the same way as for live start events, so a deposit already
+// monitored is not monitored twice.
+func (t *tbtc) reconcileExistingDeposits(startBlock uint64) error {
+	lookupStartBlock := t.depositsLookupStartBlock()
+
+	createdEvents, err := t.handle.PastDepositCreatedEvents(lookupStartBlock)
+	if err != nil {
+		return err
+	}
+
+	logger.Infof(
+		"reconciling [%v] existing deposits created since block [%v]; "+
+			"resuming actions requested since block [%v]",
+		len(createdEvents),
+		lookupStartBlock,
+		startBlock,
+	)
+
+	for _, createdEvent := range createdEvents {
+		depositAddress := createdEvent.DepositAddress
+
+		depositState, err := t.handle.CurrentState(depositAddress)
+		if err != nil {
+			logger.Warningf(
+				"could not get current state of deposit [%v]: [%v]",
+				depositAddress,
+				err,
+			)
+			continue
+		}
+
+		resume, ok := t.monitoringResumer(depositState)
+		if !ok {
+			continue
+		}
+
+		requested, err := t.actionRequestedSince(
+			createdEvent,
+			depositState,
+			startBlock,
+		)
+		if err != nil {
+			logger.Warningf(
+				"could not check when the action for deposit [%v] "+
+					"has been requested: [%v]",
+				depositAddress,
+				err,
+			)
+			continue
+		}
+
+		if !requested {
+			continue
+		}
+
+		logger.Infof(
+			"resuming monitoring of deposit [%v] in state [%v]",
+			depositAddress,
+			depositState,
+		)
+
+		resume(depositAddress)
+	}
+
+	return nil
+}
+
+// actionRequestedSince checks whether the action the deposit in the given
+// state awaits has been requested at or after the given start block.
+func (t *tbtc) actionRequestedSince(
+	createdEvent *chain.DepositCreatedEvent,
+	depositState chain.DepositState,
+	startBlock uint64,
+) (bool, error) {
+	switch depositState {
+	case chain.AwaitingWithdrawalSignature, chain.AwaitingWithdrawalProof:
+		// The redemption requested event is emitted for the redemption
+		// request and every redemption fee increase, so it is the latest
+		// request for the redemption signature or proof.
+		redemptionRequestedEvents, err := t.handle.PastDepositRedemptionRequestedEvents(
+			startBlock,
+			createdEvent.DepositAddress,
+		)
+		if err != nil {
+			return false, err
+		}
+
+		return len(redemptionRequestedEvents) > 0, nil
+	default:
+		return createdEvent.BlockNumber >= startBlock, nil
+	}
+}
+
+func (t *tbtc) depositsLookupStartBlock() uint64 {
+	currentBlock, err := t.blockCounter.CurrentBlock()
+	if err != nil {
+		return 0 // if something went wrong, start from block `0`
+	}
+
+	if currentBlock <= reconciliationLookbackBlocks {
+		return 0
+	}
+
+	return currentBlock - reconciliationLookbackBlocks
+}
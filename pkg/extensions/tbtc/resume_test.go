@@ -0,0 +1,268 @@
+package tbtc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/keep-network/keep-ecdsa/pkg/chain/local"
+)
+
+func TestReconcileExistingDeposits_RedemptionInProgress(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	tbtcChain := local.NewTBTCLocalChain(ctx)
+	tbtc := newTestTBTC(tbtcChain)
+
+	signers := append(
+		[]common.Address{tbtcChain.OperatorAddress()},
+		local.RandomSigningGroup(2)...,
+	)
+
+	// The redemption is requested before the monitoring is set up, just like
+	// it would be if the client was down at that time.
+	tbtcChain.CreateDeposit(depositAddress, signers)
+	tbtcChain.FundDeposit(depositAddress)
+
+	_, err := submitKeepPublicKey(depositAddress, tbtcChain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = tbtcChain.RedeemDeposit(depositAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keepSignature, err := submitKeepSignature(depositAddress, tbtcChain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tbtc.monitorProvideRedemptionSignature(
+		ctx,
+		constantBackoff,
+		timeout,
+		maxActAttempts,
+	)
+
+	err = tbtc.reconcileExistingDeposits(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// wait a bit longer than the monitoring timeout
+	// to make sure the potential transaction completes
+	time.Sleep(2 * timeout)
+
+	expectedProvideRedemptionSignatureCalls := 1
+	actualProvideRedemptionSignatureCalls := tbtcChain.Logger().
+		ProvideRedemptionSignatureCalls()
+	if expectedProvideRedemptionSignatureCalls !=
+		actualProvideRedemptionSignatureCalls {
+		t.Errorf(
+			"unexpected number of ProvideRedemptionSignature calls\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedProvideRedemptionSignatureCalls,
+			actualProvideRedemptionSignatureCalls,
+		)
+	}
+
+	depositSignature, err := tbtcChain.DepositRedemptionSignature(
+		depositAddress,
+	)
+	if err != nil {
+		t.Errorf(
+			"unexpected error while fetching deposit signature: [%v]",
+			err,
+		)
+	}
+
+	if !areChainSignaturesEqual(keepSignature, depositSignature) {
+		t.Errorf(
+			"unexpected signature\n"+
+				"expected: [%+v]\n"+
+				"actual:   [%+v]",
+			keepSignature,
+			depositSignature,
+		)
+	}
+}
+
+func TestReconcileExistingDeposits_AlreadyMonitored(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	tbtcChain := local.NewTBTCLocalChain(ctx)
+	tbtc := newTestTBTC(tbtcChain)
+
+	tbtc.monitorProvideRedemptionSignature(
+		ctx,
+		constantBackoff,
+		timeout,
+		maxActAttempts,
+	)
+
+	signers := append(
+		[]common.Address{tbtcChain.OperatorAddress()},
+		local.RandomSigningGroup(2)...,
+	)
+
+	tbtcChain.CreateDeposit(depositAddress, signers)
+	tbtcChain.FundDeposit(depositAddress)
+
+	_, err := submitKeepPublicKey(depositAddress, tbtcChain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The live redemption requested event starts the monitoring.
+	err = tbtcChain.RedeemDeposit(depositAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = submitKeepSignature(depositAddress, tbtcChain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = tbtc.reconcileExistingDeposits(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// wait a bit longer than the monitoring timeout
+	// to make sure the potential transaction completes
+	time.Sleep(2 * timeout)
+
+	expectedProvideRedemptionSignatureCalls := 1
+	actualProvideRedemptionSignatureCalls := tbtcChain.Logger().
+		ProvideRedemptionSignatureCalls()
+	if expectedProvideRedemptionSignatureCalls !=
+		actualProvideRedemptionSignatureCalls {
+		t.Errorf(
+			"unexpected number of ProvideRedemptionSignature calls\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedProvideRedemptionSignatureCalls,
+			actualProvideRedemptionSignatureCalls,
+		)
+	}
+}
+
+func TestReconcileExistingDeposits_NoActionNeeded(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	tbtcChain := local.NewTBTCLocalChain(ctx)
+	tbtc := newTestTBTC(tbtcChain)
+
+	signers := append(
+		[]common.Address{tbtcChain.OperatorAddress()},
+		local.RandomSigningGroup(2)...,
+	)
+
+	tbtcChain.CreateDeposit(depositAddress, signers)
+	tbtcChain.FundDeposit(depositAddress)
+
+	_, err := submitKeepPublicKey(depositAddress, tbtcChain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tbtc.monitorProvideRedemptionSignature(
+		ctx,
+		constantBackoff,
+		timeout,
+		maxActAttempts,
+	)
+
+	err = tbtc.reconcileExistingDeposits(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(2 * timeout)
+
+	if tbtc.monitoringLimiter.concurrency().Active != 0 {
+		t.Errorf("deposit not awaiting an action should not be monitored")
+	}
+
+	if calls := tbtcChain.Logger().ProvideRedemptionSignatureCalls(); calls != 0 {
+		t.Errorf(
+			"unexpected number of ProvideRedemptionSignature calls\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			0,
+			calls,
+		)
+	}
+}
+
+func TestReconcileExistingDeposits_RedemptionRequestedBeforeStartBlock(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	tbtcChain := local.NewTBTCLocalChain(ctx)
+	tbtc := newTestTBTC(tbtcChain)
+
+	signers := append(
+		[]common.Address{tbtcChain.OperatorAddress()},
+		local.RandomSigningGroup(2)...,
+	)
+
+	tbtcChain.CreateDeposit(depositAddress, signers)
+	tbtcChain.FundDeposit(depositAddress)
+
+	_, err := submitKeepPublicKey(depositAddress, tbtcChain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = tbtcChain.RedeemDeposit(depositAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = submitKeepSignature(depositAddress, tbtcChain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tbtc.monitorProvideRedemptionSignature(
+		ctx,
+		constantBackoff,
+		timeout,
+		maxActAttempts,
+	)
+
+	currentBlock, err := tbtcChain.BlockCounter().CurrentBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The redemption has been requested before the start block so it
+	// should not be replayed.
+	err = tbtc.reconcileExistingDeposits(currentBlock + 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// wait a bit longer than the monitoring timeout
+	// to make sure the potential transaction completes
+	time.Sleep(2 * timeout)
+
+	if calls := tbtcChain.Logger().ProvideRedemptionSignatureCalls(); calls != 0 {
+		t.Errorf(
+			"unexpected number of ProvideRedemptionSignature calls\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			0,
+			calls,
+		)
+	}
+}
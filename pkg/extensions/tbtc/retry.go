@@ -0,0 +1,42 @@
+package tbtc
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/keep-network/keep-ecdsa/pkg/chain"
+)
+
+// errorClassifierFn reports whether the monitoring action failed with the
+// given error should be retried.
+type errorClassifierFn func(err error) (retryable bool)
+
+// permanentRevertReasons are fragments of revert reasons of tBTC deposit
+// transactions which fail the same way no matter how many times they are
+// retried, because the deposit is not in the state the transaction expects.
+var permanentRevertReasons = []string{
+	"not currently awaiting signer setup",
+	"not currently awaiting a signature",
+	"redemption proof only allowed from redemption flow",
+	"fee increase only available after signature provided",
+}
+
+// defaultClassifyError classifies errors of monitoring actions. Missing
+// deposits, malformed deposit addresses and known permanent reverts are not
+// retryable. All other errors, like nonce issues or temporary RPC failures,
+// are considered retryable.
+func defaultClassifyError(err error) bool {
+	if errors.Is(err, chain.ErrDepositNotFound) ||
+		errors.Is(err, chain.ErrInvalidDepositAddress) {
+		return false
+	}
+
+	message := strings.ToLower(err.Error())
+	for _, reason := range permanentRevertReasons {
+		if strings.Contains(message, reason) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,119 @@
+package tbtc
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/keep-network/keep-ecdsa/pkg/chain"
+)
+
+func TestDefaultClassifyError(t *testing.T) {
+	var tests = map[string]struct {
+		err               error
+		expectedRetryable bool
+	}{
+		"temporary rpc failure": {
+			err:               fmt.Errorf("connection reset by peer"),
+			expectedRetryable: true,
+		},
+		"nonce issue": {
+			err:               fmt.Errorf("nonce too low"),
+			expectedRetryable: true,
+		},
+		"deposit not found": {
+			err: fmt.Errorf(
+				"no deposit with address [deposit]: [%w]",
+				chain.ErrDepositNotFound,
+			),
+			expectedRetryable: false,
+		},
+		"malformed deposit address": {
+			err: fmt.Errorf(
+				"[deposit] is not a deposit address: [%w]",
+				chain.ErrInvalidDepositAddress,
+			),
+			expectedRetryable: false,
+		},
+		"wrong deposit state revert": {
+			err: fmt.Errorf(
+				"execution reverted: Not currently awaiting a signature",
+			),
+			expectedRetryable: false,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			retryable := defaultClassifyError(test.err)
+			if test.expectedRetryable != retryable {
+				t.Errorf(
+					"unexpected classification\n"+
+						"expected: [%v]\n"+
+						"actual:   [%v]",
+					test.expectedRetryable,
+					retryable,
+				)
+			}
+		})
+	}
+}
+
+func TestMonitorDeposit_PermanentError(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	var actCounter uint64
+	actFn := func(ctx context.Context, depositAddress string) error {
+		atomic.AddUint64(&actCounter, 1)
+		return fmt.Errorf("execution reverted: Not currently awaiting a signature")
+	}
+
+	shouldStop := func() (bool, error) {
+		return false, nil
+	}
+
+	stopReason := monitorDeposit(
+		ctx,
+		newMonitoringLog(
+			logger,
+			monitoringLogFields{
+				monitorType:    "monitoring",
+				depositAddress: "deposit",
+			},
+		),
+		"deposit",
+		make(chan struct{}),
+		make(chan StopReason),
+		0,
+		actFn,
+		shouldStop,
+		constantBackoff,
+		maxActAttempts,
+		nil,
+		defaultClassifyError,
+		newMetrics().monitoring("monitoring"),
+	)
+
+	if stopReason != StopReasonActionFailedPermanently {
+		t.Errorf(
+			"unexpected stop reason\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			StopReasonActionFailedPermanently,
+			stopReason,
+		)
+	}
+
+	expectedActCounter := uint64(1)
+	if actCounter != expectedActCounter {
+		t.Errorf(
+			"unexpected number of action invocations\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedActCounter,
+			actCounter,
+		)
+	}
+}
@@ -0,0 +1,63 @@
+package tbtc
+
+import (
+	"context"
+	"sync"
+)
+
+// monitoringRoutines keeps track of goroutines started by deposit
+// monitorings so the extension can wait for them to exit on shutdown.
+// Unlike sync.WaitGroup, new goroutines can be started while someone is
+// waiting.
+type monitoringRoutines struct {
+	mutex   sync.Mutex
+	running int
+	idle    chan struct{}
+}
+
+func newMonitoringRoutines() *monitoringRoutines {
+	idle := make(chan struct{})
+	close(idle)
+
+	return &monitoringRoutines{idle: idle}
+}
+
+// run executes the given function in a new tracked goroutine.
+func (mr *monitoringRoutines) run(fn func()) {
+	mr.mutex.Lock()
+	if mr.running == 0 {
+		mr.idle = make(chan struct{})
+	}
+	mr.running++
+	mr.mutex.Unlock()
+
+	go func() {
+		defer mr.done()
+		fn()
+	}()
+}
+
+func (mr *monitoringRoutines) done() {
+	mr.mutex.Lock()
+	defer mr.mutex.Unlock()
+
+	mr.running--
+	if mr.running == 0 {
+		close(mr.idle)
+	}
+}
+
+// wait blocks until no tracked goroutine is running or the context is done.
+// In the latter case, the context error is returned.
+func (mr *monitoringRoutines) wait(ctx context.Context) error {
+	mr.mutex.Lock()
+	idle := mr.idle
+	mr.mutex.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
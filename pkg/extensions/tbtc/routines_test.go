@@ -0,0 +1,102 @@
+package tbtc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/keep-network/keep-ecdsa/pkg/chain/local"
+)
+
+func TestWait_MonitoringsExitedAfterCancellation(t *testing.T) {
+	chainCtx, cancelChainCtx := context.WithCancel(context.Background())
+	defer cancelChainCtx()
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	tbtcChain := local.NewTBTCLocalChain(chainCtx)
+	tbtc := newTestTBTC(tbtcChain)
+	handle := &Handle{tbtc: tbtc}
+
+	tbtc.monitorRetrievePubKey(
+		ctx,
+		constantBackoff,
+		time.Hour,
+		maxActAttempts,
+	)
+
+	signers := append(
+		[]common.Address{tbtcChain.OperatorAddress()},
+		local.RandomSigningGroup(2)...,
+	)
+
+	tbtcChain.CreateDeposit(depositAddress, signers)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for handle.MonitoringConcurrency().Active != 1 {
+		if time.Now().After(deadline) {
+			t.Fatalf("deposit monitoring has not been started")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	cancelCtx()
+
+	waitCtx, cancelWaitCtx := context.WithTimeout(
+		context.Background(),
+		5*time.Second,
+	)
+	defer cancelWaitCtx()
+
+	if err := handle.Wait(waitCtx); err != nil {
+		t.Fatalf("unexpected error: [%v]", err)
+	}
+
+	if handle.MonitoringConcurrency().Active != 0 {
+		t.Errorf(
+			"unexpected number of active monitorings\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			0,
+			handle.MonitoringConcurrency().Active,
+		)
+	}
+}
+
+func TestMonitoringRoutines_WaitDeadline(t *testing.T) {
+	routines := newMonitoringRoutines()
+
+	release := make(chan struct{})
+	defer close(release)
+
+	routines.run(func() {
+		<-release
+	})
+
+	waitCtx, cancelWaitCtx := context.WithTimeout(
+		context.Background(),
+		100*time.Millisecond,
+	)
+	defer cancelWaitCtx()
+
+	err := routines.wait(waitCtx)
+	if err != context.DeadlineExceeded {
+		t.Errorf(
+			"unexpected error\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			context.DeadlineExceeded,
+			err,
+		)
+	}
+}
+
+func TestMonitoringRoutines_WaitNoRoutines(t *testing.T) {
+	routines := newMonitoringRoutines()
+
+	if err := routines.wait(context.Background()); err != nil {
+		t.Errorf("unexpected error: [%v]", err)
+	}
+}
@@ -0,0 +1,115 @@
+package tbtc
+
+import (
+	"math/rand"
+	"sync"
+
+	"github.com/keep-network/keep-common/pkg/subscription"
+)
+
+// StopReason describes why a deposit monitoring has stopped.
+type StopReason int
+
+const (
+	// StopReasonActionPerformed means the monitoring action has been
+	// performed successfully.
+	StopReasonActionPerformed StopReason = iota
+	// StopReasonActionNotNeeded means the deposit has left the monitored
+	// state before the monitoring action has been performed.
+	StopReasonActionNotNeeded
+	// StopReasonStopEvent means an on-chain event ending the monitoring
+	// occurred, for example the deposit has been redeemed.
+	StopReasonStopEvent
+	// StopReasonKeepClosed means the keep backing the deposit has been closed.
+	StopReasonKeepClosed
+	// StopReasonKeepTerminated means the keep backing the deposit has been
+	// terminated.
+	StopReasonKeepTerminated
+	// StopReasonContextCancelled means the monitoring has been cancelled,
+	// either on demand or because the extension is shutting down.
+	StopReasonContextCancelled
+	// StopReasonActionGaveUp means the monitoring action has failed the
+	// maximum number of times.
+	StopReasonActionGaveUp
+	// StopReasonSetupFailed means the monitoring could not be set up.
+	StopReasonSetupFailed
+	// StopReasonDepositNotFound means the monitored deposit does not exist
+	// on chain so the monitoring action cannot succeed.
+	StopReasonDepositNotFound
+	// StopReasonActionFailedPermanently means the monitoring action has
+	// failed with an error which is not worth retrying.
+	StopReasonActionFailedPermanently
+)
+
+func (sr StopReason) String() string {
+	switch sr {
+	case StopReasonActionPerformed:
+		return "action performed"
+	case StopReasonActionNotNeeded:
+		return "action not needed"
+	case StopReasonStopEvent:
+		return "stop event"
+	case StopReasonKeepClosed:
+		return "keep closed"
+	case StopReasonKeepTerminated:
+		return "keep terminated"
+	case StopReasonContextCancelled:
+		return "context cancelled"
+	case StopReasonActionGaveUp:
+		return "action gave up"
+	case StopReasonSetupFailed:
+		return "setup failed"
+	case StopReasonDepositNotFound:
+		return "deposit not found"
+	case StopReasonActionFailedPermanently:
+		return "action failed permanently"
+	default:
+		return "unknown"
+	}
+}
+
+// monitoringStoppedHandler is a callback invoked when a deposit monitoring
+// stops.
+type monitoringStoppedHandler func(depositAddress string, reason StopReason)
+
+type monitoringStoppedHandlers struct {
+	mutex    sync.RWMutex
+	handlers map[int]monitoringStoppedHandler
+}
+
+func newMonitoringStoppedHandlers() *monitoringStoppedHandlers {
+	return &monitoringStoppedHandlers{
+		handlers: make(map[int]monitoringStoppedHandler),
+	}
+}
+
+func (msh *monitoringStoppedHandlers) register(
+	handler monitoringStoppedHandler,
+) subscription.EventSubscription {
+	msh.mutex.Lock()
+	defer msh.mutex.Unlock()
+
+	// #nosec G404 (insecure random number source (rand))
+	// Handler ID doesn't require secure randomness.
+	handlerID := rand.Int()
+	msh.handlers[handlerID] = handler
+
+	return subscription.NewEventSubscription(func() {
+		msh.mutex.Lock()
+		defer msh.mutex.Unlock()
+
+		delete(msh.handlers, handlerID)
+	})
+}
+
+func (msh *monitoringStoppedHandlers) notify(
+	depositAddress string,
+	reason StopReason,
+) {
+	msh.mutex.RLock()
+	defer msh.mutex.RUnlock()
+
+	for _, handler := range msh.handlers {
+		go handler(depositAddress, reason)
+	}
+}
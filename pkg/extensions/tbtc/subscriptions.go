@@ -0,0 +1,59 @@
+package tbtc
+
+import (
+	"sync"
+
+	"github.com/keep-network/keep-common/pkg/subscription"
+)
+
+// monitoringSubscriptions keeps the chain event subscriptions of monitorings
+// set up by the extension, keyed by the monitoring name, so they can be
+// cancelled all at once. Each subscription is unsubscribed at most once.
+type monitoringSubscriptions struct {
+	mutex         sync.Mutex
+	subscriptions map[string]subscription.EventSubscription
+}
+
+func newMonitoringSubscriptions() *monitoringSubscriptions {
+	return &monitoringSubscriptions{
+		subscriptions: make(map[string]subscription.EventSubscription),
+	}
+}
+
+func (ms *monitoringSubscriptions) add(
+	monitoringName string,
+	monitoringSubscription subscription.EventSubscription,
+) {
+	ms.mutex.Lock()
+	defer ms.mutex.Unlock()
+
+	ms.subscriptions[monitoringName] = monitoringSubscription
+}
+
+// unsubscribe cancels the subscription of the given monitoring. It returns
+// false if the subscription has been already cancelled.
+func (ms *monitoringSubscriptions) unsubscribe(monitoringName string) bool {
+	ms.mutex.Lock()
+	monitoringSubscription, ok := ms.subscriptions[monitoringName]
+	delete(ms.subscriptions, monitoringName)
+	ms.mutex.Unlock()
+
+	if !ok {
+		return false
+	}
+
+	monitoringSubscription.Unsubscribe()
+	return true
+}
+
+// unsubscribeAll cancels subscriptions of all monitorings.
+func (ms *monitoringSubscriptions) unsubscribeAll() {
+	ms.mutex.Lock()
+	subscriptions := ms.subscriptions
+	ms.subscriptions = make(map[string]subscription.EventSubscription)
+	ms.mutex.Unlock()
+
+	for _, monitoringSubscription := range subscriptions {
+		monitoringSubscription.Unsubscribe()
+	}
+}
@@ -0,0 +1,72 @@
+package tbtc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/keep-network/keep-ecdsa/pkg/chain/local"
+)
+
+func TestUnsubscribeAll(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	tbtcChain := local.NewTBTCLocalChain(ctx)
+	tbtc := newTestTBTC(tbtcChain)
+
+	tbtc.monitorRetrievePubKey(
+		ctx,
+		constantBackoff,
+		timeout,
+		maxActAttempts,
+	)
+	tbtc.monitorProvideRedemptionSignature(
+		ctx,
+		constantBackoff,
+		timeout,
+		maxActAttempts,
+	)
+
+	handle := &Handle{tbtc: tbtc}
+	handle.UnsubscribeAll()
+
+	signers := append(
+		[]common.Address{tbtcChain.OperatorAddress()},
+		local.RandomSigningGroup(2)...,
+	)
+
+	tbtcChain.CreateDeposit(depositAddress, signers)
+
+	_, err := submitKeepPublicKey(depositAddress, tbtcChain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// wait a bit longer than the monitoring timeout
+	// to make sure the potential transaction completes
+	time.Sleep(2 * timeout)
+
+	if calls := tbtcChain.Logger().RetrieveSignerPubkeyCalls(); calls != 0 {
+		t.Errorf(
+			"unexpected number of RetrieveSignerPubkey calls\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			0,
+			calls,
+		)
+	}
+
+	if tbtc.monitoringLimiter.concurrency().Active != 0 {
+		t.Errorf("no deposit should be monitored after unsubscribing")
+	}
+
+	// Cancelling the context must not unsubscribe the monitorings again.
+	cancelCtx()
+
+	err = handle.Wait(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"math"
 	"math/big"
@@ -27,14 +28,19 @@ import (
 var logger = log.Logger("keep-tbtc-extension")
 
 const (
-	// Maximum number of action attempts before giving up and returning
-	// a monitoring error.
+	// Default maximum number of action attempts before giving up and
+	// returning a monitoring error.
 	maxActAttempts = 3
 
 	// Determines how many blocks from the past should be included
 	// during the past events lookup.
 	pastEventsLookbackBlocks = 10000
 
+	// Determines how many blocks from the past should be included during
+	// the lookup of deposits reconciled on startup. It is roughly the six
+	// months deposit term on Ethereum.
+	reconciliationLookbackBlocks = 1300000
+
 	// Number of blocks which should elapse before confirming
 	// the given chain state expectations.
 	defaultBlockConfirmations = 12
@@ -51,43 +57,154 @@ const (
 	// The timeout for confirming initial state of the deposit upon receiving
 	// start signal but before setting up monitoring.
 	confirmInitialStateTimeout = 30 * time.Second
+
+	// Time before the on-chain redemption proof timeout at which the
+	// redemption fee should be increased if the redemption proof has not
+	// been provided.
+	redemptionProofTimeoutMargin = 15 * time.Minute
+)
+
+const (
+	// RetrievePubKeyMonitoring is the name of the monitoring retrieving
+	// the signer public key of a deposit.
+	RetrievePubKeyMonitoring = "retrieve pubkey"
+	// ProvideRedemptionSignatureMonitoring is the name of the monitoring
+	// providing the redemption signature of a deposit.
+	ProvideRedemptionSignatureMonitoring = "provide redemption signature"
+	// ProvideRedemptionProofMonitoring is the name of the monitoring
+	// providing the redemption proof of a deposit.
+	ProvideRedemptionProofMonitoring = "provide redemption proof"
 )
 
+// MonitoringNames lists names of all monitorings run by the extension.
+var MonitoringNames = []string{
+	RetrievePubKeyMonitoring,
+	ProvideRedemptionSignatureMonitoring,
+	ProvideRedemptionProofMonitoring,
+}
+
+// Handle represents a handle to the TBTC extension.
+type Handle struct {
+	tbtc *tbtc
+}
+
+// Metrics returns action counters of the deposit monitorings run by the
+// extension.
+func (h *Handle) Metrics() *Metrics {
+	return h.tbtc.metrics
+}
+
+// MonitoringConcurrency returns the number of deposits currently monitored
+// by the extension along with the configured limit.
+func (h *Handle) MonitoringConcurrency() MonitoringConcurrency {
+	return h.tbtc.monitoringLimiter.concurrency()
+}
+
+// OnMonitoringStopped registers a callback invoked every time a deposit
+// monitoring stops, along with the reason of the stop.
+func (h *Handle) OnMonitoringStopped(
+	handler func(depositAddress string, reason StopReason),
+) subscription.EventSubscription {
+	return h.tbtc.monitoringStoppedHandlers.register(handler)
+}
+
+// CircuitBreakers returns the circuit breaker states of all monitorings
+// currently running for the given deposit, keyed by the monitoring name.
+func (h *Handle) CircuitBreakers(
+	depositAddress string,
+) map[string]CircuitBreakerState {
+	return h.tbtc.circuitBreakers.states(depositAddress)
+}
+
+// Wait blocks until all deposit monitorings have exited after the context
+// the extension has been initialized with is done. If the given context is
+// done before, the context error is returned. It lets the caller shut down
+// cleanly, without leaving transactions in the middle of submission.
+func (h *Handle) Wait(ctx context.Context) error {
+	return h.tbtc.monitoringRoutines.wait(ctx)
+}
+
+// UnsubscribeAll cancels chain event subscriptions of all monitorings set up
+// by the extension so no new deposit monitoring is started. Monitorings
+// already running are not affected.
+func (h *Handle) UnsubscribeAll() {
+	h.tbtc.monitoringSubscriptions.unsubscribeAll()
+}
+
+// StopMonitoringDeposit stops all monitorings currently running for the
+// given deposit. Monitorings of other deposits are not affected. If the
+// deposit is not monitored, this function is a no-op. The deposit can be
+// monitored again once a new monitoring start event is received.
+func (h *Handle) StopMonitoringDeposit(depositAddress string) {
+	h.tbtc.stopMonitoringDeposit(depositAddress)
+}
+
 // Initialize initializes extension specific to the TBTC application.
-// TODO: Resume monitoring after client restart
+// At most maxConcurrentMonitorings deposits are monitored at once; monitoring
+// of other deposits waits until a slot is released. Zero means there is
+// no limit. Once the monitorings are set up, existing deposits are reconciled
+// and monitorings are resumed for deposits awaiting an action, for example
+// those whose redemption has been requested while the client was down.
+// Only actions requested at or after reconciliationStartBlock are resumed.
+// Once the action of a deposit monitoring fails circuitBreakerThreshold times
+// in a row, it is suspended for circuitBreakerCooldown. Zero threshold
+// disables the circuit breaker.
 func Initialize(
 	ctx context.Context,
 	tbtcHandle chain.TBTCHandle,
 	blockCounter corechain.BlockCounter,
 	blockTimestamp func(blockNumber *big.Int) (uint64, error),
-) {
+	maxConcurrentMonitorings int,
+	reconciliationStartBlock uint64,
+	circuitBreakerThreshold int,
+	circuitBreakerCooldown time.Duration,
+) *Handle {
 	logger.Infof("initializing tbtc extension")
 
 	tbtc := newTBTC(
 		tbtcHandle,
 		blockCounter,
 		blockTimestamp,
+		maxConcurrentMonitorings,
 	)
+	tbtc.circuitBreakerThreshold = circuitBreakerThreshold
+	tbtc.circuitBreakerCooldown = circuitBreakerCooldown
 
 	tbtc.monitorRetrievePubKey(
 		ctx,
 		exponentialBackoff,
 		165*time.Minute, // 15 minutes before the 3 hours on-chain timeout
+		maxActAttempts,
 	)
 
 	tbtc.monitorProvideRedemptionSignature(
 		ctx,
 		exponentialBackoff,
 		105*time.Minute, // 15 minutes before the 2 hours on-chain timeout
+		maxActAttempts,
 	)
 
 	tbtc.monitorProvideRedemptionProof(
 		ctx,
 		exponentialBackoff,
-		345*time.Minute, // 15 minutes before the 6 hours on-chain timeout
+		// 15 minutes before the 6 hours on-chain timeout; used only if
+		// the timeout can't be determined from the chain
+		345*time.Minute,
+		maxActAttempts,
 	)
 
+	tbtc.monitoringRoutines.run(func() {
+		err := tbtc.reconcileExistingDeposits(reconciliationStartBlock)
+		if err != nil {
+			logger.Errorf("could not reconcile existing deposits: [%v]", err)
+		}
+	})
+
 	logger.Infof("tbtc extension has been initialized")
+
+	return &Handle{
+		tbtc: tbtc,
+	}
 }
 
 type tbtc struct {
@@ -96,26 +213,58 @@ type tbtc struct {
 	blockTimestamp func(blockNumber *big.Int) (uint64, error)
 
 	monitoringLocks        sync.Map
+	monitoringCancelsMutex sync.Mutex
+	monitoringCancels      map[string]map[string]context.CancelFunc
 	blockConfirmations     uint64
 	memberDepositsCache    *cache.TimeCache
 	notMemberDepositsCache *cache.TimeCache
 	signerActionDelayStep  time.Duration
+	metrics                *Metrics
+	monitoringLimiter      *depositMonitoringLimiter
+	// Number of consecutive action failures opening the circuit breaker of
+	// a deposit monitoring. Zero disables the circuit breaker.
+	circuitBreakerThreshold int
+	circuitBreakerCooldown  time.Duration
+	// Decides whether a failed monitoring action should be retried.
+	classifyError errorClassifierFn
+
+	redemptionSignatureActions *inFlightActions
+	monitoringStoppedHandlers  *monitoringStoppedHandlers
+	circuitBreakers            *circuitBreakers
+	monitoringRoutines         *monitoringRoutines
+	monitoringSubscriptions    *monitoringSubscriptions
+
+	monitoringResumersMutex sync.Mutex
+	monitoringResumers      map[chain.DepositState]depositEventHandler
 }
 
 func newTBTC(
 	tbtcHandle chain.TBTCHandle,
 	blockCounter corechain.BlockCounter,
 	blockTimestamp func(blockNumber *big.Int) (uint64, error),
+	maxConcurrentMonitorings int,
 ) *tbtc {
 	return &tbtc{
 		handle:         tbtcHandle,
 		blockCounter:   blockCounter,
 		blockTimestamp: blockTimestamp,
 
+		monitoringCancels:      make(map[string]map[string]context.CancelFunc),
 		blockConfirmations:     defaultBlockConfirmations,
 		memberDepositsCache:    cache.NewTimeCache(monitoringCachePeriod),
 		notMemberDepositsCache: cache.NewTimeCache(monitoringCachePeriod),
 		signerActionDelayStep:  defaultSignerActionDelayStep,
+		metrics:                newMetrics(),
+		monitoringLimiter:      newDepositMonitoringLimiter(maxConcurrentMonitorings),
+		classifyError:          defaultClassifyError,
+
+		redemptionSignatureActions: newInFlightActions(),
+		monitoringStoppedHandlers:  newMonitoringStoppedHandlers(),
+		circuitBreakers:            newCircuitBreakers(),
+		monitoringRoutines:         newMonitoringRoutines(),
+		monitoringSubscriptions:    newMonitoringSubscriptions(),
+
+		monitoringResumers: make(map[chain.DepositState]depositEventHandler),
 	}
 }
 
@@ -123,12 +272,16 @@ func (t *tbtc) monitorRetrievePubKey(
 	ctx context.Context,
 	actBackoffFn backoffFn,
 	timeout time.Duration,
+	maxAttempts int,
 ) {
+	monitoringName := RetrievePubKeyMonitoring
 	initialDepositState := chain.AwaitingSignerSetup
 
 	monitoringStartFn := func(
 		handler depositEventHandler,
 	) subscription.EventSubscription {
+		t.registerMonitoringResumer(initialDepositState, handler)
+
 		return t.handle.OnDepositCreated(handler)
 	}
 
@@ -150,17 +303,21 @@ func (t *tbtc) monitorRetrievePubKey(
 			) {
 				handler(depositAddress)
 			} else {
-				logger.Warningf(
-					"retrieve pubkey monitoring stop event for "+
-						"deposit [%v] is not confirmed; "+
+				newMonitoringLog(
+					logger,
+					monitoringLogFields{
+						monitorType:    monitoringName,
+						depositAddress: depositAddress,
+					},
+				).Warningf(
+					"monitoring stop event is not confirmed; " +
 						"monitoring will be continued",
-					depositAddress,
 				)
 			}
 		})
 	}
 
-	actFn := func(depositAddress string) error {
+	actFn := func(_ context.Context, depositAddress string) error {
 		err := t.handle.RetrieveSignerPubkey(depositAddress)
 		if err != nil {
 			return err
@@ -187,21 +344,26 @@ func (t *tbtc) monitorRetrievePubKey(
 
 	monitoringSubscription := t.monitorAndAct(
 		ctx,
-		"retrieve pubkey",
+		monitoringName,
 		shouldMonitorFn,
 		monitoringStartFn,
 		monitoringStopFn,
 		t.watchKeepClosed,
 		actFn,
+		t.depositStateChanged(initialDepositState),
 		actBackoffFn,
+		maxAttempts,
 		timeoutFn,
 	)
 
-	go func() {
+	t.monitoringSubscriptions.add(monitoringName, monitoringSubscription)
+
+	t.monitoringRoutines.run(func() {
 		<-ctx.Done()
-		monitoringSubscription.Unsubscribe()
-		logger.Infof("retrieve pubkey monitoring disabled")
-	}()
+		if t.monitoringSubscriptions.unsubscribe(monitoringName) {
+			logger.Infof("retrieve pubkey monitoring disabled")
+		}
+	})
 
 	logger.Infof("retrieve pubkey monitoring initialized")
 }
@@ -210,12 +372,16 @@ func (t *tbtc) monitorProvideRedemptionSignature(
 	ctx context.Context,
 	actBackoffFn backoffFn,
 	timeout time.Duration,
+	maxAttempts int,
 ) {
+	monitoringName := ProvideRedemptionSignatureMonitoring
 	initialDepositState := chain.AwaitingWithdrawalSignature
 
 	monitoringStartFn := func(
 		handler depositEventHandler,
 	) subscription.EventSubscription {
+		t.registerMonitoringResumer(initialDepositState, handler)
+
 		// Start right after a redemption has been requested or the redemption
 		// fee has been increased.
 		return t.handle.OnDepositRedemptionRequested(handler)
@@ -241,11 +407,15 @@ func (t *tbtc) monitorProvideRedemptionSignature(
 				) {
 					handler(depositAddress)
 				} else {
-					logger.Warningf(
-						"provide redemption signature monitoring stop "+
-							"event for deposit [%v] is not confirmed; "+
+					newMonitoringLog(
+						logger,
+						monitoringLogFields{
+							monitorType:    monitoringName,
+							depositAddress: depositAddress,
+						},
+					).Warningf(
+						"monitoring stop event is not confirmed; " +
 							"monitoring will be continued",
-						depositAddress,
 					)
 				}
 			},
@@ -260,11 +430,15 @@ func (t *tbtc) monitorProvideRedemptionSignature(
 				) {
 					handler(depositAddress)
 				} else {
-					logger.Warningf(
-						"provide redemption signature monitoring stop "+
-							"event for deposit [%v] is not confirmed; "+
+					newMonitoringLog(
+						logger,
+						monitoringLogFields{
+							monitorType:    monitoringName,
+							depositAddress: depositAddress,
+						},
+					).Warningf(
+						"monitoring stop event is not confirmed; " +
 							"monitoring will be continued",
-						depositAddress,
 					)
 				}
 			},
@@ -278,77 +452,8 @@ func (t *tbtc) monitorProvideRedemptionSignature(
 		)
 	}
 
-	actFn := func(depositAddress string) error {
-		keep, err := t.handle.Keep(depositAddress)
-		if err != nil {
-			return err
-		}
-
-		redemptionRequestedEvents, err := t.handle.PastDepositRedemptionRequestedEvents(
-			t.pastEventsLookupStartBlock(),
-			depositAddress,
-		)
-		if err != nil {
-			return err
-		}
-
-		if len(redemptionRequestedEvents) == 0 {
-			return fmt.Errorf(
-				"no redemption requested events found for deposit: [%v]",
-				depositAddress,
-			)
-		}
-
-		latestRedemptionRequestedEvent :=
-			redemptionRequestedEvents[len(redemptionRequestedEvents)-1]
-
-		signatureSubmittedEvents, err := keep.PastSignatureSubmittedEvents(
-			latestRedemptionRequestedEvent.BlockNumber,
-		)
-		if err != nil {
-			return err
-		}
-
-		if len(signatureSubmittedEvents) == 0 {
-			return fmt.Errorf(
-				"no signature submitted events found for deposit: [%v]",
-				depositAddress,
-			)
-		}
-
-		latestSignatureSubmittedEvent :=
-			signatureSubmittedEvents[len(signatureSubmittedEvents)-1]
-
-		depositDigest := latestRedemptionRequestedEvent.Digest
-
-		if !bytes.Equal(latestSignatureSubmittedEvent.Digest[:], depositDigest[:]) {
-			return fmt.Errorf(
-				"could not find signature for digest: [%v]",
-				depositDigest,
-			)
-		}
-
-		// We add 27 to the recovery ID to align it with ethereum and
-		// bitcoin protocols where 27 is added to recovery ID to
-		// indicate usage of uncompressed public keys.
-		err = t.handle.ProvideRedemptionSignature(
-			depositAddress,
-			27+latestSignatureSubmittedEvent.RecoveryID,
-			latestSignatureSubmittedEvent.R,
-			latestSignatureSubmittedEvent.S,
-		)
-		if err != nil {
-			return err
-		}
-
-		if !t.waitDepositStateChangeConfirmation(
-			depositAddress,
-			initialDepositState,
-		) {
-			return fmt.Errorf("deposit state change is not confirmed")
-		}
-
-		return nil
+	actFn := func(_ context.Context, depositAddress string) error {
+		return t.provideRedemptionSignature(depositAddress)
 	}
 
 	timeoutFn := func(depositAddress string) (time.Duration, error) {
@@ -362,21 +467,26 @@ func (t *tbtc) monitorProvideRedemptionSignature(
 
 	monitoringSubscription := t.monitorAndAct(
 		ctx,
-		"provide redemption signature",
+		monitoringName,
 		shouldMonitorFn,
 		monitoringStartFn,
 		monitoringStopFn,
 		t.watchKeepClosed,
 		actFn,
+		t.depositStateChanged(initialDepositState),
 		actBackoffFn,
+		maxAttempts,
 		timeoutFn,
 	)
 
-	go func() {
+	t.monitoringSubscriptions.add(monitoringName, monitoringSubscription)
+
+	t.monitoringRoutines.run(func() {
 		<-ctx.Done()
-		monitoringSubscription.Unsubscribe()
-		logger.Infof("provide redemption signature monitoring disabled")
-	}()
+		if t.monitoringSubscriptions.unsubscribe(monitoringName) {
+			logger.Infof("provide redemption signature monitoring disabled")
+		}
+	})
 
 	logger.Infof("provide redemption signature monitoring initialized")
 }
@@ -385,12 +495,16 @@ func (t *tbtc) monitorProvideRedemptionProof(
 	ctx context.Context,
 	actBackoffFn backoffFn,
 	timeout time.Duration,
+	maxAttempts int,
 ) {
+	monitoringName := ProvideRedemptionProofMonitoring
 	initialDepositState := chain.AwaitingWithdrawalProof
 
 	monitoringStartFn := func(
 		handler depositEventHandler,
 	) subscription.EventSubscription {
+		t.registerMonitoringResumer(initialDepositState, handler)
+
 		// Start right after a redemption signature has been provided.
 		return t.handle.OnDepositGotRedemptionSignature(handler)
 	}
@@ -415,11 +529,15 @@ func (t *tbtc) monitorProvideRedemptionProof(
 				) {
 					handler(depositAddress)
 				} else {
-					logger.Warningf(
-						"provide redemption proof monitoring stop "+
-							"event for deposit [%v] is not confirmed; "+
+					newMonitoringLog(
+						logger,
+						monitoringLogFields{
+							monitorType:    monitoringName,
+							depositAddress: depositAddress,
+						},
+					).Warningf(
+						"monitoring stop event is not confirmed; " +
 							"monitoring will be continued",
-						depositAddress,
 					)
 				}
 			},
@@ -434,11 +552,15 @@ func (t *tbtc) monitorProvideRedemptionProof(
 				) {
 					handler(depositAddress)
 				} else {
-					logger.Warningf(
-						"provide redemption proof monitoring stop "+
-							"event for deposit [%v] is not confirmed; "+
+					newMonitoringLog(
+						logger,
+						monitoringLogFields{
+							monitorType:    monitoringName,
+							depositAddress: depositAddress,
+						},
+					).Warningf(
+						"monitoring stop event is not confirmed; " +
 							"monitoring will be continued",
-						depositAddress,
 					)
 				}
 			},
@@ -452,7 +574,7 @@ func (t *tbtc) monitorProvideRedemptionProof(
 		)
 	}
 
-	actFn := func(depositAddress string) error {
+	actFn := func(_ context.Context, depositAddress string) error {
 		redemptionRequestedEvents, err := t.handle.PastDepositRedemptionRequestedEvents(
 			t.pastEventsLookupStartBlock(),
 			depositAddress,
@@ -525,69 +647,167 @@ func (t *tbtc) monitorProvideRedemptionProof(
 		// the `GotRedemptionSignature` event.
 		gotRedemptionSignatureTimestamp := uint64(time.Now().Unix())
 
-		redemptionRequestedEvents, err := t.handle.PastDepositRedemptionRequestedEvents(
-			t.pastEventsLookupStartBlock(),
-			depositAddress,
-		)
+		actionDelay, err := t.getSignerActionDelay(depositAddress)
 		if err != nil {
 			return 0, err
 		}
 
-		if len(redemptionRequestedEvents) == 0 {
-			return 0, fmt.Errorf(
-				"no redemption requested events found for deposit: [%v]",
+		redemptionRequestedTimestamp, err := t.latestRedemptionRequestedTimestamp(
+			depositAddress,
+		)
+		if err != nil {
+			logger.Warningf(
+				"could not determine redemption request timestamp "+
+					"for deposit [%v]: [%v]; falling back to "+
+					"the static timeout [%v]",
 				depositAddress,
+				err,
+				timeout,
 			)
+			return timeout + actionDelay, nil
 		}
 
-		latestRedemptionRequestedEvent :=
-			redemptionRequestedEvents[len(redemptionRequestedEvents)-1]
-
-		// Get the seconds timestamp for the latest redemption request.
-		redemptionRequestedTimestamp, err := t.blockTimestamp(
-			new(big.Int).SetUint64(latestRedemptionRequestedEvent.BlockNumber),
-		)
+		redemptionProofTimeout, err := t.handle.RedemptionProofTimeout()
 		if err != nil {
-			return 0, err
+			logger.Warningf(
+				"could not determine on-chain redemption proof timeout "+
+					"for deposit [%v]: [%v]; falling back to "+
+					"the static timeout [%v]",
+				depositAddress,
+				err,
+				timeout,
+			)
+			return timeout + actionDelay, nil
 		}
 
-		// We must shift the constant timeout value by subtracting the time
-		// elapsed between the redemption request and the redemption signature.
-		// This way we obtain a value close to the redemption proof timeout
-		// and it doesn't matter when the redemption signature arrives.
+		// Fee increase is aligned with the on-chain redemption proof
+		// timeout counted from the redemption request.
+		deadline := redemptionProofTimeout - redemptionProofTimeoutMargin
+
+		// We must shift the deadline by subtracting the time elapsed between
+		// the redemption request and the redemption signature. This way we
+		// obtain a value close to the redemption proof timeout and it doesn't
+		// matter when the redemption signature arrives.
 		timeoutShift := time.Duration(
 			gotRedemptionSignatureTimestamp-redemptionRequestedTimestamp,
 		) * time.Second
 
-		actionDelay, err := t.getSignerActionDelay(depositAddress)
-		if err != nil {
-			return 0, err
-		}
-
-		return (timeout - timeoutShift) + actionDelay, nil
+		return (deadline - timeoutShift) + actionDelay, nil
 	}
 
 	monitoringSubscription := t.monitorAndAct(
 		ctx,
-		"provide redemption proof",
+		monitoringName,
 		shouldMonitorFn,
 		monitoringStartFn,
 		monitoringStopFn,
 		t.watchKeepClosed,
 		actFn,
+		t.depositStateChanged(initialDepositState),
 		actBackoffFn,
+		maxAttempts,
 		timeoutFn,
 	)
 
-	go func() {
+	t.monitoringSubscriptions.add(monitoringName, monitoringSubscription)
+
+	t.monitoringRoutines.run(func() {
 		<-ctx.Done()
-		monitoringSubscription.Unsubscribe()
-		logger.Infof("provide redemption proof monitoring disabled")
-	}()
+		if t.monitoringSubscriptions.unsubscribe(monitoringName) {
+			logger.Infof("provide redemption proof monitoring disabled")
+		}
+	})
 
 	logger.Infof("provide redemption proof monitoring initialized")
 }
 
+// provideRedemptionSignature provides the redemption signature for the given
+// deposit. Only one attempt runs at a time for the deposit; attempts triggered
+// while another one is in flight share its result.
+func (t *tbtc) provideRedemptionSignature(depositAddress string) error {
+	shared, err := t.redemptionSignatureActions.do(depositAddress, func() error {
+		keep, err := t.handle.Keep(depositAddress)
+		if err != nil {
+			return err
+		}
+
+		redemptionRequestedEvents, err := t.handle.PastDepositRedemptionRequestedEvents(
+			t.pastEventsLookupStartBlock(),
+			depositAddress,
+		)
+		if err != nil {
+			return err
+		}
+
+		if len(redemptionRequestedEvents) == 0 {
+			return fmt.Errorf(
+				"no redemption requested events found for deposit: [%v]",
+				depositAddress,
+			)
+		}
+
+		latestRedemptionRequestedEvent :=
+			redemptionRequestedEvents[len(redemptionRequestedEvents)-1]
+
+		signatureSubmittedEvents, err := keep.PastSignatureSubmittedEvents(
+			latestRedemptionRequestedEvent.BlockNumber,
+		)
+		if err != nil {
+			return err
+		}
+
+		if len(signatureSubmittedEvents) == 0 {
+			return fmt.Errorf(
+				"no signature submitted events found for deposit: [%v]",
+				depositAddress,
+			)
+		}
+
+		latestSignatureSubmittedEvent :=
+			signatureSubmittedEvents[len(signatureSubmittedEvents)-1]
+
+		depositDigest := latestRedemptionRequestedEvent.Digest
+
+		if !bytes.Equal(latestSignatureSubmittedEvent.Digest[:], depositDigest[:]) {
+			return fmt.Errorf(
+				"could not find signature for digest: [%v]",
+				depositDigest,
+			)
+		}
+
+		// We add 27 to the recovery ID to align it with ethereum and
+		// bitcoin protocols where 27 is added to recovery ID to
+		// indicate usage of uncompressed public keys.
+		err = t.handle.ProvideRedemptionSignature(
+			depositAddress,
+			27+latestSignatureSubmittedEvent.RecoveryID,
+			latestSignatureSubmittedEvent.R,
+			latestSignatureSubmittedEvent.S,
+		)
+		if err != nil {
+			return err
+		}
+
+		if !t.waitDepositStateChangeConfirmation(
+			depositAddress,
+			chain.AwaitingWithdrawalSignature,
+		) {
+			return fmt.Errorf("deposit state change is not confirmed")
+		}
+
+		return nil
+	})
+	if shared {
+		logger.Infof(
+			"provide redemption signature attempt for deposit [%v] "+
+				"coalesced with the one already in flight",
+			depositAddress,
+		)
+	}
+
+	return err
+}
+
 type shouldMonitorDepositFn func(depositAddress string) bool
 
 type depositEventHandler func(depositAddress string)
@@ -597,12 +817,14 @@ type watchDepositEventFn func(
 ) subscription.EventSubscription
 
 type watchKeepClosedFn func(depositAddress string) (
-	keepClosedChan chan struct{},
+	keepClosedChan chan StopReason,
 	unsubscribe func(),
 	err error,
 )
 
-type submitDepositTxFn func(depositAddress string) error
+type depositActionFn func(ctx context.Context, depositAddress string) error
+
+type shouldStopMonitoringFn func(depositAddress string) (bool, error)
 
 type backoffFn func(iteration int) time.Duration
 
@@ -615,10 +837,14 @@ func (t *tbtc) monitorAndAct(
 	monitoringStartFn watchDepositEventFn,
 	monitoringStopFn watchDepositEventFn,
 	keepClosedFn watchKeepClosedFn,
-	actFn submitDepositTxFn,
+	actFn depositActionFn,
+	shouldStopFn shouldStopMonitoringFn,
 	actBackoffFn backoffFn,
+	maxAttempts int,
 	timeoutFn timeoutFn,
 ) subscription.EventSubscription {
+	monitoringMetrics := t.metrics.monitoring(monitoringName)
+
 	handleStartEvent := func(depositAddress string) {
 		if !shouldMonitorFn(depositAddress) {
 			return
@@ -634,12 +860,39 @@ func (t *tbtc) monitorAndAct(
 		}
 		defer t.releaseMonitoringLock(depositAddress, monitoringName)
 
-		logger.Infof(
-			"starting [%v] monitoring for deposit [%v]",
-			monitoringName,
-			depositAddress,
+		monitoringCtx, cancelMonitoring := context.WithCancel(ctx)
+		t.registerMonitoringCancel(depositAddress, monitoringName, cancelMonitoring)
+		defer func() {
+			t.unregisterMonitoringCancel(depositAddress, monitoringName)
+			cancelMonitoring()
+		}()
+
+		if !t.monitoringLimiter.acquire(monitoringCtx, depositAddress) {
+			logger.Infof(
+				"context is done for [%v] monitoring for deposit [%v] "+
+					"waiting for a free monitoring slot",
+				monitoringName,
+				depositAddress,
+			)
+			t.monitoringStoppedHandlers.notify(
+				depositAddress,
+				StopReasonContextCancelled,
+			)
+			return
+		}
+		defer t.monitoringLimiter.release(depositAddress)
+
+		depositLog := newMonitoringLog(
+			logger,
+			monitoringLogFields{
+				monitorType:    monitoringName,
+				depositAddress: depositAddress,
+				keepAddress:    t.keepAddress(depositAddress),
+			},
 		)
 
+		depositLog.Infof("starting deposit monitoring")
+
 		stopEventChan := make(chan struct{})
 
 		stopEventSubscription := monitoringStopFn(
@@ -655,12 +908,10 @@ func (t *tbtc) monitorAndAct(
 			depositAddress,
 		)
 		if err != nil {
-			logger.Errorf(
-				"could not setup keep closed handler for [%v] "+
-					"monitoring for deposit [%v]: [%v]",
-				monitoringName,
+			depositLog.Errorf("could not setup keep closed handler: [%v]", err)
+			t.monitoringStoppedHandlers.notify(
 				depositAddress,
-				err,
+				StopReasonSetupFailed,
 			)
 			return
 		}
@@ -668,107 +919,210 @@ func (t *tbtc) monitorAndAct(
 
 		timeout, err := timeoutFn(depositAddress)
 		if err != nil {
-			logger.Errorf(
-				"could determine timeout value for [%v] "+
-					"monitoring for deposit [%v]: [%v]",
-				monitoringName,
+			depositLog.Errorf("could not determine timeout value: [%v]", err)
+			t.monitoringStoppedHandlers.notify(
 				depositAddress,
-				err,
+				StopReasonSetupFailed,
 			)
 			return
 		}
 
-		timeoutChan := time.After(timeout)
+		breaker := newActionCircuitBreaker(
+			t.circuitBreakerThreshold,
+			t.circuitBreakerCooldown,
+		)
+		t.circuitBreakers.register(depositAddress, monitoringName, breaker)
+		defer t.circuitBreakers.unregister(depositAddress, monitoringName)
 
-		actionAttempt := 1
+		stopReason := monitorDeposit(
+			monitoringCtx,
+			depositLog,
+			depositAddress,
+			stopEventChan,
+			keepClosedChan,
+			timeout,
+			actFn,
+			func() (bool, error) {
+				return shouldStopFn(depositAddress)
+			},
+			actBackoffFn,
+			maxAttempts,
+			breaker,
+			t.classifyError,
+			monitoringMetrics,
+		)
 
-	monitoring:
-		for {
-			select {
-			case <-ctx.Done():
-				logger.Infof(
-					"context is done for [%v] "+
-						"monitoring for deposit [%v]",
-					monitoringName,
-					depositAddress,
-				)
-				break monitoring
-			case <-stopEventChan:
-				logger.Infof(
-					"stop event occurred for [%v] "+
-						"monitoring for deposit [%v]",
-					monitoringName,
-					depositAddress,
-				)
-				break monitoring
-			case <-keepClosedChan:
-				logger.Infof(
-					"keep closed event occurred for [%v] "+
-						"monitoring for deposit [%v]",
-					monitoringName,
-					depositAddress,
+		depositLog.Infof("stopped deposit monitoring; reason: [%v]", stopReason)
+
+		t.monitoringStoppedHandlers.notify(depositAddress, stopReason)
+	}
+
+	return monitoringStartFn(
+		func(depositAddress string) {
+			t.monitoringRoutines.run(func() {
+				handleStartEvent(depositAddress)
+			})
+		},
+	)
+}
+
+// monitorDeposit runs the monitoring control flow for a single deposit. Once
+// the timeout elapses, the action is performed unless the monitoring has been
+// stopped before or shouldStop reports the action is no longer needed.
+// A failed action is retried with backoff until maxAttempts is reached. Zero
+// maxAttempts means the action is retried until the monitoring is stopped.
+// Once the action fails the breaker threshold number of times in a row, it is
+// suspended for the breaker cooldown period instead of the backoff. A nil
+// breaker never suspends the action. The action is not retried if the
+// deposit does not exist or classifyError reports the error is not retryable.
+// Messages are written to the given monitoring log. The reason the monitoring
+// has stopped is returned.
+func monitorDeposit(
+	ctx context.Context,
+	depositLog *monitoringLog,
+	depositAddress string,
+	stopEventChan <-chan struct{},
+	keepClosedChan <-chan StopReason,
+	timeout time.Duration,
+	action depositActionFn,
+	shouldStop func() (bool, error),
+	actBackoffFn backoffFn,
+	maxAttempts int,
+	breaker *actionCircuitBreaker,
+	classifyError errorClassifierFn,
+	monitoringMetrics *monitoringCounters,
+) StopReason {
+	timeoutChan := time.After(timeout)
+
+	actionAttempt := 1
+
+	for {
+		select {
+		case <-ctx.Done():
+			depositLog.Infof("context is done for deposit monitoring")
+			return StopReasonContextCancelled
+		case <-stopEventChan:
+			depositLog.Infof("stop event occurred for deposit monitoring")
+			return StopReasonStopEvent
+		case keepStopReason := <-keepClosedChan:
+			depositLog.Infof(
+				"[%v] event occurred for deposit monitoring",
+				keepStopReason,
+			)
+			return keepStopReason
+		case <-timeoutChan:
+			attemptLog := depositLog.withAttempt(actionAttempt)
+
+			if breaker.cooldownElapsed() {
+				attemptLog.Infof(
+					"circuit breaker cooldown elapsed; " +
+						"moving circuit breaker to half-open state",
 				)
-				break monitoring
-			case <-timeoutChan:
-				logger.Infof(
-					"[%v] not performed in the expected time frame "+
-						"for deposit [%v]; performing the action",
-					monitoringName,
-					depositAddress,
+			}
+
+			attemptLog.Infof(
+				"monitored action not performed in the expected " +
+					"time frame; performing the action",
+			)
+
+			stop, err := shouldStop()
+			if err != nil {
+				attemptLog.Warningf(
+					"could not check if deposit monitoring should be "+
+						"stopped: [%v]; performing the action",
+					err,
 				)
+			} else if stop {
+				attemptLog.Infof("monitored action is no longer needed")
+				return StopReasonActionNotNeeded
+			}
+
+			monitoringMetrics.recordAttempt()
 
-				err := actFn(depositAddress)
-				if err != nil {
-					if actionAttempt == maxActAttempts {
-						logger.Errorf(
-							"could not perform action "+
-								"for [%v] monitoring for deposit [%v]: [%v]; "+
-								"the maximum number of attempts reached",
-							monitoringName,
-							depositAddress,
-							err,
-						)
-						break monitoring
-					}
-
-					backoff := actBackoffFn(actionAttempt)
-
-					logger.Errorf(
-						"could not perform action "+
-							"for [%v] monitoring for deposit [%v]: [%v]; "+
-							"retrying after: [%v]",
-						monitoringName,
-						depositAddress,
+			err = action(ctx, depositAddress)
+			if err != nil {
+				monitoringMetrics.recordFailure()
+
+				if errors.Is(err, chain.ErrDepositNotFound) {
+					attemptLog.Errorf(
+						"could not perform monitored action: [%v]; "+
+							"deposit does not exist; giving up",
+						err,
+					)
+					return StopReasonDepositNotFound
+				}
+
+				if !classifyError(err) {
+					attemptLog.Errorf(
+						"could not perform monitored action: [%v]; "+
+							"the error is not retryable; giving up",
+						err,
+					)
+					return StopReasonActionFailedPermanently
+				}
+
+				if maxAttempts > 0 && actionAttempt >= maxAttempts {
+					attemptLog.Errorf(
+						"could not perform monitored action: [%v]; "+
+							"the maximum number of attempts reached",
+						err,
+					)
+					return StopReasonActionGaveUp
+				}
+
+				if breaker.recordFailure() {
+					attemptLog.Errorf(
+						"could not perform monitored action: [%v]; "+
+							"circuit breaker opened; retrying after: [%v]",
 						err,
-						backoff,
+						breaker.cooldown,
 					)
 
-					timeoutChan = time.After(backoff)
+					timeoutChan = time.After(breaker.cooldown)
 					actionAttempt++
-				} else {
-					break monitoring
+					continue
 				}
+
+				backoff := actBackoffFn(actionAttempt)
+
+				attemptLog.Errorf(
+					"could not perform monitored action: [%v]; "+
+						"retrying after: [%v]",
+					err,
+					backoff,
+				)
+
+				timeoutChan = time.After(backoff)
+				actionAttempt++
+			} else {
+				breaker.recordSuccess()
+				monitoringMetrics.recordSuccess()
+				return StopReasonActionPerformed
 			}
 		}
-
-		logger.Infof(
-			"stopped [%v] monitoring for deposit [%v]",
-			monitoringName,
-			depositAddress,
-		)
 	}
+}
 
-	return monitoringStartFn(
-		func(depositAddress string) {
-			go handleStartEvent(depositAddress)
-		},
-	)
+// depositStateChanged returns a function reporting whether the deposit is
+// no longer in the given initial state, in which case the monitoring action
+// is not needed anymore.
+func (t *tbtc) depositStateChanged(
+	initialDepositState chain.DepositState,
+) shouldStopMonitoringFn {
+	return func(depositAddress string) (bool, error) {
+		currentState, err := t.handle.CurrentState(depositAddress)
+		if err != nil {
+			return false, err
+		}
+
+		return currentState != initialDepositState, nil
+	}
 }
 
 func (t *tbtc) watchKeepClosed(
 	depositAddress string,
-) (chan struct{}, func(), error) {
-	signalChan := make(chan struct{})
+) (chan StopReason, func(), error) {
+	signalChan := make(chan StopReason)
 
 	keep, err := t.handle.Keep(depositAddress)
 	if err != nil {
@@ -783,7 +1137,7 @@ func (t *tbtc) watchKeepClosed(
 			)
 
 			if t.waitKeepNotActiveConfirmation(keep) {
-				signalChan <- struct{}{}
+				signalChan <- StopReasonKeepClosed
 			}
 		},
 	)
@@ -799,7 +1153,7 @@ func (t *tbtc) watchKeepClosed(
 			)
 
 			if t.waitKeepNotActiveConfirmation(keep) {
-				signalChan <- struct{}{}
+				signalChan <- StopReasonKeepTerminated
 			}
 		},
 	)
@@ -878,6 +1232,18 @@ func (t *tbtc) shouldMonitorDeposit(
 	return true
 }
 
+// keepAddress returns the address of the keep backing the given deposit
+// for use in log messages. An empty string is returned if the keep could not
+// be resolved.
+func (t *tbtc) keepAddress(depositAddress string) string {
+	keep, err := t.handle.Keep(depositAddress)
+	if err != nil {
+		return ""
+	}
+
+	return keep.ID().String()
+}
+
 func (t *tbtc) getSignerIndex(depositAddress string) (int, error) {
 	keep, err := t.handle.Keep(depositAddress)
 	if err != nil {
@@ -981,6 +1347,34 @@ func (t *tbtc) waitKeepNotActiveConfirmation(
 	return !isKeepActive
 }
 
+// latestRedemptionRequestedTimestamp returns the seconds timestamp of the
+// latest redemption request of the given deposit.
+func (t *tbtc) latestRedemptionRequestedTimestamp(
+	depositAddress string,
+) (uint64, error) {
+	redemptionRequestedEvents, err := t.handle.PastDepositRedemptionRequestedEvents(
+		t.pastEventsLookupStartBlock(),
+		depositAddress,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(redemptionRequestedEvents) == 0 {
+		return 0, fmt.Errorf(
+			"no redemption requested events found for deposit: [%v]",
+			depositAddress,
+		)
+	}
+
+	latestRedemptionRequestedEvent :=
+		redemptionRequestedEvents[len(redemptionRequestedEvents)-1]
+
+	return t.blockTimestamp(
+		new(big.Int).SetUint64(latestRedemptionRequestedEvent.BlockNumber),
+	)
+}
+
 func (t *tbtc) pastEventsLookupStartBlock() uint64 {
 	currentBlock, err := t.blockCounter.CurrentBlock()
 	if err != nil {
@@ -1007,6 +1401,63 @@ func (t *tbtc) releaseMonitoringLock(depositAddress, monitoringName string) {
 	t.monitoringLocks.Delete(monitoringLockKey(depositAddress, monitoringName))
 }
 
+func (t *tbtc) registerMonitoringCancel(
+	depositAddress string,
+	monitoringName string,
+	cancel context.CancelFunc,
+) {
+	t.monitoringCancelsMutex.Lock()
+	defer t.monitoringCancelsMutex.Unlock()
+
+	depositCancels, ok := t.monitoringCancels[depositAddress]
+	if !ok {
+		depositCancels = make(map[string]context.CancelFunc)
+		t.monitoringCancels[depositAddress] = depositCancels
+	}
+
+	depositCancels[monitoringName] = cancel
+}
+
+func (t *tbtc) unregisterMonitoringCancel(
+	depositAddress string,
+	monitoringName string,
+) {
+	t.monitoringCancelsMutex.Lock()
+	defer t.monitoringCancelsMutex.Unlock()
+
+	depositCancels, ok := t.monitoringCancels[depositAddress]
+	if !ok {
+		return
+	}
+
+	delete(depositCancels, monitoringName)
+
+	if len(depositCancels) == 0 {
+		delete(t.monitoringCancels, depositAddress)
+	}
+}
+
+func (t *tbtc) stopMonitoringDeposit(depositAddress string) {
+	t.monitoringCancelsMutex.Lock()
+	defer t.monitoringCancelsMutex.Unlock()
+
+	depositCancels, ok := t.monitoringCancels[depositAddress]
+	if !ok {
+		return
+	}
+
+	for monitoringName, cancel := range depositCancels {
+		logger.Infof(
+			"stopping [%v] monitoring for deposit [%v] on demand",
+			monitoringName,
+			depositAddress,
+		)
+		cancel()
+	}
+
+	delete(t.monitoringCancels, depositAddress)
+}
+
 func monitoringLockKey(
 	depositAddress string,
 	monitoringName string,
@@ -34,6 +34,7 @@ func newTestTBTC(
 		localChain,
 		localChain.BlockCounter(),
 		localChain.BlockTimestamp,
+		0,
 	)
 
 	tbtc.blockConfirmations = defaultLocalBlockConfirmations
@@ -52,6 +53,7 @@ func TestRetrievePubkey_TimeoutElapsed(t *testing.T) {
 		ctx,
 		constantBackoff,
 		timeout,
+		maxActAttempts,
 	)
 
 	signers := append(
@@ -113,6 +115,7 @@ func TestRetrievePubkey_StopEventOccurred(t *testing.T) {
 		ctx,
 		constantBackoff,
 		timeout,
+		maxActAttempts,
 	)
 
 	signers := append(
@@ -184,6 +187,7 @@ func TestRetrievePubkey_KeepClosedEventOccurred(t *testing.T) {
 		ctx,
 		constantBackoff,
 		timeout,
+		maxActAttempts,
 	)
 
 	signers := append(
@@ -252,6 +256,7 @@ func TestRetrievePubkey_KeepTerminatedEventOccurred(t *testing.T) {
 		ctx,
 		constantBackoff,
 		timeout,
+		maxActAttempts,
 	)
 
 	signers := append(
@@ -320,6 +325,7 @@ func TestRetrievePubkey_ActionFailed(t *testing.T) {
 		ctx,
 		constantBackoff,
 		timeout,
+		maxActAttempts,
 	)
 
 	signers := append(
@@ -350,6 +356,51 @@ func TestRetrievePubkey_ActionFailed(t *testing.T) {
 	}
 }
 
+func TestRetrievePubkey_ActionFailed_Metrics(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	tbtcChain := local.NewTBTCLocalChain(ctx)
+	tbtc := newTestTBTC(tbtcChain)
+
+	tbtc.monitorRetrievePubKey(
+		ctx,
+		constantBackoff,
+		timeout,
+		maxActAttempts,
+	)
+
+	signers := append(
+		[]common.Address{tbtcChain.OperatorAddress()},
+		local.RandomSigningGroup(2)...,
+	)
+
+	tbtcChain.CreateDeposit(depositAddress, signers)
+
+	// do not submit the keep public key intentionally to cause
+	// the action error
+
+	// wait a bit longer than the monitoring timeout
+	// to make sure the potential transaction completes
+	time.Sleep(2 * timeout)
+
+	expectedMetrics := MonitoringMetrics{
+		Attempts:  3,
+		Successes: 0,
+		Failures:  3,
+	}
+	actualMetrics := tbtc.metrics.Snapshot()["retrieve pubkey"]
+	if expectedMetrics != actualMetrics {
+		t.Errorf(
+			"unexpected retrieve pubkey monitoring metrics\n"+
+				"expected: [%+v]\n"+
+				"actual:   [%+v]",
+			expectedMetrics,
+			actualMetrics,
+		)
+	}
+}
+
 func TestRetrievePubkey_ContextCancelled_WithoutWorkingMonitoring(t *testing.T) {
 	ctx, cancelCtx := context.WithCancel(context.Background())
 	defer cancelCtx()
@@ -361,6 +412,7 @@ func TestRetrievePubkey_ContextCancelled_WithoutWorkingMonitoring(t *testing.T)
 		ctx,
 		constantBackoff,
 		timeout,
+		maxActAttempts,
 	)
 
 	// cancel the context before any start event occurs
@@ -402,6 +454,7 @@ func TestRetrievePubkey_ContextCancelled_WithWorkingMonitoring(t *testing.T) {
 		ctx,
 		constantBackoff,
 		timeout,
+		maxActAttempts,
 	)
 
 	signers := append(
@@ -449,6 +502,7 @@ func TestRetrievePubkey_OperatorNotInSigningGroup(t *testing.T) {
 		ctx,
 		constantBackoff,
 		timeout,
+		maxActAttempts,
 	)
 
 	signers := local.RandomSigningGroup(3)
@@ -485,6 +539,7 @@ func TestProvideRedemptionSignature_TimeoutElapsed(t *testing.T) {
 		ctx,
 		constantBackoff,
 		timeout,
+		maxActAttempts,
 	)
 
 	signers := append(
@@ -549,6 +604,69 @@ func TestProvideRedemptionSignature_TimeoutElapsed(t *testing.T) {
 	}
 }
 
+func TestProvideRedemptionSignature_ConcurrentAttempts(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	tbtcChain := local.NewTBTCLocalChain(ctx)
+	tbtc := newTestTBTC(tbtcChain)
+
+	// Waiting for a block confirmation keeps the first attempt in flight
+	// long enough for the second one to be triggered.
+	tbtc.blockConfirmations = 1
+
+	signers := append(
+		[]common.Address{tbtcChain.OperatorAddress()},
+		local.RandomSigningGroup(2)...,
+	)
+
+	tbtcChain.CreateDeposit(depositAddress, signers)
+	tbtcChain.FundDeposit(depositAddress)
+
+	_, err := submitKeepPublicKey(depositAddress, tbtcChain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = tbtcChain.RedeemDeposit(depositAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = submitKeepSignature(depositAddress, tbtcChain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// trigger two attempts back-to-back
+	errChan := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			errChan <- tbtc.provideRedemptionSignature(depositAddress)
+		}()
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := <-errChan; err != nil {
+			t.Errorf("unexpected error: [%v]", err)
+		}
+	}
+
+	expectedProvideRedemptionSignatureCalls := 1
+	actualProvideRedemptionSignatureCalls := tbtcChain.Logger().
+		ProvideRedemptionSignatureCalls()
+	if expectedProvideRedemptionSignatureCalls !=
+		actualProvideRedemptionSignatureCalls {
+		t.Errorf(
+			"unexpected number of ProvideRedemptionSignature calls\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedProvideRedemptionSignatureCalls,
+			actualProvideRedemptionSignatureCalls,
+		)
+	}
+}
+
 func TestProvideRedemptionSignature_StopEventOccurred_DepositGotRedemptionSignature(
 	t *testing.T,
 ) {
@@ -562,6 +680,7 @@ func TestProvideRedemptionSignature_StopEventOccurred_DepositGotRedemptionSignat
 		ctx,
 		constantBackoff,
 		timeout,
+		maxActAttempts,
 	)
 
 	signers := append(
@@ -654,6 +773,7 @@ func TestProvideRedemptionSignature_StopEventOccurred_DepositRedeemed(
 		ctx,
 		constantBackoff,
 		timeout,
+		maxActAttempts,
 	)
 
 	signers := append(
@@ -737,6 +857,7 @@ func TestProvideRedemptionSignature_KeepClosedEventOccurred(t *testing.T) {
 		ctx,
 		constantBackoff,
 		timeout,
+		maxActAttempts,
 	)
 
 	signers := append(
@@ -817,6 +938,7 @@ func TestProvideRedemptionSignature_KeepTerminatedEventOccurred(t *testing.T) {
 		ctx,
 		constantBackoff,
 		timeout,
+		maxActAttempts,
 	)
 
 	signers := append(
@@ -897,6 +1019,7 @@ func TestProvideRedemptionSignature_ActionFailed(t *testing.T) {
 		ctx,
 		constantBackoff,
 		timeout,
+		maxActAttempts,
 	)
 
 	signers := append(
@@ -957,6 +1080,7 @@ func TestProvideRedemptionSignature_ContextCancelled_WithoutWorkingMonitoring(
 		ctx,
 		constantBackoff,
 		timeout,
+		maxActAttempts,
 	)
 
 	// cancel the context before any start event occurs
@@ -1012,6 +1136,7 @@ func TestProvideRedemptionSignature_ContextCancelled_WithWorkingMonitoring(
 		ctx,
 		constantBackoff,
 		timeout,
+		maxActAttempts,
 	)
 
 	signers := append(
@@ -1072,6 +1197,7 @@ func TestProvideRedemptionSignature_OperatorNotInSigningGroup(
 		ctx,
 		constantBackoff,
 		timeout,
+		maxActAttempts,
 	)
 
 	signers := local.RandomSigningGroup(3)
@@ -1124,6 +1250,7 @@ func TestProvideRedemptionProof_TimeoutElapsed(t *testing.T) {
 		ctx,
 		constantBackoff,
 		timeout,
+		maxActAttempts,
 	)
 
 	signers := append(
@@ -1212,6 +1339,76 @@ func TestProvideRedemptionProof_TimeoutElapsed(t *testing.T) {
 	}
 }
 
+func TestProvideRedemptionProof_RedemptionProofDeadlineElapsed(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	tbtcChain := local.NewTBTCLocalChain(ctx)
+	tbtc := newTestTBTC(tbtcChain)
+
+	// The deadline derived from the on-chain redemption proof timeout and
+	// the redemption request timestamp should take precedence over the
+	// static timeout which is way longer.
+	tbtcChain.SetRedemptionProofTimeout(redemptionProofTimeoutMargin + timeout)
+
+	tbtc.monitorProvideRedemptionProof(
+		ctx,
+		constantBackoff,
+		1*time.Hour,
+		maxActAttempts,
+	)
+
+	signers := append(
+		[]common.Address{tbtcChain.OperatorAddress()},
+		local.RandomSigningGroup(2)...,
+	)
+
+	tbtcChain.CreateDeposit(depositAddress, signers)
+	tbtcChain.FundDeposit(depositAddress)
+
+	_, err := submitKeepPublicKey(depositAddress, tbtcChain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = tbtcChain.RedeemDeposit(depositAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keepSignature, err := submitKeepSignature(depositAddress, tbtcChain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = tbtcChain.ProvideRedemptionSignature(
+		depositAddress,
+		keepSignature.V,
+		keepSignature.R,
+		keepSignature.S,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// wait a bit longer than the redemption proof deadline
+	// to make sure the potential transaction completes
+	time.Sleep(2 * timeout)
+
+	expectedIncreaseRedemptionFeeCalls := 1
+	actualIncreaseRedemptionFeeCalls := tbtcChain.Logger().
+		IncreaseRedemptionFeeCalls()
+	if expectedIncreaseRedemptionFeeCalls != actualIncreaseRedemptionFeeCalls {
+		t.Errorf(
+			"unexpected number of IncreaseRedemptionFee calls\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedIncreaseRedemptionFeeCalls,
+			actualIncreaseRedemptionFeeCalls,
+		)
+	}
+}
+
 func TestProvideRedemptionProof_StopEventOccurred_DepositRedemptionRequested(
 	t *testing.T,
 ) {
@@ -1225,6 +1422,7 @@ func TestProvideRedemptionProof_StopEventOccurred_DepositRedemptionRequested(
 		ctx,
 		constantBackoff,
 		timeout,
+		maxActAttempts,
 	)
 
 	signers := append(
@@ -1337,6 +1535,7 @@ func TestProvideRedemptionProof_StopEventOccurred_DepositRedeemed(
 		ctx,
 		constantBackoff,
 		timeout,
+		maxActAttempts,
 	)
 
 	signers := append(
@@ -1429,6 +1628,7 @@ func TestProvideRedemptionProof_KeepClosedEventOccurred(t *testing.T) {
 		ctx,
 		constantBackoff,
 		timeout,
+		maxActAttempts,
 	)
 
 	signers := append(
@@ -1526,6 +1726,7 @@ func TestProvideRedemptionProof_KeepTerminatedEventOccurred(t *testing.T) {
 		ctx,
 		constantBackoff,
 		timeout,
+		maxActAttempts,
 	)
 
 	signers := append(
@@ -1623,6 +1824,7 @@ func TestProvideRedemptionProof_ActionFailed(t *testing.T) {
 		ctx,
 		constantBackoff,
 		timeout,
+		maxActAttempts,
 	)
 
 	signers := append(
@@ -1692,6 +1894,7 @@ func TestProvideRedemptionProof_ContextCancelled_WithoutWorkingMonitoring(
 		ctx,
 		constantBackoff,
 		timeout,
+		maxActAttempts,
 	)
 
 	// cancel the context before any start event occurs
@@ -1761,6 +1964,7 @@ func TestProvideRedemptionProof_ContextCancelled_WithWorkingMonitoring(
 		ctx,
 		constantBackoff,
 		timeout,
+		maxActAttempts,
 	)
 
 	signers := append(
@@ -1835,6 +2039,7 @@ func TestProvideRedemptionProof_OperatorNotInSigningGroup(
 		ctx,
 		constantBackoff,
 		timeout,
+		maxActAttempts,
 	)
 
 	signers := local.RandomSigningGroup(3)
@@ -1914,16 +2119,20 @@ func TestMonitorAndActDeduplication(t *testing.T) {
 		return subscription.NewEventSubscription(func() {})
 	}
 
-	keepClosedFn := func(depositAddress string) (chan struct{}, func(), error) {
-		return make(chan struct{}), func() {}, nil
+	keepClosedFn := func(depositAddress string) (chan StopReason, func(), error) {
+		return make(chan StopReason), func() {}, nil
 	}
 
 	var actCounter uint64
-	actFn := func(depositAddress string) error {
+	actFn := func(ctx context.Context, depositAddress string) error {
 		atomic.AddUint64(&actCounter, 1)
 		return nil
 	}
 
+	shouldStopFn := func(depositAddress string) (bool, error) {
+		return false, nil
+	}
+
 	timeoutFn := func(depositAddress string) (duration time.Duration, e error) {
 		return timeout, nil
 	}
@@ -1936,7 +2145,9 @@ func TestMonitorAndActDeduplication(t *testing.T) {
 		monitoringStopFn,
 		keepClosedFn,
 		actFn,
+		shouldStopFn,
 		constantBackoff,
+		maxActAttempts,
 		timeoutFn,
 	)
 	defer monitoringSubscription.Unsubscribe()
@@ -1957,6 +2168,770 @@ func TestMonitorAndActDeduplication(t *testing.T) {
 	}
 }
 
+func TestMonitorDeposit_ActionRetried(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	// scripted action failing twice and succeeding afterwards
+	var actCounter uint64
+	actFn := func(ctx context.Context, depositAddress string) error {
+		if atomic.AddUint64(&actCounter, 1) <= 2 {
+			return fmt.Errorf("scripted failure")
+		}
+		return nil
+	}
+
+	var shouldStopCounter uint64
+	shouldStop := func() (bool, error) {
+		atomic.AddUint64(&shouldStopCounter, 1)
+		return false, nil
+	}
+
+	metrics := newMetrics()
+
+	stopReason := monitorDeposit(
+		ctx,
+		newMonitoringLog(
+			logger,
+			monitoringLogFields{
+				monitorType:    "monitoring",
+				depositAddress: "deposit",
+			},
+		),
+		"deposit",
+		make(chan struct{}),
+		make(chan StopReason),
+		0,
+		actFn,
+		shouldStop,
+		constantBackoff,
+		maxActAttempts,
+		nil,
+		defaultClassifyError,
+		metrics.monitoring("monitoring"),
+	)
+
+	if stopReason != StopReasonActionPerformed {
+		t.Errorf(
+			"unexpected stop reason\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			StopReasonActionPerformed,
+			stopReason,
+		)
+	}
+
+	expectedActCounter := uint64(3)
+	if actCounter != expectedActCounter {
+		t.Errorf(
+			"unexpected number of action invocations\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedActCounter,
+			actCounter,
+		)
+	}
+
+	if shouldStopCounter != expectedActCounter {
+		t.Errorf(
+			"unexpected number of stop checks\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedActCounter,
+			shouldStopCounter,
+		)
+	}
+
+	expectedMetrics := MonitoringMetrics{
+		Attempts:  3,
+		Successes: 1,
+		Failures:  2,
+	}
+	actualMetrics := metrics.Snapshot()["monitoring"]
+	if expectedMetrics != actualMetrics {
+		t.Errorf(
+			"unexpected monitoring metrics\n"+
+				"expected: [%+v]\n"+
+				"actual:   [%+v]",
+			expectedMetrics,
+			actualMetrics,
+		)
+	}
+}
+
+func TestMonitorDeposit_ShouldStop(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	var actCounter uint64
+	actFn := func(ctx context.Context, depositAddress string) error {
+		atomic.AddUint64(&actCounter, 1)
+		return nil
+	}
+
+	shouldStop := func() (bool, error) {
+		return true, nil
+	}
+
+	stopReason := monitorDeposit(
+		ctx,
+		newMonitoringLog(
+			logger,
+			monitoringLogFields{
+				monitorType:    "monitoring",
+				depositAddress: "deposit",
+			},
+		),
+		"deposit",
+		make(chan struct{}),
+		make(chan StopReason),
+		0,
+		actFn,
+		shouldStop,
+		constantBackoff,
+		maxActAttempts,
+		nil,
+		defaultClassifyError,
+		newMetrics().monitoring("monitoring"),
+	)
+
+	if stopReason != StopReasonActionNotNeeded {
+		t.Errorf(
+			"unexpected stop reason\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			StopReasonActionNotNeeded,
+			stopReason,
+		)
+	}
+
+	if actCounter != 0 {
+		t.Errorf(
+			"unexpected number of action invocations\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			0,
+			actCounter,
+		)
+	}
+}
+
+func TestMonitorDeposit_MaxAttemptsReached(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	var actCounter uint64
+	actFn := func(ctx context.Context, depositAddress string) error {
+		atomic.AddUint64(&actCounter, 1)
+		return fmt.Errorf("scripted failure")
+	}
+
+	shouldStop := func() (bool, error) {
+		return false, nil
+	}
+
+	stopReason := monitorDeposit(
+		ctx,
+		newMonitoringLog(
+			logger,
+			monitoringLogFields{
+				monitorType:    "monitoring",
+				depositAddress: "deposit",
+			},
+		),
+		"deposit",
+		make(chan struct{}),
+		make(chan StopReason),
+		0,
+		actFn,
+		shouldStop,
+		constantBackoff,
+		2,
+		nil,
+		defaultClassifyError,
+		newMetrics().monitoring("monitoring"),
+	)
+
+	if stopReason != StopReasonActionGaveUp {
+		t.Errorf(
+			"unexpected stop reason\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			StopReasonActionGaveUp,
+			stopReason,
+		)
+	}
+
+	expectedActCounter := uint64(2)
+	if actCounter != expectedActCounter {
+		t.Errorf(
+			"unexpected number of action invocations\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedActCounter,
+			actCounter,
+		)
+	}
+}
+
+func TestMonitorDeposit_DepositNotFound(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	var actCounter uint64
+	actFn := func(ctx context.Context, depositAddress string) error {
+		atomic.AddUint64(&actCounter, 1)
+		return fmt.Errorf(
+			"no deposit with address [%v]: [%w]",
+			depositAddress,
+			chain.ErrDepositNotFound,
+		)
+	}
+
+	shouldStop := func() (bool, error) {
+		return false, nil
+	}
+
+	stopReason := monitorDeposit(
+		ctx,
+		newMonitoringLog(
+			logger,
+			monitoringLogFields{
+				monitorType:    "monitoring",
+				depositAddress: "deposit",
+			},
+		),
+		"deposit",
+		make(chan struct{}),
+		make(chan StopReason),
+		0,
+		actFn,
+		shouldStop,
+		constantBackoff,
+		maxActAttempts,
+		nil,
+		defaultClassifyError,
+		newMetrics().monitoring("monitoring"),
+	)
+
+	if stopReason != StopReasonDepositNotFound {
+		t.Errorf(
+			"unexpected stop reason\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			StopReasonDepositNotFound,
+			stopReason,
+		)
+	}
+
+	expectedActCounter := uint64(1)
+	if actCounter != expectedActCounter {
+		t.Errorf(
+			"unexpected number of action invocations\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedActCounter,
+			actCounter,
+		)
+	}
+}
+
+func TestMonitorDeposit_UnlimitedAttempts(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	// scripted action failing more times than the default attempts cap
+	failures := uint64(maxActAttempts + 2)
+	var actCounter uint64
+	actFn := func(ctx context.Context, depositAddress string) error {
+		if atomic.AddUint64(&actCounter, 1) <= failures {
+			return fmt.Errorf("scripted failure")
+		}
+		return nil
+	}
+
+	shouldStop := func() (bool, error) {
+		return false, nil
+	}
+
+	stopReason := monitorDeposit(
+		ctx,
+		newMonitoringLog(
+			logger,
+			monitoringLogFields{
+				monitorType:    "monitoring",
+				depositAddress: "deposit",
+			},
+		),
+		"deposit",
+		make(chan struct{}),
+		make(chan StopReason),
+		0,
+		actFn,
+		shouldStop,
+		constantBackoff,
+		0,
+		nil,
+		defaultClassifyError,
+		newMetrics().monitoring("monitoring"),
+	)
+
+	if stopReason != StopReasonActionPerformed {
+		t.Errorf(
+			"unexpected stop reason\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			StopReasonActionPerformed,
+			stopReason,
+		)
+	}
+
+	expectedActCounter := failures + 1
+	if actCounter != expectedActCounter {
+		t.Errorf(
+			"unexpected number of action invocations\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedActCounter,
+			actCounter,
+		)
+	}
+}
+
+func TestOnMonitoringStopped_KeepClosedEventOccurred(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	tbtcChain := local.NewTBTCLocalChain(ctx)
+	tbtc := newTestTBTC(tbtcChain)
+
+	stopReasonChan := make(chan StopReason, 10)
+	tbtc.monitoringStoppedHandlers.register(
+		func(depositAddress string, reason StopReason) {
+			stopReasonChan <- reason
+		},
+	)
+
+	tbtc.monitorRetrievePubKey(
+		ctx,
+		constantBackoff,
+		timeout,
+		maxActAttempts,
+	)
+
+	signers := append(
+		[]common.Address{tbtcChain.OperatorAddress()},
+		local.RandomSigningGroup(2)...,
+	)
+
+	tbtcChain.CreateDeposit(depositAddress, signers)
+
+	_, err := submitKeepPublicKey(depositAddress, tbtcChain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// wait a while before triggering the keep closed event because the
+	// extension must have time to handle the start event
+	time.Sleep(100 * time.Millisecond)
+
+	err = closeKeep(depositAddress, tbtcChain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// wait a bit longer than the monitoring timeout
+	// to make sure the potential transaction completes
+	time.Sleep(2 * timeout)
+
+	assertStopReasons(t, stopReasonChan, StopReasonKeepClosed)
+}
+
+func TestOnMonitoringStopped_TimeoutElapsed(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	tbtcChain := local.NewTBTCLocalChain(ctx)
+	tbtc := newTestTBTC(tbtcChain)
+
+	stopReasonChan := make(chan StopReason, 10)
+	tbtc.monitoringStoppedHandlers.register(
+		func(depositAddress string, reason StopReason) {
+			stopReasonChan <- reason
+		},
+	)
+
+	tbtc.monitorRetrievePubKey(
+		ctx,
+		constantBackoff,
+		timeout,
+		maxActAttempts,
+	)
+
+	signers := append(
+		[]common.Address{tbtcChain.OperatorAddress()},
+		local.RandomSigningGroup(2)...,
+	)
+
+	tbtcChain.CreateDeposit(depositAddress, signers)
+
+	_, err := submitKeepPublicKey(depositAddress, tbtcChain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// wait a bit longer than the monitoring timeout
+	// to make sure the potential transaction completes
+	time.Sleep(2 * timeout)
+
+	assertStopReasons(t, stopReasonChan, StopReasonActionPerformed)
+}
+
+func TestOnMonitoringStopped_Unsubscribe(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	tbtcChain := local.NewTBTCLocalChain(ctx)
+	tbtc := newTestTBTC(tbtcChain)
+
+	stopReasonChan := make(chan StopReason, 10)
+	handlerSubscription := tbtc.monitoringStoppedHandlers.register(
+		func(depositAddress string, reason StopReason) {
+			stopReasonChan <- reason
+		},
+	)
+	handlerSubscription.Unsubscribe()
+
+	tbtc.monitoringStoppedHandlers.notify(
+		depositAddress,
+		StopReasonActionPerformed,
+	)
+
+	// wait a while to let the potential handler run
+	time.Sleep(100 * time.Millisecond)
+
+	assertStopReasons(t, stopReasonChan)
+}
+
+// assertStopReasons checks that exactly the expected stop reasons, in the
+// given order, have been reported to the given channel.
+func assertStopReasons(
+	t *testing.T,
+	stopReasonChan chan StopReason,
+	expectedReasons ...StopReason,
+) {
+	actualReasons := make([]StopReason, 0)
+	for {
+		select {
+		case reason := <-stopReasonChan:
+			actualReasons = append(actualReasons, reason)
+			continue
+		default:
+		}
+		break
+	}
+
+	if len(expectedReasons) != len(actualReasons) {
+		t.Fatalf(
+			"unexpected stop reasons\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedReasons,
+			actualReasons,
+		)
+	}
+
+	for i := range expectedReasons {
+		if expectedReasons[i] != actualReasons[i] {
+			t.Errorf(
+				"unexpected stop reason\n"+
+					"expected: [%v]\n"+
+					"actual:   [%v]",
+				expectedReasons[i],
+				actualReasons[i],
+			)
+		}
+	}
+}
+
+func TestStopMonitoringDeposit(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	tbtcChain := local.NewTBTCLocalChain(ctx)
+	tbtc := newTestTBTC(tbtcChain)
+
+	shouldMonitorFn := func(depositAddress string) bool {
+		return true
+	}
+
+	startEventHandlerChan := make(chan depositEventHandler, 1)
+	monitoringStartFn := func(
+		handler depositEventHandler,
+	) subscription.EventSubscription {
+		startEventHandlerChan <- handler
+		return subscription.NewEventSubscription(func() {})
+	}
+
+	monitoringStopFn := func(
+		handler depositEventHandler,
+	) subscription.EventSubscription {
+		return subscription.NewEventSubscription(func() {})
+	}
+
+	keepClosedFn := func(depositAddress string) (chan StopReason, func(), error) {
+		return make(chan StopReason), func() {}, nil
+	}
+
+	var actCounter uint64
+	actFn := func(ctx context.Context, depositAddress string) error {
+		atomic.AddUint64(&actCounter, 1)
+		return nil
+	}
+
+	shouldStopFn := func(depositAddress string) (bool, error) {
+		return false, nil
+	}
+
+	timeoutFn := func(depositAddress string) (duration time.Duration, e error) {
+		return timeout, nil
+	}
+
+	monitoringSubscription := tbtc.monitorAndAct(
+		ctx,
+		"monitoring",
+		shouldMonitorFn,
+		monitoringStartFn,
+		monitoringStopFn,
+		keepClosedFn,
+		actFn,
+		shouldStopFn,
+		constantBackoff,
+		maxActAttempts,
+		timeoutFn,
+	)
+	defer monitoringSubscription.Unsubscribe()
+
+	startEventHandler := <-startEventHandlerChan
+
+	startEventHandler("deposit")
+
+	// wait a while before stopping the monitoring because the
+	// extension must have time to handle the start event
+	time.Sleep(100 * time.Millisecond)
+
+	tbtc.stopMonitoringDeposit("deposit")
+
+	// wait a bit longer than the monitoring timeout
+	// to make sure the potential transaction completes
+	time.Sleep(2 * timeout)
+
+	expectedActCounter := uint64(0)
+	actualActCounter := atomic.LoadUint64(&actCounter)
+	if actualActCounter != expectedActCounter {
+		t.Errorf(
+			"unexpected number of action invocations after stop\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedActCounter,
+			actualActCounter,
+		)
+	}
+
+	// the same deposit should be monitored again after a new start event
+	startEventHandler("deposit")
+
+	// wait a bit longer than the monitoring timeout
+	// to make sure the potential transaction completes
+	time.Sleep(2 * timeout)
+
+	expectedActCounter = uint64(1)
+	actualActCounter = atomic.LoadUint64(&actCounter)
+	if actualActCounter != expectedActCounter {
+		t.Errorf(
+			"unexpected number of action invocations after restart\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedActCounter,
+			actualActCounter,
+		)
+	}
+}
+
+func TestStopMonitoringDeposit_UnknownDeposit(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	tbtcChain := local.NewTBTCLocalChain(ctx)
+	tbtc := newTestTBTC(tbtcChain)
+
+	// should not panic nor block
+	tbtc.stopMonitoringDeposit("unknown")
+
+	if len(tbtc.monitoringCancels) != 0 {
+		t.Errorf(
+			"unexpected number of registered monitorings\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			0,
+			len(tbtc.monitoringCancels),
+		)
+	}
+}
+
+func TestMonitorAndActConcurrencyLimit(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	tbtcChain := local.NewTBTCLocalChain(ctx)
+	tbtc := newTestTBTC(tbtcChain)
+
+	maxConcurrentMonitorings := 2
+	tbtc.monitoringLimiter = newDepositMonitoringLimiter(maxConcurrentMonitorings)
+
+	shouldMonitorFn := func(depositAddress string) bool {
+		return true
+	}
+
+	startEventHandlerChan := make(chan depositEventHandler, 1)
+	monitoringStartFn := func(
+		handler depositEventHandler,
+	) subscription.EventSubscription {
+		startEventHandlerChan <- handler
+		return subscription.NewEventSubscription(func() {})
+	}
+
+	monitoringStopFn := func(
+		handler depositEventHandler,
+	) subscription.EventSubscription {
+		return subscription.NewEventSubscription(func() {})
+	}
+
+	keepClosedFn := func(depositAddress string) (chan StopReason, func(), error) {
+		return make(chan StopReason), func() {}, nil
+	}
+
+	actFn := func(ctx context.Context, depositAddress string) error {
+		return nil
+	}
+
+	shouldStopFn := func(depositAddress string) (bool, error) {
+		return false, nil
+	}
+
+	// The timeout is determined once the monitoring becomes active so it is
+	// used to count promoted deposits. It is long enough for the monitorings
+	// to last until they are stopped.
+	var promotedCounter uint64
+	timeoutFn := func(depositAddress string) (duration time.Duration, e error) {
+		atomic.AddUint64(&promotedCounter, 1)
+		return 1 * time.Minute, nil
+	}
+
+	monitoringSubscription := tbtc.monitorAndAct(
+		ctx,
+		"monitoring",
+		shouldMonitorFn,
+		monitoringStartFn,
+		monitoringStopFn,
+		keepClosedFn,
+		actFn,
+		shouldStopFn,
+		constantBackoff,
+		maxActAttempts,
+		timeoutFn,
+	)
+	defer monitoringSubscription.Unsubscribe()
+
+	startEventHandler := <-startEventHandlerChan
+
+	depositsCount := 5
+	for i := 0; i < depositsCount; i++ {
+		startEventHandler(fmt.Sprintf("deposit-%v", i))
+	}
+
+	checkConcurrency := func() MonitoringConcurrency {
+		concurrency := tbtc.monitoringLimiter.concurrency()
+		if concurrency.Active > maxConcurrentMonitorings {
+			t.Fatalf(
+				"unexpected number of active monitorings\n"+
+					"expected at most: [%v]\n"+
+					"actual:           [%v]",
+				maxConcurrentMonitorings,
+				concurrency.Active,
+			)
+		}
+		return concurrency
+	}
+
+	// wait a while because the extension must have time
+	// to handle the start events
+	for i := 0; i < 10; i++ {
+		checkConcurrency()
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	expectedConcurrency := MonitoringConcurrency{
+		Active: maxConcurrentMonitorings,
+		Queued: depositsCount - maxConcurrentMonitorings,
+		Limit:  maxConcurrentMonitorings,
+	}
+	actualConcurrency := checkConcurrency()
+	if expectedConcurrency != actualConcurrency {
+		t.Errorf(
+			"unexpected monitoring concurrency\n"+
+				"expected: [%+v]\n"+
+				"actual:   [%+v]",
+			expectedConcurrency,
+			actualConcurrency,
+		)
+	}
+
+	// stop active monitorings one by one to promote the queued ones
+	for i := 0; i < depositsCount; i++ {
+		tbtc.monitoringLimiter.mutex.Lock()
+		var activeDeposit string
+		for depositAddress := range tbtc.monitoringLimiter.active {
+			activeDeposit = depositAddress
+			break
+		}
+		tbtc.monitoringLimiter.mutex.Unlock()
+
+		tbtc.stopMonitoringDeposit(activeDeposit)
+
+		for j := 0; j < 10; j++ {
+			checkConcurrency()
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	expectedPromotedCounter := uint64(depositsCount)
+	actualPromotedCounter := atomic.LoadUint64(&promotedCounter)
+	if expectedPromotedCounter != actualPromotedCounter {
+		t.Errorf(
+			"unexpected number of promoted monitorings\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedPromotedCounter,
+			actualPromotedCounter,
+		)
+	}
+
+	expectedConcurrency = MonitoringConcurrency{
+		Limit: maxConcurrentMonitorings,
+	}
+	actualConcurrency = checkConcurrency()
+	if expectedConcurrency != actualConcurrency {
+		t.Errorf(
+			"unexpected monitoring concurrency after stop\n"+
+				"expected: [%+v]\n"+
+				"actual:   [%+v]",
+			expectedConcurrency,
+			actualConcurrency,
+		)
+	}
+}
+
 func TestAcquireMonitoringLock(t *testing.T) {
 	ctx, cancelCtx := context.WithCancel(context.Background())
 	defer cancelCtx()
@@ -2,10 +2,12 @@ package metrics
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/ipfs/go-log"
 	"github.com/keep-network/keep-ecdsa/pkg/client"
+	"github.com/keep-network/keep-ecdsa/pkg/extensions/tbtc"
 
 	"github.com/keep-network/keep-common/pkg/metrics"
 )
@@ -39,6 +41,73 @@ func ObserveTSSPreParamsPoolSize(
 	)
 }
 
+// ObserveTBTCMonitorings triggers an observation process of the
+// tbtc_<monitoring>_attempts, tbtc_<monitoring>_successes and
+// tbtc_<monitoring>_failures metrics for all monitorings of the TBTC
+// extension, along with the tbtc_monitorings_active and
+// tbtc_monitorings_queued metrics. It is a no-op if the extension has not
+// been initialized.
+func ObserveTBTCMonitorings(
+	ctx context.Context,
+	registry *metrics.Registry,
+	clientHandle *client.Handle,
+	tick time.Duration,
+) {
+	tbtcExtension := clientHandle.TBTCExtension()
+	if tbtcExtension == nil {
+		return
+	}
+
+	tick = validateTick(tick, DefaultClientMetricsTick)
+
+	for _, monitoringName := range tbtc.MonitoringNames {
+		monitoringName := monitoringName
+		metricPrefix := "tbtc_" + strings.ReplaceAll(monitoringName, " ", "_")
+
+		inputs := map[string]metrics.ObserverInput{
+			"attempts": func() float64 {
+				return float64(
+					tbtcExtension.Metrics().Snapshot()[monitoringName].Attempts,
+				)
+			},
+			"successes": func() float64 {
+				return float64(
+					tbtcExtension.Metrics().Snapshot()[monitoringName].Successes,
+				)
+			},
+			"failures": func() float64 {
+				return float64(
+					tbtcExtension.Metrics().Snapshot()[monitoringName].Failures,
+				)
+			},
+		}
+
+		for suffix, input := range inputs {
+			observe(ctx, metricPrefix+"_"+suffix, input, registry, tick)
+		}
+	}
+
+	observe(
+		ctx,
+		"tbtc_monitorings_active",
+		func() float64 {
+			return float64(tbtcExtension.MonitoringConcurrency().Active)
+		},
+		registry,
+		tick,
+	)
+
+	observe(
+		ctx,
+		"tbtc_monitorings_queued",
+		func() float64 {
+			return float64(tbtcExtension.MonitoringConcurrency().Queued)
+		},
+		registry,
+		tick,
+	)
+}
+
 func observe(
 	ctx context.Context,
 	name string,